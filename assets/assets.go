@@ -0,0 +1,11 @@
+// Package assets embeds the default data pack, so the program works without
+// the repository assets folder.
+package assets
+
+import "embed"
+
+// Data holds the default data files under the data directory, and the
+// locale string tables under its locales directory.
+//
+//go:embed data/*.json data/locales/*.json
+var Data embed.FS
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/monster"
+)
+
+// Constants for the bestiary data file and command usage.
+const (
+	bestiary_file  = "bestiary.json"
+	bestiary_usage = "bestiary list | bestiary show <name>"
+)
+
+// loadBestiary loads the bestiary from the data search path.
+// Returns the loaded Bestiary and any error encountered.
+func loadBestiary() (*monster.Bestiary, error) {
+	content, err := readDataFile(bestiary_file)
+	if err != nil {
+		return nil, err
+	}
+	return monster.ParseBestiary(content)
+}
+
+// runBestiary runs the bestiary subcommand.
+// "list" prints every monster with its challenge rating, and "show <name>"
+// prints the stat block of a monster.
+// It returns the process exit code.
+func runBestiary(args []string) int {
+	if len(args) == 0 {
+		printCommandUsage(bestiary_usage)
+		return 2
+	}
+	bestiary, err := loadBestiary()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	switch args[0] {
+	case "list":
+		for _, name := range bestiary.Names() {
+			m, _ := bestiary.Get(name)
+			fmt.Printf("%-24s CR %-4s %6d XP\n", m.Name, m.ChallengeRating, m.XP())
+		}
+	case "show":
+		if len(args) < 2 {
+			printCommandUsage(bestiary_usage)
+			return 2
+		}
+		name := strings.Join(args[1:], " ")
+		m, ok := bestiary.Get(name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "monster %q not found in bestiary\n", name)
+			return 1
+		}
+		fmt.Print(m.StatBlock())
+	default:
+		printCommandUsage(bestiary_usage)
+		return 2
+	}
+	return 0
+}
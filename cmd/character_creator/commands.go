@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command represents a CLI subcommand.
+// Run receives the arguments after the command name and returns the process
+// exit code.
+type command struct {
+	usage string
+	run   func(args []string) int
+}
+
+// commands maps each subcommand name to its command.
+// Running the program without a subcommand starts the interactive character
+// creation.
+var commands = map[string]command{
+	"bestiary":  {usage: bestiary_usage, run: runBestiary},
+	"compare":   {usage: compare_usage, run: runCompare},
+	"convert":   {usage: convert_usage, run: runConvert},
+	"create":    {usage: create_usage, run: runCreate},
+	"data":      {usage: data_usage, run: runData},
+	"diff":      {usage: diff_usage, run: runDiff},
+	"encounter": {usage: encounter_usage, run: runEncounter},
+	"feat":      {usage: feat_usage, run: runFeat},
+	"history":   {usage: history_usage, run: runHistory},
+	"levelup":   {usage: levelup_usage, run: runLevelUp},
+	"locale":    {usage: locale_usage, run: runLocale},
+	"redo":      {usage: redo_usage, run: runRedo},
+	"replay":    {usage: replay_usage, run: runReplay},
+	"rest":      {usage: rest_usage, run: runRest},
+	"roster":    {usage: roster_usage, run: runRoster},
+	"sheet":     {usage: sheet_usage, run: runSheet},
+	"undo":      {usage: undo_usage, run: runUndo},
+}
+
+// printUsage prints the usage of every subcommand to the standard error.
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  character_creator [-data dir] [-lang locale]            create a new character")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  character_creator [-data dir] [-lang locale] %s\n", commands[name].usage)
+	}
+	fmt.Fprintf(os.Stderr, "Data files are read from the -data directory, the %s directories, the %s\n", data_env, data_config_path)
+	fmt.Fprintln(os.Stderr, "folder of the user config directory and the built-in data, in that order.")
+}
+
+// printCommandUsage prints the usage of a single subcommand to the standard error.
+func printCommandUsage(usage string) {
+	fmt.Fprintf(os.Stderr, "Usage: character_creator %s\n", usage)
+}
+
+// runCommand runs the subcommand named by the first argument.
+// It returns the process exit code.
+func runCommand(args []string) int {
+	cmd, ok := commands[strings.ToLower(args[0])]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		printUsage()
+		return 2
+	}
+	return cmd.run(args[1:])
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/dataformat"
+)
+
+// convert_usage is the usage of the convert command.
+const convert_usage = "convert [-sparse] <input> <output>"
+
+// runConvert runs the convert subcommand.
+// It converts a content file between JSON, YAML and TOML, the formats being
+// chosen by the file extensions. With -sparse the input is read as
+// character creation data and written without its zero attribute rewards
+// and fail penalties.
+// It returns the process exit code.
+func runConvert(args []string) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	sparse := flags.Bool("sparse", false, "write character creation data without zero attribute entries")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		printCommandUsage(convert_usage)
+		return 2
+	}
+	input, output := flags.Arg(0), flags.Arg(1)
+	content, err := dataformat.ReadFile(input)
+	if err == nil && *sparse {
+		var data *character.CharacterCreationData
+		if data, err = character.ParseCharacterData(content); err == nil {
+			content, err = json.Marshal(data)
+		}
+	}
+	if err == nil {
+		err = dataformat.WriteFile(output, content)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("Converted %s to %s\n", input, output)
+	return 0
+}
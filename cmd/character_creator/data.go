@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/datapack"
+)
+
+// Constants for the data pack environment variable, the data pack folder in
+// the user config directory and the data command usage.
+const (
+	data_env         = "DANDD_DATA"
+	data_config_path = "dandd/data"
+	data_usage       = "data list | data merge"
+)
+
+// mergedFiles lists the data files merged from every pack instead of read
+// from the first pack that has them.
+var mergedFiles = []string{character_creation_file}
+
+// dataDir is the data pack directory given with the -data flag.
+var dataDir string
+
+// dataSearchPath returns the data packs in the order files are searched:
+// the -data flag directory, the directories listed in DANDD_DATA, the
+// dandd/data folder of the user config directory ($XDG_CONFIG_HOME on
+// Linux) and the default pack embedded in the binary.
+func dataSearchPath() datapack.SearchPath {
+	var path datapack.SearchPath
+	if dataDir != "" {
+		path = append(path, datapack.Dir("flag", dataDir))
+	}
+	for _, dir := range filepath.SplitList(os.Getenv(data_env)) {
+		if dir != "" {
+			path = append(path, datapack.Dir("env", dir))
+		}
+	}
+	if config, err := os.UserConfigDir(); err == nil {
+		path = append(path, datapack.Dir("config", filepath.Join(config, data_config_path)))
+	}
+	return append(path, datapack.Embedded())
+}
+
+// readDataFile returns the content of the named data file from the data
+// search path.
+func readDataFile(name string) ([]byte, error) {
+	content, _, err := dataSearchPath().ReadFile(name)
+	return content, err
+}
+
+// mergeCharacterData merges the character data of every pack of the data
+// search path, applying the packs from the built-in data to the -data flag
+// directory, so earlier packs of the search path win.
+// Returns the merged data, the merge report and any error encountered.
+func mergeCharacterData() (*character.CharacterCreationData, character.MergeReport, error) {
+	files, err := dataSearchPath().ReadAll(character_creation_file)
+	if err != nil {
+		return nil, character.MergeReport{}, err
+	}
+	packs := make([]character.CreationPack, 0, len(files))
+	for _, f := range slices.Backward(files) {
+		data, err := character.ParseCharacterData(f.Content)
+		if err != nil {
+			return nil, character.MergeReport{}, fmt.Errorf("%s from %s: %w", f.Name, f.Pack, err)
+		}
+		packs = append(packs, character.CreationPack{Name: f.Pack.String(), Data: data})
+	}
+	data, report := character.MergeCreationData(packs...)
+	return data, report, nil
+}
+
+// sameDataFile returns true if both names are the same data file, maybe in
+// different content file formats.
+func sameDataFile(a string, b string) bool {
+	return strings.TrimSuffix(a, filepath.Ext(a)) == strings.TrimSuffix(b, filepath.Ext(b))
+}
+
+// runData runs the data subcommand.
+// "list" prints the data search path and every data file with the pack it
+// is read from, and "merge" prints what each pack changed in the merged
+// character data and the conflicts between them.
+// It returns the process exit code.
+func runData(args []string) int {
+	if len(args) != 1 {
+		printCommandUsage(data_usage)
+		return 2
+	}
+	switch strings.ToLower(args[0]) {
+	case "list":
+		listData()
+	case "merge":
+		_, report, err := mergeCharacterData()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(report.Events) == 0 {
+			fmt.Println("No changes to the built-in character data")
+		}
+		for _, event := range report.Events {
+			fmt.Println(event)
+		}
+		for _, conflict := range report.Conflicts {
+			fmt.Printf("conflict: %s\n", conflict)
+		}
+	default:
+		printCommandUsage(data_usage)
+		return 2
+	}
+	return 0
+}
+
+// listData prints the data search path and every data file with the pack
+// it is read from.
+func listData() {
+	path := dataSearchPath()
+	fmt.Println("Search path:")
+	for i, p := range path {
+		status := ""
+		if !p.Available() {
+			status = " (missing)"
+		}
+		fmt.Printf("  %d. %s%s\n", i+1, p, status)
+	}
+	fmt.Println("Files:")
+	for _, f := range append(path.Files("."), path.Files(locales_path)...) {
+		line := fmt.Sprintf("  %-24s %s", f.Name, f.Pack)
+		if len(f.Overridden) > 0 {
+			names := make([]string, len(f.Overridden))
+			for i, p := range f.Overridden {
+				names[i] = p.String()
+			}
+			verb := ", overrides "
+			if slices.ContainsFunc(mergedFiles, func(name string) bool { return sameDataFile(name, f.Name) }) {
+				verb = ", merged over "
+			}
+			line += verb + strings.Join(names, ", ")
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/sheet"
+)
+
+// Constants for the diff and compare command usages.
+const (
+	diff_usage    = "diff <old> <new> | diff -from seq [-to seq] <file>"
+	compare_usage = "compare <file> <file>..."
+)
+
+// runDiff runs the diff subcommand.
+// It reports the differences between two saved characters or, with -from,
+// between two versions of a character reconstructed from its journal, the
+// latest version by default.
+// It returns the process exit code.
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	from := flags.Int("from", -1, "journal entry of the old version, 0 for before any change")
+	to := flags.Int("to", -1, "journal entry of the new version, the latest by default")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	versions := *from >= 0
+	if (versions && flags.NArg() != 1) || (!versions && (flags.NArg() != 2 || *to >= 0)) {
+		printCommandUsage(diff_usage)
+		return 2
+	}
+	old, err := character.LoadCharacter(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	new := old
+	if versions {
+		if *to >= 0 {
+			new, err = old.Reconstruct(*to)
+		}
+		if err == nil {
+			old, err = old.Reconstruct(*from)
+		}
+	} else {
+		new, err = character.LoadCharacter(flags.Arg(1))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	d := character.Diff(old, new)
+	if d.IsEmpty() {
+		fmt.Println("No differences")
+		return 0
+	}
+	fmt.Print(d)
+	return 0
+}
+
+// compareRow is a row of the side by side comparison, with a label and
+// the value of each character.
+type compareRow struct {
+	label  string
+	values []string
+}
+
+// compareRows returns the rows comparing the sheets side by side.
+func compareRows(sheets []*sheet.Sheet) []compareRow {
+	row := func(label string, value func(s *sheet.Sheet) string) compareRow {
+		r := compareRow{label: label}
+		for _, s := range sheets {
+			r.values = append(r.values, value(s))
+		}
+		return r
+	}
+	rows := []compareRow{
+		row("Name", func(s *sheet.Sheet) string { return s.Name }),
+		row("Classes", func(s *sheet.Sheet) string { return s.Classes }),
+		row("Level", func(s *sheet.Sheet) string { return strconv.Itoa(s.Level) }),
+		row("Hit points", func(s *sheet.Sheet) string { return s.HitPoints }),
+		row("Armor class", func(s *sheet.Sheet) string { return strconv.Itoa(s.ArmorClass) }),
+		row("Initiative", func(s *sheet.Sheet) string { return fmt.Sprintf("%+d", s.Initiative) }),
+		row("Passive perception", func(s *sheet.Sheet) string { return strconv.Itoa(s.PassivePerception) }),
+	}
+	for i := range sheets[0].Abilities {
+		rows = append(rows, row(sheets[0].Abilities[i].Short, func(s *sheet.Sheet) string {
+			a := s.Abilities[i]
+			return fmt.Sprintf("%d (%+d, save %+d)", a.Score, a.Modifier, a.Save)
+		}))
+	}
+	rows = append(rows,
+		row("Skills", func(s *sheet.Sheet) string {
+			var skills []string
+			for _, sk := range s.Skills {
+				if sk.Proficient {
+					skills = append(skills, sk.Name)
+				}
+			}
+			return strings.Join(skills, ", ")
+		}),
+		row("Feats", func(s *sheet.Sheet) string { return strings.Join(s.Feats, ", ") }),
+		row("Items", func(s *sheet.Sheet) string { return strconv.Itoa(len(s.Equipment)) }),
+		row("Wallet", func(s *sheet.Sheet) string { return s.Wallet }),
+		row("Spells", func(s *sheet.Sheet) string {
+			if s.Spellcasting == nil {
+				return ""
+			}
+			return fmt.Sprintf("%d known, DC %d", len(s.Spellcasting.Spells), s.Spellcasting.SaveDC)
+		}),
+		row("Background", func(s *sheet.Sheet) string { return s.Backstory.Background }),
+	)
+	return rows
+}
+
+// runCompare runs the compare subcommand.
+// It prints several saved characters side by side, one column each, marking
+// with an asterisk the values that differ from the first character.
+// It returns the process exit code.
+func runCompare(args []string) int {
+	if len(args) < 2 {
+		printCommandUsage(compare_usage)
+		return 2
+	}
+	data := sheet.Data{Items: loadItemData(), Spells: loadSpellData(), Backgrounds: loadBackgroundData()}
+	var sheets []*sheet.Sheet
+	for _, fpath := range args {
+		c, err := character.LoadCharacter(fpath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		sheets = append(sheets, sheet.New(c, data))
+	}
+	rows := compareRows(sheets)
+	labelWidth := 0
+	widths := make([]int, len(sheets))
+	for _, r := range rows {
+		labelWidth = max(labelWidth, len(r.label))
+		for i, value := range r.values {
+			widths[i] = max(widths[i], len(value))
+		}
+	}
+	for _, r := range rows {
+		line := fmt.Sprintf("%-*s", labelWidth, r.label)
+		for i, value := range r.values {
+			mark := " "
+			if value != r.values[0] {
+				mark = "*"
+			}
+			line += fmt.Sprintf(" |%s%-*s", mark, widths[i], value)
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/encounter"
+	"github.com/jrecuero/DandD/internal/monster"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// encounter_usage is the usage of the encounter subcommand.
+const encounter_usage = "encounter rate -party <files> <monster>... | encounter build -party <files> [-difficulty d] [-env e] [-min-cr n] [-max-cr n] [-max-monsters n] [-seed n]"
+
+// loadParty loads the party from a comma separated list of character files.
+// Returns the loaded Party and any error encountered.
+func loadParty(files string) (*encounter.Party, error) {
+	var filenames []string
+	for _, name := range strings.Split(files, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			filenames = append(filenames, name)
+		}
+	}
+	return encounter.LoadParty(filenames...)
+}
+
+// displayEncounter prints the monsters and rating of an encounter.
+func displayEncounter(e *encounter.Encounter) {
+	fmt.Printf("Monsters: %s\n", e)
+	fmt.Printf("Party thresholds: %s\n", e.Rating.Thresholds)
+	fmt.Printf("XP: %d (x%.1f = %d adjusted)\n", e.Rating.BaseXP, e.Rating.Multiplier, e.Rating.AdjustedXP)
+	fmt.Printf("Difficulty: %s\n", e.Rating.Difficulty)
+}
+
+// runEncounter runs the encounter subcommand.
+// "rate" rates the given monsters against the party, and "build" randomly
+// assembles an encounter from the bestiary for the party.
+// It returns the process exit code.
+func runEncounter(args []string) int {
+	if len(args) == 0 {
+		printCommandUsage(encounter_usage)
+		return 2
+	}
+	flags := flag.NewFlagSet("encounter "+args[0], flag.ContinueOnError)
+	partyFiles := flags.String("party", "", "comma separated list of character files")
+	difficulty := flags.String("difficulty", "medium", "target difficulty: easy, medium, hard or deadly")
+	environment := flags.String("env", "", "environment of the monsters")
+	minCR := flags.Float64("min-cr", 0, "minimum challenge rating")
+	maxCR := flags.Float64("max-cr", 0, "maximum challenge rating, 0 for no limit")
+	maxMonsters := flags.Int("max-monsters", encounter.DefaultMaxMonsters, "maximum number of monsters")
+	seed := flags.Int64("seed", 0, "random seed, 0 for a random encounter")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	party, err := loadParty(*partyFiles)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	bestiary, err := loadBestiary()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	switch args[0] {
+	case "rate":
+		var monsters []*monster.Monster
+		for _, name := range flags.Args() {
+			m, ok := bestiary.Get(name)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "monster %q not found in bestiary\n", name)
+				return 1
+			}
+			monsters = append(monsters, m)
+		}
+		displayEncounter(&encounter.Encounter{Monsters: monsters, Rating: encounter.Rate(party, monsters)})
+	case "build":
+		target, err := encounter.ParseDifficulty(*difficulty)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		var roller dice.Roller
+		if *seed != 0 {
+			roller = dice.NewRandRoller(*seed)
+		}
+		opts := encounter.Options{Difficulty: target, Environment: *environment, MinCR: *minCR, MaxCR: *maxCR, MaxMonsters: *maxMonsters}
+		e, err := encounter.Build(bestiary, party, opts, roller)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		displayEncounter(e)
+	default:
+		printCommandUsage(encounter_usage)
+		return 2
+	}
+	return 0
+}
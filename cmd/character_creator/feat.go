@@ -0,0 +1,274 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/locale"
+)
+
+// Constants for the feat data file and command usages.
+const (
+	feats_file    = "feats.json"
+	feat_usage    = "feat list [-character file] | feat take [-increase attr] <file> <feat>"
+	levelup_usage = "levelup [-class job] [-skills a,b] [-improve STR:1,DEX:1] [-feat id] [-increase attr] <file>"
+)
+
+// loadFeatData loads the feat data from the data search path.
+// Returns the loaded FeatData and any error encountered.
+func loadFeatData() (*character.FeatData, error) {
+	content, err := readDataFile(feats_file)
+	if err != nil {
+		return nil, err
+	}
+	return character.ParseFeatData(content)
+}
+
+// parseAttribute parses an attribute short or full name.
+// An empty name returns the zero Attribute, for feats without a choice.
+func parseAttribute(name string) (character.Attribute, error) {
+	var attr character.Attribute
+	if name == "" {
+		return attr, nil
+	}
+	err := attr.UnmarshalText([]byte(name))
+	return attr, err
+}
+
+// displayFeats prints every feat, and when a character is given, whether
+// the character can take it or why not.
+func displayFeats(featData *character.FeatData, c *character.Character, l *locale.Localizer) {
+	for _, id := range featData.IDs() {
+		f, _ := featData.Get(id)
+		if c == nil {
+			fmt.Printf("%-20s %s\n", f.ID, f.Description)
+			continue
+		}
+		status := l.Message("feat_available")
+		if err := c.CanTakeFeat(f); err != nil {
+			status = err.Error()
+		}
+		fmt.Printf("%-20s %s\n", f.ID, status)
+	}
+}
+
+// runFeat runs the feat subcommand.
+// "list" prints every feat, with its availability for a character when one
+// is given, and "take" spends an ability score improvement of a saved
+// character on a feat.
+// It returns the process exit code.
+func runFeat(args []string) int {
+	if len(args) == 0 {
+		printCommandUsage(feat_usage)
+		return 2
+	}
+	flags := flag.NewFlagSet("feat "+args[0], flag.ContinueOnError)
+	file := flags.String("character", "", "character file to check prerequisites against")
+	increase := flags.String("increase", "", "attribute to increase for feats with a choice")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	featData, err := loadFeatData()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	switch args[0] {
+	case "list":
+		var c *character.Character
+		if *file != "" {
+			if c, err = character.LoadCharacter(*file); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+		}
+		displayFeats(featData, c, loadLocalizer())
+		return 0
+	case "take":
+		if flags.NArg() != 2 {
+			printCommandUsage(feat_usage)
+			return 2
+		}
+		return takeFeat(flags.Arg(0), featData, flags.Arg(1), *increase)
+	}
+	printCommandUsage(feat_usage)
+	return 2
+}
+
+// takeFeat loads a saved character, spends an ability score improvement on
+// the feat and saves the character back to the same file.
+// It returns the process exit code.
+func takeFeat(fpath string, featData *character.FeatData, id string, increase string) int {
+	c, err := character.LoadCharacter(fpath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if code := applyFeat(c, featData, id, increase, true, loadLocalizer()); code != 0 {
+		return code
+	}
+	if err := character.SaveCharacter(fpath, c); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// applyFeat gives the character the feat with the given ID, spending an
+// ability score improvement when improvement is true, which is recorded in
+// the character journal.
+// It returns the process exit code.
+func applyFeat(c *character.Character, featData *character.FeatData, id string, increase string, improvement bool, l *locale.Localizer) int {
+	f, ok := featData.Get(id)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "feat %q not found\n", id)
+		return 1
+	}
+	attr, err := parseAttribute(increase)
+	if err == nil {
+		if improvement {
+			_, err = c.Record(journalAuthor(), "take feat "+f.ID, func(c *character.Character) error {
+				return c.TakeFeat(f, attr)
+			})
+		} else {
+			err = c.AddFeat(f, attr)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println(l.Message("feat_taken", c.Name, f.Name))
+	fmt.Println(l.Message("attributes", c.Attributes))
+	return 0
+}
+
+// parseSkills parses a comma separated list of skills.
+// An empty list returns no skills.
+func parseSkills(list string) ([]character.Skill, error) {
+	var skills []character.Skill
+	if list == "" {
+		return skills, nil
+	}
+	for _, name := range strings.Split(list, ",") {
+		skill, err := character.ParseSkill(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
+
+// runLevelUp runs the levelup subcommand.
+// It loads a saved character, raises its level in its first class or in
+// the given class, multiclassing with the given skills when the class is
+// new, and, when the new level grants an ability score improvement, spends
+// it on the given increases or feat. Every change is recorded in the
+// character journal, and the character is saved back to the same file.
+// It returns the process exit code.
+func runLevelUp(args []string) int {
+	flags := flag.NewFlagSet("levelup", flag.ContinueOnError)
+	class := flags.String("class", "", "class to take the level in, the first class by default")
+	skillList := flags.String("skills", "", "skills chosen when multiclassing into a new class")
+	improve := flags.String("improve", "", "ability score increases, as STR:1,DEX:1 or CON:2")
+	feat := flags.String("feat", "", "feat to take instead of an ability score improvement")
+	increase := flags.String("increase", "", "attribute to increase for feats with a choice")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 || (*improve != "" && *feat != "") {
+		printCommandUsage(levelup_usage)
+		return 2
+	}
+	fpath := flags.Arg(0)
+	c, err := character.LoadCharacter(fpath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	skills, err := parseSkills(*skillList)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	job := *class
+	if job == "" {
+		job = c.ClassLevels()[0].Job
+	}
+	var result character.LevelUpResult
+	_, err = c.Record(journalAuthor(), "level up in "+job, func(c *character.Character) error {
+		result, err = c.AddClassLevel(job, loadSpellData(), skills...)
+		return err
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("%s %s\n", c.Name, result)
+	if c.IsMulticlass() {
+		fmt.Println("Classes:", c.ClassString())
+	}
+	switch {
+	case *improve != "":
+		increases, err := character.ParseIncreases(*improve)
+		if err == nil {
+			_, err = c.Record(journalAuthor(), "ability score improvement "+*improve, func(c *character.Character) error {
+				return c.ImproveAbilities(increases)
+			})
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Println("Attributes:", c.Attributes)
+	case *feat != "":
+		featData, err := loadFeatData()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if code := applyFeat(c, featData, *feat, *increase, true, loadLocalizer()); code != 0 {
+			return code
+		}
+	}
+	if c.Improvements > 0 {
+		fmt.Printf("Unspent ability score improvements: %d\n", c.Improvements)
+	}
+	if err := character.SaveCharacter(fpath, c); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// chooseFeat lists the feats available to a new character and lets the
+// player pick one, or none with an empty answer.
+// Unavailable feats are listed with the reason they cannot be taken.
+func chooseFeat(c *character.Character, featData *character.FeatData, l *locale.Localizer, answer func() string) {
+	fmt.Println(l.Message("feats"))
+	displayFeats(featData, c, l)
+	for {
+		fmt.Print(l.Message("choose_feat"))
+		id := strings.TrimSpace(answer())
+		if id == "" {
+			return
+		}
+		f, ok := featData.Get(id)
+		if !ok {
+			fmt.Println(l.Message("unknown_feat", id))
+			continue
+		}
+		increase := ""
+		if len(f.Effects.IncreaseChoice) > 0 {
+			fmt.Print(l.Message("feat_increase", f.Effects.IncreaseChoice))
+			increase = strings.TrimSpace(answer())
+		}
+		if applyFeat(c, featData, id, increase, false, l) == 0 {
+			return
+		}
+	}
+}
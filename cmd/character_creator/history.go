@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// Constants for the history, undo and redo command usages.
+const (
+	history_usage = "history [-at seq -o file] <file>"
+	undo_usage    = "undo [-n count] <file>"
+	redo_usage    = "redo [-n count] <file>"
+)
+
+// journalAuthor returns the author recorded in the character journal, the
+// user running the program, or an empty string when it is not known so the
+// entries omit it.
+func journalAuthor() string {
+	return os.Getenv("USER")
+}
+
+// runHistory runs the history subcommand.
+// It prints the journal of a saved character or, with -at, writes the
+// character as it was right after the given journal entry to another file.
+// It returns the process exit code.
+func runHistory(args []string) int {
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	at := flags.Int("at", -1, "journal entry to reconstruct the character at, 0 for before any change")
+	output := flags.String("o", "", "file to write the reconstructed character to")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 || (*at >= 0) != (*output != "") {
+		printCommandUsage(history_usage)
+		return 2
+	}
+	c, err := character.LoadCharacter(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *at >= 0 {
+		past, err := c.Reconstruct(*at)
+		if err == nil {
+			err = character.SaveCharacter(*output, past)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Printf("%s at change #%d saved to %s\n", past, *at, *output)
+		return 0
+	}
+	if len(c.Journal.Changes) == 0 {
+		fmt.Printf("%s has no recorded changes\n", c.Name)
+		return 0
+	}
+	for _, ch := range c.Journal.Changes {
+		fmt.Println(ch)
+	}
+	return 0
+}
+
+// runUndo runs the undo subcommand.
+// It reverts the last changes recorded in the journal of a saved character
+// and saves the character back to the same file.
+// It returns the process exit code.
+func runUndo(args []string) int {
+	return runStep(args, "undo", undo_usage, (*character.Character).Undo)
+}
+
+// runRedo runs the redo subcommand.
+// It reapplies the last undone changes of a saved character and saves the
+// character back to the same file.
+// It returns the process exit code.
+func runRedo(args []string) int {
+	return runStep(args, "redo", redo_usage, (*character.Character).Redo)
+}
+
+// runStep runs the undo or redo subcommand with the given step function.
+// It returns the process exit code.
+func runStep(args []string, name string, usage string, step func(c *character.Character, n int, author string) ([]character.Change, error)) int {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	count := flags.Int("n", 1, "number of changes to "+name)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		printCommandUsage(usage)
+		return 2
+	}
+	fpath := flags.Arg(0)
+	c, err := character.LoadCharacter(fpath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	changes, err := step(c, *count, journalAuthor())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, ch := range changes {
+		fmt.Println(ch)
+	}
+	if err := character.SaveCharacter(fpath, c); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
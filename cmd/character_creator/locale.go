@@ -0,0 +1,179 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/locale"
+)
+
+// Constants for the locale tables folder in the data packs and the locale
+// command usage.
+const (
+	locales_path = "locales"
+	locale_usage = "locale list | locale check [lang]"
+)
+
+// lang is the locale given with the -lang flag.
+var lang string
+
+// messages maps the ID of every message of the interactive character
+// creation, some also shown by the feat commands, to its English format
+// string.
+var messages = map[string]string{
+	"enter_name":              "Enter character name: ",
+	"enter_job":               "Enter character job: ",
+	"seed":                    "Seed: %d",
+	"initial_attributes":      "Initial attributes: %s",
+	"question":                "Year %d: %s",
+	"selected_answer":         "Selected answer: %s",
+	"attribute_test":          "- Attribute to test %s[%d] DC: %d",
+	"attribute_increases":     "- Attribute increases: %s",
+	"attribute_fail_effects":  "- Attribute fail effects: %s",
+	"rolling":                 "Rolling for attribute test...",
+	"press_enter":             "Press Enter to roll the dice",
+	"rolled_modifier":         "You rolled a d20 + modifier (%d): %d",
+	"rolled":                  "Rolled: %d + %d = %d vs DC %d",
+	"test_passed":             "Test passed! Applying increases.",
+	"test_failed":             "Test failed! Applying fail effects.",
+	"increased":               "- Increased %s by %d",
+	"decreased":               "- Decreased %s by %d",
+	"updated_attributes":      "Updated attributes: %s",
+	"final_character":         "Final character:",
+	"name":                    "Name: %s",
+	"job":                     "Job: %s",
+	"attributes":              "Attributes: %s",
+	"no_starting_equipment":   "No starting equipment for job %q",
+	"starting_equipment":      "Starting equipment:",
+	"equipment_item":          "- %dx %s",
+	"equipment_item_equipped": "- %dx %s (equipped)",
+	"carried_weight":          "Carried weight: %.1f/%.0f lb (%s)",
+	"starting_gold":           "Starting gold: %d gp",
+	"wallet":                  "Wallet: %s",
+	"background":              "Background: %s",
+	"background_skills":       "- Skills: %s",
+	"background_tools":        "- Tools: %s",
+	"background_languages":    "- Languages: %s",
+	"background_feature":      "- Feature: %s. %s",
+	"personality_trait":       "- Trait: %s",
+	"personality_ideal":       "- Ideal: %s",
+	"personality_bond":        "- Bond: %s",
+	"personality_flaw":        "- Flaw: %s",
+	"feats":                   "Feats:",
+	"feat_available":          "available",
+	"choose_feat":             "Choose a feat (empty for none): ",
+	"unknown_feat":            "Unknown feat %q",
+	"feat_increase":           "Attribute to increase %v: ",
+	"feat_taken":              "%s takes the %s feat",
+	"spellcasting":            "Spellcasting: %s, save DC %d, attack bonus %+d",
+	"spell_slots":             "Spell slots: %s",
+	"known_spells":            "Known spells: %s",
+	"character_saved":         "Character saved to %s",
+}
+
+// loadLocaleTable loads the string table of the locale, or of its language,
+// from the data search path.
+// Returns the table, or nil and an error if the locale has no table.
+func loadLocaleTable(name string) (*locale.Table, error) {
+	for _, candidate := range locale.Candidates(name) {
+		content, _, err := dataSearchPath().ReadFile(path.Join(locales_path, candidate+".json"))
+		if err != nil {
+			continue
+		}
+		return locale.ParseTable(content)
+	}
+	return nil, fmt.Errorf("no string table for locale %q", name)
+}
+
+// loadLocalizer returns the Localizer for the locale given with the -lang
+// flag or selected by the environment. It prints a warning to the standard
+// error and uses English if the locale has no string table.
+func loadLocalizer() *locale.Localizer {
+	name := cmp.Or(lang, locale.FromEnv())
+	if name == "" || locale.IsEnglish(name) {
+		return locale.New(nil, messages)
+	}
+	table, err := loadLocaleTable(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v, using English\n", err)
+	}
+	return locale.New(table, messages)
+}
+
+// availableLocales returns the locales with a string table in the data
+// search path, sorted.
+func availableLocales() []string {
+	var names []string
+	for _, f := range dataSearchPath().Files(locales_path) {
+		name := path.Base(f.Name)
+		names = append(names, strings.TrimSuffix(name, path.Ext(name)))
+	}
+	return names
+}
+
+// runLocale runs the locale subcommand.
+// "list" prints the locales with a string table, and "check" reports the
+// entries missing from the table of a locale, or of every locale, and the
+// entries they translate that no longer exist.
+// It returns the process exit code, 1 if any translation is missing.
+func runLocale(args []string) int {
+	if len(args) == 0 {
+		printCommandUsage(locale_usage)
+		return 2
+	}
+	switch strings.ToLower(args[0]) {
+	case "list":
+		if len(args) != 1 {
+			printCommandUsage(locale_usage)
+			return 2
+		}
+		fmt.Println(locale.English, "(built-in)")
+		for _, name := range availableLocales() {
+			fmt.Println(name)
+		}
+		return 0
+	case "check":
+		if len(args) > 2 {
+			printCommandUsage(locale_usage)
+			return 2
+		}
+		names := availableLocales()
+		if len(args) == 2 {
+			names = []string{args[1]}
+		}
+		return checkLocales(names)
+	}
+	printCommandUsage(locale_usage)
+	return 2
+}
+
+// checkLocales prints the missing and unused entries of the string tables
+// of the locales.
+// It returns the process exit code, 1 if any translation is missing.
+func checkLocales(names []string) int {
+	data := loadCharacterData()
+	code := 0
+	for _, name := range names {
+		table, err := loadLocaleTable(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code = 1
+			continue
+		}
+		report := table.Check(messages, data)
+		if !report.IsComplete() {
+			code = 1
+		}
+		fmt.Printf("%s: %d missing, %d unused\n", report.Locale, len(report.Missing), len(report.Unused))
+		for _, entry := range report.Missing {
+			fmt.Printf("  missing %s\n", entry)
+		}
+		for _, entry := range report.Unused {
+			fmt.Printf("  unused %s\n", entry)
+		}
+	}
+	return code
+}
@@ -2,95 +2,199 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jrecuero/DandD/internal/character"
-	"github.com/jrecuero/DandD/pkg/dice"
+	"github.com/jrecuero/DandD/internal/locale"
 )
 
-type rollData struct {
-	attribute   character.Attribute
-	attrValue   int
-	dc          int
-	increases   map[character.Attribute]int
-	failEffects map[character.Attribute]int
-}
-
 // Constants for file paths
-// and character creation JSON file.
+// and data JSON files.
 const (
 	character_creation_file = "character_creation.json"
-	data_assets_path        = "./assets/data/"
+	items_file              = "items.json"
+	spells_file             = "spells.json"
+	backgrounds_file        = "backgrounds.json"
+	characters_path         = "./characters/"
+	create_usage            = "create [-seed n]"
 )
 
-// loadCharacterData loads the character data from the JSON file.
+// loadCharacterData loads the character data merged from every data pack
+// of the search path, the built-in data first, and prints the merge
+// conflicts to the standard error.
 // It panics if there is an error.
 // Returns the loaded CharacterData.
 func loadCharacterData() *character.CharacterCreationData {
-	fpath := filepath.Join(data_assets_path, character_creation_file)
-	data, err := character.LoadCharacterData(fpath)
+	data, report, err := mergeCharacterData()
+	if err != nil {
+		panic(err)
+	}
+	for _, conflict := range report.Conflicts {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", conflict)
+	}
+	return data
+}
+
+// loadItemData loads the item data from the data search path.
+// It panics if there is an error.
+// Returns the loaded ItemData.
+func loadItemData() *character.ItemData {
+	content, err := readDataFile(items_file)
+	if err != nil {
+		panic(err)
+	}
+	data, err := character.ParseItemData(content)
 	if err != nil {
 		panic(err)
 	}
 	return data
 }
 
-// loadAttributes initializes the AttributesMap
-// with the starting attributes from CharacterData.
-// Returns the initialized AttributesMap.
-func loadAttributes(characterData *character.CharacterCreationData) character.AttributesMap {
-	attributesMap := character.NewAttributesMap()
-	for attrName, value := range characterData.StartingAttributes {
-		if attr, ok := character.GetAttributeFromShortName(attrName); ok {
-			attributesMap.Set(attr, value)
+// equipCharacter gives the character the starting equipment for its job
+// and equips everything that fits in a free slot.
+// It prints the resulting inventory to the console.
+func equipCharacter(c *character.Character, itemData *character.ItemData, l *locale.Localizer) {
+	if !c.ApplyStartingEquipment(itemData) {
+		fmt.Println(l.Message("no_starting_equipment", c.Job))
+		return
+	}
+	fmt.Println(l.Message("starting_equipment"))
+	for _, entry := range c.Inventory.Items {
+		item, _ := itemData.GetItem(entry.ItemID)
+		id := "equipment_item"
+		if item.IsEquippable() && c.Inventory.Equip(itemData, item.ID, "") == nil {
+			id = "equipment_item_equipped"
 		}
+		fmt.Println(l.Message(id, entry.Quantity, item.Name))
 	}
-	return attributesMap
+	fmt.Println(l.Message("carried_weight",
+		c.Inventory.Weight(itemData), c.CarryingCapacity(), c.Encumbrance(itemData)))
 }
 
-// chooseAnswer selects an answer from the provided answer pool.
-// It shuffles the answers and picks the first one.
-// Returns the selected Answer.
-func chooseAnswer(anwser_pool []character.Answer) character.Answer {
-	rand.Shuffle(len(anwser_pool), func(i, j int) { anwser_pool[i], anwser_pool[j] = anwser_pool[j], anwser_pool[i] })
-	// for i, answer := range anwser_pool[:3] {
-	// 	fmt.Printf("\t%d. %s\n", i+1, answer.Description)
-	// }
-	anwser := anwser_pool[0]
-	return anwser
+// rollStartingGold rolls the starting gold for the character job
+// and prints the resulting wallet to the console.
+func rollStartingGold(c *character.Character, itemData *character.ItemData, l *locale.Localizer) {
+	gold, err := c.RollStartingGold(itemData)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(l.Message("starting_gold", gold))
+	fmt.Println(l.Message("wallet", &c.Wallet))
+}
+
+// loadSpellData loads the spell data from the data search path.
+// It panics if there is an error.
+// Returns the loaded SpellData.
+func loadSpellData() *character.SpellData {
+	content, err := readDataFile(spells_file)
+	if err != nil {
+		panic(err)
+	}
+	data, err := character.ParseSpellData(content)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// setupSpellcasting initializes spellcasting for characters with a caster job.
+// The character learns every class spell it can cast and prepares as many
+// as allowed. It prints the spellcasting details to the console.
+func setupSpellcasting(c *character.Character, spellData *character.SpellData, l *locale.Localizer) {
+	if err := c.InitSpellcasting(spellData); err != nil {
+		return
+	}
+	maxLevel := 0
+	for level := 1; level <= character.MaxSpellLevel; level++ {
+		if c.Spellcasting.Slots.Max[level-1] > 0 {
+			maxLevel = level
+		}
+	}
+	for _, spell := range spellData.ClassSpells(c.Job, maxLevel) {
+		c.LearnSpell(spell)
+		c.PrepareSpell(spell)
+	}
+	fmt.Println(l.Message("spellcasting", l.Attribute(c.Spellcasting.Ability), c.SpellSaveDC(), c.SpellAttackBonus()))
+	fmt.Println(l.Message("spell_slots", &c.Spellcasting.Slots))
+	fmt.Println(l.Message("known_spells", strings.Join(c.Spellcasting.Known, ", ")))
+}
+
+// loadBackgroundData loads the background data from the data search path.
+// It panics if there is an error.
+// Returns the loaded BackgroundData.
+func loadBackgroundData() *character.BackgroundData {
+	content, err := readDataFile(backgrounds_file)
+	if err != nil {
+		panic(err)
+	}
+	data, err := character.ParseBackgroundData(content)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// assignBackground infers the background that best matches the answers
+// chosen during creation and applies it to the character.
+// It prints the background, why it was chosen and what it grants to the console.
+func assignBackground(c *character.Character, upbringing character.Upbringing, backgroundData *character.BackgroundData, itemData *character.ItemData, l *locale.Localizer) {
+	match := backgroundData.Infer(upbringing)
+	if err := c.ApplyBackground(match.Background, itemData, nil); err != nil {
+		fmt.Println(err)
+		return
+	}
+	b := match.Background
+	fmt.Println(l.Message("background", match))
+	skills := make([]string, len(b.Skills))
+	for i, skill := range b.Skills {
+		skills[i] = string(skill)
+	}
+	fmt.Println(l.Message("background_skills", strings.Join(skills, ", ")))
+	if len(b.Tools) > 0 {
+		fmt.Println(l.Message("background_tools", strings.Join(b.Tools, ", ")))
+	}
+	if len(b.Languages) > 0 {
+		fmt.Println(l.Message("background_languages", strings.Join(b.Languages, ", ")))
+	}
+	fmt.Println(l.Message("background_feature", b.Feature.Name, b.Feature.Description))
+	fmt.Println(l.Message("personality_trait", c.Personality.Trait))
+	fmt.Println(l.Message("personality_ideal", c.Personality.Ideal))
+	fmt.Println(l.Message("personality_bond", c.Personality.Bond))
+	fmt.Println(l.Message("personality_flaw", c.Personality.Flaw))
+}
+
+// saveCharacter saves the character as a JSON file in the characters folder.
+// It panics if there is an error.
+func saveCharacter(c *character.Character, l *locale.Localizer) {
+	if err := os.MkdirAll(characters_path, 0755); err != nil {
+		panic(err)
+	}
+	fname := character.Slug(c.Name) + ".json"
+	fpath := filepath.Join(characters_path, fname)
+	if err := character.SaveCharacter(fpath, c); err != nil {
+		panic(err)
+	}
+	fmt.Println(l.Message("character_saved", fpath))
 }
 
 // displayAnswer displays the selected answer details,
-// including attribute tests, increases, and fail effects.
-// It takes the selected Answer and the current AttributesMap as parameters.
+// including the attribute test, increases, and fail effects.
 // It prints the details to the console.
-// It returns a rollData struct with relevant information.
-func displayAnswer(anwser character.Answer, attributes character.AttributesMap) *rollData {
-	fmt.Printf("Selected answer: %s\n", anwser.Description)
-	attrName := anwser.Test
-	attr, ok := character.GetAttributeFromShortName(attrName)
-	if !ok {
-		panic("invalid attribute name in test: " + attrName)
-	}
-	attrValue := attributes.Get(attr)
-	fmt.Printf("- Attribute to test %s[%d] DC: %d\n", attrName, attrValue, anwser.DC)
-	increases := character.GetAttributeIncreases(anwser)
-	failEffects := character.GetAttributeFailEffects(anwser)
-	fmt.Println("- Attribute increases:", character.AttributeMapToString(increases))
-	fmt.Println("- Attribute fail effects:", character.AttributeMapToString(failEffects))
+func displayAnswer(step character.CreationStep, l *locale.Localizer) {
+	anwser := step.Answer
+	fmt.Println(l.Message("selected_answer", l.Answer(anwser)))
+	fmt.Println(l.Message("attribute_test", anwser.Test, step.Result.Score, anwser.DC))
+	fmt.Println(l.Message("attribute_increases", character.AttributeMapToString(character.GetAttributeIncreases(anwser))))
+	fmt.Println(l.Message("attribute_fail_effects", character.AttributeMapToString(character.GetAttributeFailEffects(anwser))))
 	fmt.Println()
-	return &rollData{
-		attribute:   attr,
-		attrValue:   attrValue,
-		dc:          anwser.DC,
-		increases:   increases,
-		failEffects: failEffects,
-	}
 }
 
 // displayDots displays dots in the console for the specified duration.
@@ -111,14 +215,22 @@ func displayDots(duration time.Duration) {
 	}
 }
 
-// rollDice performs the attribute test roll.
-// It takes the rollData and current AttributesMap as parameters.
-// It rolls a d20, adds the attribute value, and compares it to the DC.
-// Depending on the result, it applies increases or fail effects to the AttributesMap.
+// displayClampEvent displays a warning when an attribute was clamped to its bounds.
+// It does nothing if the event is nil.
+func displayClampEvent(event *character.ClampEvent) {
+	if event != nil {
+		fmt.Printf("  ! %s\n", event)
+	}
+}
+
+// rollDice reveals the attribute test roll of a creation step.
+// It waits for the user to press Enter and displays the d20 roll plus the
+// ability modifier against the DC, with the increases or fail effects
+// applied to the attributes, which were kept within their bounds.
 // It prints the results to the console.
-func rollDice(rollData *rollData, attributes character.AttributesMap) {
-	fmt.Println("Rolling for attribute test...")
-	fmt.Printf("Press Enter to roll the dice")
+func rollDice(step character.CreationStep, attributes character.AttributesMap, l *locale.Localizer) {
+	fmt.Println(l.Message("rolling"))
+	fmt.Print(l.Message("press_enter"))
 
 	// Wait for user to press Enter
 	bufio.NewReader(os.Stdin).ReadBytes('\n')
@@ -133,55 +245,119 @@ func rollDice(rollData *rollData, attributes character.AttributesMap) {
 	// Wait for dots to finish
 	<-dotsChan
 
-	rollDice := dice.Roll(1, 20)
-	mod := character.AbilityModifier(rollData.attrValue)
-	total := rollDice + mod
-	fmt.Printf("You rolled a d20 + modifier (%d): %d\n", mod, rollDice-mod)
-	fmt.Printf("Rolled: %d + %d = %d vs DC %d\n", rollDice, mod, total, rollData.dc)
-	if total >= rollData.dc {
-		fmt.Println("Test passed! Applying increases.")
-		for attr, inc := range rollData.increases {
-			attributes.Increase(attr, inc)
-			fmt.Printf("- Increased %s by %d\n", character.GetAttributeName(attr), inc)
-		}
+	result := step.Result
+	fmt.Println(l.Message("rolled_modifier", result.Modifier, result.Total()))
+	fmt.Println(l.Message("rolled", result.Roll, result.Modifier, result.Total(), result.DC))
+	if result.Passed {
+		fmt.Println(l.Message("test_passed"))
 	} else {
-		fmt.Println("Test failed! Applying fail effects.")
-		for attr, dec := range rollData.failEffects {
-			attributes.Decrease(attr, dec)
-			fmt.Printf("- Decreased %s by %d\n", character.GetAttributeName(attr), dec)
+		fmt.Println(l.Message("test_failed"))
+	}
+	for attr := character.Str; attr <= character.Cha; attr++ {
+		value, ok := result.Changes[attr]
+		if !ok {
+			continue
+		}
+		// Show the change actually applied, followed by the clamp that
+		// reduced it.
+		i := slices.IndexFunc(result.Events, func(e character.ClampEvent) bool { return e.Attribute == attr })
+		if i >= 0 {
+			value -= result.Events[i].Requested - result.Events[i].Value
+		}
+		if value < 0 {
+			fmt.Println(l.Message("decreased", l.Attribute(attr), -value))
+		} else {
+			fmt.Println(l.Message("increased", l.Attribute(attr), value))
+		}
+		if i >= 0 {
+			displayClampEvent(&result.Events[i])
 		}
 	}
-	fmt.Println("Updated attributes:", attributes.ColorString())
+	fmt.Println(l.Message("updated_attributes", attributes.ColorString()))
 	fmt.Println()
 }
 
-func main() {
+// runCreate runs the interactive character creation, also run when the
+// program is started without a subcommand.
+// Answers are picked and attribute tests rolled from the -seed flag, or a
+// random seed, which is recorded with the answers and rolls in the
+// character transcript so the run can be replayed. Questions, answers and
+// messages are shown in the locale of the -lang flag or the environment.
+// It returns the process exit code.
+func runCreate(args []string) int {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	seed := flags.Int64("seed", rand.Int64(), "seed of the answers and attribute test rolls")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		printCommandUsage(create_usage)
+		return 2
+	}
 	var character_name string
 	var character_job string
+	l := loadLocalizer()
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Enter character name: ")
+	fmt.Print(l.Message("enter_name"))
 	character_name, _ = reader.ReadString('\n')
 	character_name = strings.TrimSpace(character_name)
 
-	fmt.Printf("Enter character job: ")
+	fmt.Print(l.Message("enter_job"))
 	character_job, _ = reader.ReadString('\n')
 	character_job = strings.TrimSpace(character_job)
 	character := character.NewCharacter(character_name, character_job, character.AttributesMap{})
 
 	characterData := loadCharacterData()
-	attributes := loadAttributes(characterData)
+	creation, events := characterData.NewCreation(*seed)
+	for _, event := range events {
+		displayClampEvent(&event)
+	}
 
-	fmt.Println("Initial attributes:", attributes)
+	fmt.Println(l.Message("seed", *seed))
+	fmt.Println(l.Message("initial_attributes", creation.Attributes))
 
 	for _, question := range characterData.Questions {
-		fmt.Printf("Year %d: %s\n", question.Year, question.Question)
-		anwser := chooseAnswer(question.Answers)
-		rollData := displayAnswer(anwser, attributes)
-		rollDice(rollData, attributes)
-	}
-	character.Attributes = attributes
-	fmt.Println("Final character:")
-	fmt.Printf("Name: %s\n", character.Name)
-	fmt.Printf("Job: %s\n", character.Job)
-	fmt.Printf("Attributes: %s\n", character.Attributes.ColorString())
+		fmt.Println(l.Message("question", question.Year, l.Question(question)))
+		step, err := creation.Answer(question)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		displayAnswer(step, l)
+		rollDice(step, creation.Attributes, l)
+	}
+	character.Attributes = creation.Attributes
+	character.Transcript = creation.Transcript()
+	fmt.Println(l.Message("final_character"))
+	fmt.Println(l.Message("name", character.Name))
+	fmt.Println(l.Message("job", character.Job))
+	fmt.Println(l.Message("attributes", character.Attributes.ColorString()))
+	fmt.Println()
+	itemData := loadItemData()
+	equipCharacter(character, itemData, l)
+	rollStartingGold(character, itemData, l)
+	assignBackground(character, creation.Upbringing, loadBackgroundData(), itemData, l)
+	if featData, err := loadFeatData(); err != nil {
+		fmt.Println(err)
+	} else {
+		chooseFeat(character, featData, l, func() string {
+			text, _ := reader.ReadString('\n')
+			return text
+		})
+	}
+	setupSpellcasting(character, loadSpellData(), l)
+	character.InitHitPoints()
+	saveCharacter(character, l)
+	return 0
+}
+
+func main() {
+	flag.StringVar(&dataDir, "data", "", "data pack directory searched first")
+	flag.StringVar(&lang, "lang", "", "locale of the creation text, $LANG by default")
+	flag.Usage = printUsage
+	flag.Parse()
+	if flag.NArg() > 0 {
+		os.Exit(runCommand(flag.Args()))
+	}
+	os.Exit(runCreate(nil))
 }
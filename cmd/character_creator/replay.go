@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// replay_usage is the usage of the replay command.
+const replay_usage = "replay <file>"
+
+// runReplay runs the replay subcommand.
+// It re-executes the creation run recorded in the transcript of a saved
+// character with the current creation data and verifies that it ends with
+// the recorded attributes and that its seed still reproduces it.
+// It returns the process exit code.
+func runReplay(args []string) int {
+	if len(args) != 1 {
+		printCommandUsage(replay_usage)
+		return 2
+	}
+	c, err := character.LoadCharacter(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if c.Transcript == nil {
+		fmt.Fprintf(os.Stderr, "%s has no creation transcript\n", c.Name)
+		return 1
+	}
+	steps, err := loadCharacterData().Replay(c.Transcript)
+	for _, step := range steps {
+		result := step.Result
+		outcome := "failed"
+		if result.Passed {
+			outcome = "passed"
+		}
+		fmt.Printf("Year %d: %s, %s d20 %d %+d = %d vs DC %d, %s\n", step.Question.Year, step.Answer.AnswerID,
+			character.GetAttributeShortName(result.Attribute), result.Roll, result.Modifier, result.Total(), result.DC, outcome)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("Replay of seed %d matches: %s\n", c.Transcript.Seed, c.Transcript.Attributes)
+	return 0
+}
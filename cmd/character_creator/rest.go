@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// rest_usage is the usage of the rest subcommand.
+const rest_usage = "rest short [-dice n] [-seed n] <file> | rest long <file>"
+
+// runRest runs the rest subcommand.
+// It loads a saved character, takes a short or long rest, recorded in the
+// character journal, and saves the character back to the same file.
+// It returns the process exit code.
+func runRest(args []string) int {
+	if len(args) == 0 {
+		printCommandUsage(rest_usage)
+		return 2
+	}
+	flags := flag.NewFlagSet("rest "+args[0], flag.ContinueOnError)
+	hitDice := flags.Int("dice", 1, "number of hit dice to spend on a short rest")
+	seed := flags.Int64("seed", 0, "random seed, 0 for random rolls")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		printCommandUsage(rest_usage)
+		return 2
+	}
+	fpath := flags.Arg(0)
+	c, err := character.LoadCharacter(fpath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	var result character.RestResult
+	switch args[0] {
+	case "short":
+		var roller dice.Roller
+		if *seed != 0 {
+			roller = dice.NewRandRoller(*seed)
+		}
+		_, err = c.Record(journalAuthor(), "short rest", func(c *character.Character) error {
+			result, err = c.ShortRest(*hitDice, roller)
+			return err
+		})
+	case "long":
+		_, err = c.Record(journalAuthor(), "long rest", func(c *character.Character) error {
+			result, err = c.LongRest()
+			return err
+		})
+	default:
+		printCommandUsage(rest_usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("%s: %s\n", c.Name, result)
+	fmt.Printf("Hit points: %s, hit dice: %d/%d\n", c.HitPoints, c.HitDiceAvailable(), c.Level)
+	if err := character.SaveCharacter(fpath, c); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/roster"
+)
+
+// Constants for the roster directory and the roster command usage.
+const (
+	roster_path  = "./roster/"
+	roster_usage = "roster [-dir path] list [-all] | search [-name text] [-class job] [-min n] [-max n] [-all] | " +
+		"add <file>... | show <id> | export <id> <file> | rename <id> <name> | clone <id> <name> | " +
+		"archive <id> | unarchive <id> | delete <id>"
+)
+
+// rosterArgs lists the number of arguments of each roster subcommand, with
+// -1 for one or more.
+var rosterArgs = map[string]int{
+	"list":      0,
+	"search":    0,
+	"add":       -1,
+	"show":      1,
+	"export":    2,
+	"rename":    2,
+	"clone":     2,
+	"archive":   1,
+	"unarchive": 1,
+	"delete":    1,
+}
+
+// printEntries prints roster entries one per line.
+func printEntries(entries []roster.Entry) {
+	if len(entries) == 0 {
+		fmt.Println("No characters found")
+	}
+	for _, e := range entries {
+		fmt.Println(e)
+	}
+}
+
+// runRoster runs the roster subcommand.
+// It manages the characters stored in the roster directory: listing and
+// searching them, adding character files, exporting them back to files,
+// and renaming, cloning, archiving and deleting them.
+// It returns the process exit code.
+func runRoster(args []string) int {
+	global := flag.NewFlagSet("roster", flag.ContinueOnError)
+	dir := global.String("dir", roster_path, "roster directory")
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	args = global.Args()
+	if len(args) == 0 {
+		printCommandUsage(roster_usage)
+		return 2
+	}
+	sub := strings.ToLower(args[0])
+	nargs, ok := rosterArgs[sub]
+	if !ok {
+		printCommandUsage(roster_usage)
+		return 2
+	}
+	flags := flag.NewFlagSet("roster "+sub, flag.ContinueOnError)
+	all := flags.Bool("all", false, "include archived characters")
+	var query roster.Query
+	if sub == "search" {
+		flags.StringVar(&query.Name, "name", "", "text the character name contains")
+		flags.StringVar(&query.Class, "class", "", "class the character has levels in")
+		flags.IntVar(&query.MinLevel, "min", 0, "minimum character level")
+		flags.IntVar(&query.MaxLevel, "max", 0, "maximum character level")
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if (nargs >= 0 && flags.NArg() != nargs) || (nargs < 0 && flags.NArg() == 0) {
+		printCommandUsage(roster_usage)
+		return 2
+	}
+	store, err := roster.NewFileStore(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := rosterCommand(roster.New(store), sub, flags.Args(), query, *all); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// rosterCommand runs a roster subcommand with its arguments.
+func rosterCommand(r *roster.Roster, sub string, args []string, query roster.Query, all bool) error {
+	var entry roster.Entry
+	var err error
+	switch sub {
+	case "list":
+		entries, err := r.List(all)
+		if err != nil {
+			return err
+		}
+		printEntries(entries)
+		return nil
+	case "search":
+		query.Archived = all
+		entries, err := r.Search(query)
+		if err != nil {
+			return err
+		}
+		printEntries(entries)
+		return nil
+	case "add":
+		for _, fpath := range args {
+			c, err := character.LoadCharacter(fpath)
+			if err != nil {
+				return err
+			}
+			if entry, err = r.Add(c); err != nil {
+				return err
+			}
+			fmt.Println("Added", entry)
+		}
+		return nil
+	case "show":
+		c, err := r.Get(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(c)
+		fmt.Printf("Classes: %s, hit points: %s\n", c.ClassString(), c.HitPoints)
+		return nil
+	case "export":
+		c, err := r.Get(args[0])
+		if err != nil {
+			return err
+		}
+		if err := character.SaveCharacter(args[1], c); err != nil {
+			return err
+		}
+		fmt.Printf("Exported %s to %s\n", args[0], args[1])
+		return nil
+	case "rename":
+		entry, err = r.Rename(args[0], args[1])
+	case "clone":
+		entry, err = r.Clone(args[0], args[1])
+	case "archive":
+		entry, err = r.Archive(args[0])
+	case "unarchive":
+		entry, err = r.Unarchive(args[0])
+	case "delete":
+		if err := r.Delete(args[0]); err != nil {
+			return err
+		}
+		fmt.Println("Deleted", args[0])
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(entry)
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/sheet"
+)
+
+// sheet_usage is the usage of the sheet subcommand.
+const sheet_usage = "sheet [-format text|markdown|html] [-o output] <file>"
+
+// runSheet runs the sheet subcommand.
+// It loads a saved character and renders its character sheet in the given
+// format, to the standard output or to the output file.
+// It returns the process exit code.
+func runSheet(args []string) int {
+	flags := flag.NewFlagSet("sheet", flag.ContinueOnError)
+	format := flags.String("format", string(sheet.Text), "sheet format: text, markdown or html")
+	output := flags.String("o", "", "file to write the sheet to, the standard output by default")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		printCommandUsage(sheet_usage)
+		return 2
+	}
+	f, err := sheet.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	c, err := character.LoadCharacter(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	s := sheet.New(c, sheet.Data{
+		Items:       loadItemData(),
+		Spells:      loadSpellData(),
+		Backgrounds: loadBackgroundData(),
+	})
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := s.Render(w, f); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -1,6 +1,10 @@
 package character
 
-import "strings"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Attribute represents a character attribute type.
 type Attribute int
@@ -72,6 +76,46 @@ func GetAttributeFromShortName(shortName string) (Attribute, bool) {
 	return 0, false
 }
 
+// String returns the short name of the attribute.
+func (a Attribute) String() string {
+	if name, ok := attributeShortNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Attribute(%d)", int(a))
+}
+
+// MarshalText encodes the attribute as its short name, so attributes and
+// maps keyed by attributes are serialized as "STR", "DEX" and so on.
+func (a Attribute) MarshalText() ([]byte, error) {
+	name, ok := attributeShortNames[a]
+	if !ok {
+		return nil, fmt.Errorf("invalid attribute %d", int(a))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes an attribute from its short name or full name.
+// Numeric values are also accepted.
+// It returns an error if the text does not correspond to any Attribute.
+func (a *Attribute) UnmarshalText(text []byte) error {
+	s := string(text)
+	if attr, ok := GetAttributeFromShortName(s); ok {
+		*a = attr
+		return nil
+	}
+	if attr, ok := GetAttributeFromName(s); ok {
+		*a = attr
+		return nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if _, ok := attributeShortNames[Attribute(n)]; ok {
+			*a = Attribute(n)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown attribute %q", s)
+}
+
 // AbilityModifier calculates the ability modifier for a given ability score.
 // The formula used is (score - 10) / 2, rounded down.
 // For example, a score of 15 yields a modifier of +2, while a score of 8 yields -1.
@@ -79,5 +123,17 @@ func GetAttributeFromShortName(shortName string) (Attribute, bool) {
 // associated with character attributes.
 // It takes an integer score as input and returns the corresponding modifier as an integer.
 func AbilityModifier(score int) int {
-	return (score - 10) / 2
+	// Shifting right divides by two rounding down, also for negative values,
+	// where integer division would round toward zero.
+	return (score - 10) >> 1
+}
+
+// ProficiencyBonus calculates the proficiency bonus for a given character level.
+// The bonus starts at +2 on level 1 and increases by one every four levels,
+// reaching +6 on level 17.
+func ProficiencyBonus(level int) int {
+	if level < 1 {
+		level = 1
+	}
+	return 2 + (level-1)/4
 }
@@ -0,0 +1,278 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/dataformat"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// BackgroundFeature represents the narrative feature granted by a
+// background, such as Shelter of the Faithful.
+type BackgroundFeature struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// BackgroundRules holds the weights used to infer a background from the
+// upbringing of a character. Flags adds its weight for every time a flag
+// was collected, and Answers adds its weight when the answer was chosen.
+// Weights can be negative to steer away from a background.
+type BackgroundRules struct {
+	Flags   map[string]int `json:"flags,omitempty"`
+	Answers map[string]int `json:"answers,omitempty"`
+}
+
+// Background represents a background definition.
+// It includes JSON struct tags for serialization.
+// Skills are the skill proficiencies granted, Tools the tool proficiencies
+// and Languages the languages learned. Equipment references item IDs and
+// Gold is given in gold pieces. Traits, Ideals, Bonds and Flaws are the
+// tables personality is rolled from.
+type Background struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Skills      []Skill           `json:"skills"`
+	Tools       []string          `json:"tools"`
+	Languages   []string          `json:"languages"`
+	Equipment   []InventoryEntry  `json:"equipment"`
+	Gold        int               `json:"gold"`
+	Feature     BackgroundFeature `json:"feature"`
+	Traits      []string          `json:"traits"`
+	Ideals      []string          `json:"ideals"`
+	Bonds       []string          `json:"bonds"`
+	Flaws       []string          `json:"flaws"`
+	Rules       BackgroundRules   `json:"rules"`
+}
+
+// Personality represents the trait, ideal, bond and flaw of a character.
+// It includes JSON struct tags for serialization.
+type Personality struct {
+	Trait string `json:"trait,omitempty"`
+	Ideal string `json:"ideal,omitempty"`
+	Bond  string `json:"bond,omitempty"`
+	Flaw  string `json:"flaw,omitempty"`
+}
+
+// rollEntry returns a random entry of the table, or an empty string if the
+// table is empty.
+func rollEntry(table []string, r dice.Roller) string {
+	if len(table) == 0 {
+		return ""
+	}
+	return table[r.RollDie(len(table))-1]
+}
+
+// RollPersonality rolls a trait, an ideal, a bond and a flaw from the
+// background tables.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func (b *Background) RollPersonality(r dice.Roller) Personality {
+	if r == nil {
+		r = dice.DefaultRoller
+	}
+	return Personality{
+		Trait: rollEntry(b.Traits, r),
+		Ideal: rollEntry(b.Ideals, r),
+		Bond:  rollEntry(b.Bonds, r),
+		Flaw:  rollEntry(b.Flaws, r),
+	}
+}
+
+// BackgroundData represents the structure of the backgrounds JSON file.
+// It includes the background definitions and the ID of the background used
+// when no rule matches the upbringing.
+type BackgroundData struct {
+	Default     string       `json:"default"`
+	Backgrounds []Background `json:"backgrounds"`
+	index       map[string]int
+}
+
+// LoadBackgroundData reads the background data from a JSON, YAML or TOML
+// file, chosen by its extension, unmarshals it into a BackgroundData struct
+// and validates it.
+// It returns the BackgroundData and any error encountered during the process.
+func LoadBackgroundData(filename string) (*BackgroundData, error) {
+	file, err := dataformat.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBackgroundData(file)
+}
+
+// ParseBackgroundData unmarshals the background data from JSON content into
+// a BackgroundData struct and validates it.
+// It returns the BackgroundData and any error encountered during the process.
+func ParseBackgroundData(content []byte) (*BackgroundData, error) {
+	var data BackgroundData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Validate checks that background IDs are unique, that every skill is known,
+// that equipment quantities and gold are not negative, that every background
+// has a feature and that the default background exists.
+func (d *BackgroundData) Validate() error {
+	d.index = make(map[string]int, len(d.Backgrounds))
+	for i, b := range d.Backgrounds {
+		if b.ID == "" {
+			return fmt.Errorf("background %q has no id", b.Name)
+		}
+		key := strings.ToLower(b.ID)
+		if _, ok := d.index[key]; ok {
+			return fmt.Errorf("duplicate background id %q", b.ID)
+		}
+		d.index[key] = i
+		for _, skill := range b.Skills {
+			if _, err := ParseSkill(string(skill)); err != nil {
+				return fmt.Errorf("background %q: %w", b.ID, err)
+			}
+		}
+		for _, entry := range b.Equipment {
+			if entry.Quantity <= 0 {
+				return fmt.Errorf("background %q: invalid quantity %d for item %q", b.ID, entry.Quantity, entry.ItemID)
+			}
+		}
+		if b.Gold < 0 {
+			return fmt.Errorf("background %q: negative gold %d", b.ID, b.Gold)
+		}
+		if b.Feature.Name == "" {
+			return fmt.Errorf("background %q has no feature", b.ID)
+		}
+	}
+	if _, ok := d.index[strings.ToLower(d.Default)]; !ok {
+		return fmt.Errorf("unknown default background %q", d.Default)
+	}
+	return nil
+}
+
+// Get returns the background with the given ID, ignoring case.
+// It returns the Background and a boolean indicating whether it was found.
+func (d *BackgroundData) Get(id string) (*Background, bool) {
+	if d.index == nil {
+		d.index = make(map[string]int, len(d.Backgrounds))
+		for i, b := range d.Backgrounds {
+			d.index[strings.ToLower(b.ID)] = i
+		}
+	}
+	i, ok := d.index[strings.ToLower(strings.TrimSpace(id))]
+	if !ok {
+		return nil, false
+	}
+	return &d.Backgrounds[i], true
+}
+
+// BackgroundMatch represents how well a background matches an upbringing.
+// Reasons explain every rule that contributed to the score.
+type BackgroundMatch struct {
+	Background *Background
+	Score      int
+	Reasons    []string
+}
+
+// String returns a string representation of the BackgroundMatch.
+func (m BackgroundMatch) String() string {
+	if len(m.Reasons) == 0 {
+		return fmt.Sprintf("%s (score %d)", m.Background.Name, m.Score)
+	}
+	return fmt.Sprintf("%s (score %d: %s)", m.Background.Name, m.Score, strings.Join(m.Reasons, ", "))
+}
+
+// Match scores a single background against the upbringing.
+func (b *Background) Match(u Upbringing) BackgroundMatch {
+	match := BackgroundMatch{Background: b}
+	count := u.FlagCount()
+	flags := make([]string, 0, len(b.Rules.Flags))
+	for flag := range b.Rules.Flags {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	for _, flag := range flags {
+		if n := count[flag]; n > 0 {
+			points := n * b.Rules.Flags[flag]
+			match.Score += points
+			match.Reasons = append(match.Reasons, fmt.Sprintf("%s x%d (%+d)", flag, n, points))
+		}
+	}
+	for _, answer := range u.Answers {
+		if points, ok := b.Rules.Answers[answer]; ok {
+			match.Score += points
+			match.Reasons = append(match.Reasons, fmt.Sprintf("answer %s (%+d)", answer, points))
+		}
+	}
+	return match
+}
+
+// Rank scores every background against the upbringing.
+// It returns the matches from the highest score to the lowest, keeping the
+// order of the data file for equal scores.
+func (d *BackgroundData) Rank(u Upbringing) []BackgroundMatch {
+	matches := make([]BackgroundMatch, len(d.Backgrounds))
+	for i := range d.Backgrounds {
+		matches[i] = d.Backgrounds[i].Match(u)
+	}
+	slices.SortStableFunc(matches, func(a, b BackgroundMatch) int {
+		return b.Score - a.Score
+	})
+	return matches
+}
+
+// Infer returns the background that best matches the upbringing.
+// When no background scores above zero, the default background is returned
+// with a reason saying so.
+func (d *BackgroundData) Infer(u Upbringing) BackgroundMatch {
+	matches := d.Rank(u)
+	if len(matches) > 0 && matches[0].Score > 0 {
+		return matches[0]
+	}
+	b, _ := d.Get(d.Default)
+	return BackgroundMatch{Background: b, Reasons: []string{"no rule matched, default background"}}
+}
+
+// ApplyBackground gives the character the background proficiencies,
+// languages, equipment and gold, and rolls its personality.
+// Skills the character is already proficient in are not duplicated.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+// It returns an error if the character already has a background or the
+// equipment references an item missing from the item data.
+func (c *Character) ApplyBackground(b *Background, data *ItemData, r dice.Roller) error {
+	if c.Background != "" {
+		return fmt.Errorf("%s already has background %q", c.Name, c.Background)
+	}
+	for _, entry := range b.Equipment {
+		if _, ok := data.GetItem(entry.ItemID); !ok {
+			return fmt.Errorf("background %q references unknown item %q", b.ID, entry.ItemID)
+		}
+	}
+	for _, skill := range b.Skills {
+		if !c.IsProficient(skill) {
+			c.Skills = append(c.Skills, skill)
+		}
+	}
+	for _, tool := range b.Tools {
+		if !slices.Contains(c.Tools, tool) {
+			c.Tools = append(c.Tools, tool)
+		}
+	}
+	for _, language := range b.Languages {
+		if !slices.Contains(c.Languages, language) {
+			c.Languages = append(c.Languages, language)
+		}
+	}
+	for _, entry := range b.Equipment {
+		c.Inventory.Add(entry.ItemID, entry.Quantity)
+	}
+	c.Wallet.Add(GP, b.Gold)
+	c.Personality = b.RollPersonality(r)
+	c.Background = b.ID
+	return nil
+}
@@ -0,0 +1,108 @@
+package character
+
+import "fmt"
+
+// Bounds represents the inclusive range allowed for an attribute score.
+// It includes JSON struct tags so creation data can declare its own caps.
+type Bounds struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Default bounds for attribute scores.
+// CreationBounds applies while a character is being created, and
+// AbsoluteBounds is the hard limit for any creature.
+var (
+	CreationBounds = Bounds{Min: 1, Max: 20}
+	AbsoluteBounds = Bounds{Min: 1, Max: 30}
+)
+
+// Clamp restricts the given value to the bounds.
+// It returns the clamped value and a boolean indicating whether the value was changed.
+func (b Bounds) Clamp(value int) (int, bool) {
+	if value < b.Min {
+		return b.Min, true
+	}
+	if value > b.Max {
+		return b.Max, true
+	}
+	return value, false
+}
+
+// Contains returns true if the value is within the bounds.
+func (b Bounds) Contains(value int) bool {
+	return value >= b.Min && value <= b.Max
+}
+
+// Validate returns an error if Min is above Max or the bounds are not within
+// AbsoluteBounds.
+func (b Bounds) Validate() error {
+	if b.Min > b.Max {
+		return fmt.Errorf("bounds %s have min above max", b)
+	}
+	if !AbsoluteBounds.Contains(b.Min) || !AbsoluteBounds.Contains(b.Max) {
+		return fmt.Errorf("bounds %s are not within %s", b, AbsoluteBounds)
+	}
+	return nil
+}
+
+// String returns a string representation of the Bounds.
+func (b Bounds) String() string {
+	return fmt.Sprintf("%d-%d", b.Min, b.Max)
+}
+
+// ClampEvent reports that an attribute score was clamped to its bounds.
+// Requested is the value that would have been set without bounds, and
+// Value is the value actually set.
+type ClampEvent struct {
+	Attribute Attribute
+	Requested int
+	Value     int
+	Bounds    Bounds
+}
+
+// String returns a string representation of the ClampEvent.
+func (e ClampEvent) String() string {
+	return fmt.Sprintf("%s clamped from %d to %d (bounds %s)",
+		GetAttributeShortName(e.Attribute), e.Requested, e.Value, e.Bounds)
+}
+
+// SetWithin assigns a new value to the specified attribute, clamped to the given bounds.
+// It returns a ClampEvent if the value was clamped, or nil otherwise.
+func (am AttributesMap) SetWithin(attr Attribute, value int, bounds Bounds) *ClampEvent {
+	clamped, changed := bounds.Clamp(value)
+	am[attr] = clamped
+	if !changed {
+		return nil
+	}
+	return &ClampEvent{Attribute: attr, Requested: value, Value: clamped, Bounds: bounds}
+}
+
+// IncreaseWithin increments the value of the specified attribute by the given amount,
+// clamped to the given bounds.
+// It returns a ClampEvent if the value was clamped, or nil otherwise.
+func (am AttributesMap) IncreaseWithin(attr Attribute, value int, bounds Bounds) *ClampEvent {
+	return am.SetWithin(attr, am[attr]+value, bounds)
+}
+
+// DecreaseWithin decrements the value of the specified attribute by the given amount,
+// clamped to the given bounds.
+// It returns a ClampEvent if the value was clamped, or nil otherwise.
+func (am AttributesMap) DecreaseWithin(attr Attribute, value int, bounds Bounds) *ClampEvent {
+	return am.SetWithin(attr, am[attr]-value, bounds)
+}
+
+// Clamp restricts every attribute in the AttributesMap to the given bounds.
+// It returns the list of ClampEvents for the attributes that were changed.
+func (am AttributesMap) Clamp(bounds Bounds) []ClampEvent {
+	var events []ClampEvent
+	for _, attr := range []Attribute{Str, Dex, Con, Int, Wis, Cha} {
+		if _, ok := am[attr]; !ok {
+			continue
+		}
+		if event := am.SetWithin(attr, am[attr], bounds); event != nil {
+			events = append(events, *event)
+		}
+	}
+	return events
+}
@@ -1,26 +1,227 @@
 package character
 
-// Character represents a player character with a name, job, and attributes.
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/damage"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Character represents a player character with a name, job, level and attributes.
 // It includes JSON struct tags for serialization.
-// Attributes is represented using the AttributesMap type.
 type Character struct {
-	Name       string        `json:"name"`
-	Job        string        `json:"job"`
+	Name  string `json:"name"`
+	Job   string `json:"job"`
+	Level int    `json:"level"`
+	// Attributes holds the base scores, without modifiers.
 	Attributes AttributesMap `json:"attributes"`
+	HitPoints  HitPoints     `json:"hit_points"`
+	// Modifiers holds the effects layered on top of the base scores.
+	Modifiers    Modifiers     `json:"modifiers,omitempty"`
+	Inventory    Inventory     `json:"inventory"`
+	Wallet       Wallet        `json:"wallet"`
+	Spellcasting *Spellcasting `json:"spellcasting,omitempty"`
+	// Conditions lists the conditions altering the character rolls.
+	Conditions Conditions `json:"conditions,omitempty"`
+	// State tracks whether the character is conscious, dying, stable or
+	// dead, and DeathSaves the death saves rolled while dying.
+	State      LifeState  `json:"state,omitempty"`
+	DeathSaves DeathSaves `json:"death_saves,omitzero"`
+	// HitDiceSpent counts the hit dice spent on short rests.
+	HitDiceSpent int `json:"hit_dice_spent,omitempty"`
+	// Features lists the features with limited uses recovered by resting.
+	Features []Feature `json:"features,omitempty"`
+	// Defenses lists the damage types the character resists, is vulnerable
+	// or immune to.
+	Defenses damage.Defenses `json:"defenses,omitzero"`
+	// Skills lists the skills the character is proficient in.
+	Skills []Skill `json:"skills,omitempty"`
+	// Background is the ID of the character background, which grants the
+	// Tools proficiencies, the Languages and the rolled Personality.
+	Background  string      `json:"background,omitempty"`
+	Tools       []string    `json:"tools,omitempty"`
+	Languages   []string    `json:"languages,omitempty"`
+	Personality Personality `json:"personality,omitzero"`
+	// Feats lists the IDs of the feats taken.
+	Feats []string `json:"feats,omitempty"`
+	// Improvements counts the ability score improvements not spent yet.
+	Improvements int `json:"improvements,omitempty"`
+	// Classes lists the levels in each class of a multiclass character, the
+	// first class being Job.
+	Classes []ClassLevel `json:"classes,omitempty"`
+	// Proficiencies lists the armor and weapon proficiencies.
+	Proficiencies []string `json:"proficiencies,omitempty"`
+	// Saves lists the saving throws the character is proficient in besides
+	// the ones of its first class, such as those granted by feats.
+	Saves []Attribute `json:"saves,omitempty"`
+	// Journal holds the changes recorded with Record, which can be undone
+	// and redone.
+	Journal Journal `json:"journal,omitzero"`
+	// Transcript records the creation run the character was rolled with.
+	Transcript *Transcript `json:"transcript,omitempty"`
 }
 
 // NewCharacter creates and returns a new Character instance.
 // It takes the character's name, job, and attributes as parameters.
+// The character starts at level 1.
 // It returns a pointer to the newly created Character.
 func NewCharacter(name string, job string, attributes AttributesMap) *Character {
 	return &Character{
 		Name:       name,
 		Job:        job,
+		Level:      1,
 		Attributes: attributes,
+		State:      Conscious,
+	}
+}
+
+// Slug returns the file name for a character name, without extension, in
+// lower case with underscores instead of spaces. Other characters that are
+// not letters, digits, underscores or hyphens are dropped, so the name can
+// not leave the folder it is saved in, and an empty result is "character".
+func Slug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "character"
+	}
+	return b.String()
+}
+
+// SaveCharacter marshals the character to JSON and writes it to the given file.
+// It returns any error encountered during the process.
+func SaveCharacter(filename string, c *Character) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal character: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON file: %w", err)
 	}
+	return nil
+}
+
+// LoadCharacter reads a character from a JSON file and unmarshals it
+// into a Character struct.
+// It returns the Character and any error encountered during the process.
+func LoadCharacter(filename string) (*Character, error) {
+	file, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON file: %w", err)
+	}
+	var c Character
+	if err := json.Unmarshal(file, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	if c.Attributes == nil {
+		c.Attributes = NewAttributesMap()
+	}
+	if c.Level < 1 {
+		c.Level = 1
+	}
+	if c.State == "" {
+		c.State = Conscious
+	}
+	return &c, nil
 }
 
 // String returns a string representation of the Character.
 func (c *Character) String() string {
 	return c.Name + " the " + c.Job + " [" + c.Attributes.String() + "]"
 }
+
+// EffectiveAttributes returns the attribute scores after applying all
+// modifiers, clamped to AbsoluteBounds.
+// The base Attributes are not modified.
+func (c *Character) EffectiveAttributes() AttributesMap {
+	attributes := c.Modifiers.Apply(c.Attributes)
+	attributes.Clamp(AbsoluteBounds)
+	return attributes
+}
+
+// EffectiveAttribute returns the score of the given attribute after applying
+// all modifiers, clamped to AbsoluteBounds.
+func (c *Character) EffectiveAttribute(attr Attribute) int {
+	score, _ := AbsoluteBounds.Clamp(c.Modifiers.Effective(c.Attributes, attr))
+	return score
+}
+
+// AttributeSources lists the sources contributing to the effective score of
+// the given attribute, starting with the base score.
+func (c *Character) AttributeSources(attr Attribute) []Contribution {
+	return c.Modifiers.Sources(c.Attributes, attr)
+}
+
+// ApplyStartingEquipment adds the starting equipment package for the
+// character class to the inventory.
+// It returns false if the class has no starting equipment package.
+func (c *Character) ApplyStartingEquipment(data *ItemData) bool {
+	entries := data.GetStartingEquipment(c.Job)
+	for _, entry := range entries {
+		c.Inventory.Add(entry.ItemID, entry.Quantity)
+	}
+	return entries != nil
+}
+
+// ArmorClass returns the armor class of the character from the equipped
+// armor and shield and the effective dexterity score. Without armor the
+// armor class is 10 plus the dexterity modifier.
+func (c *Character) ArmorClass(data *ItemData) int {
+	dex := AbilityModifier(c.EffectiveAttribute(Dex))
+	ac := 10 + dex
+	if armor, ok := data.GetItem(c.Inventory.EquippedIn(SlotBody)); ok && armor.Type == ItemArmor {
+		if armor.MaxDexBonus != nil {
+			dex = min(dex, *armor.MaxDexBonus)
+		}
+		ac = armor.ArmorClass + dex
+	}
+	if shield, ok := data.GetItem(c.Inventory.EquippedIn(SlotOffHand)); ok && shield.Type == ItemShield {
+		ac += shield.ArmorClass
+	}
+	return ac
+}
+
+// ProficiencyBonus returns the proficiency bonus for the character level.
+func (c *Character) ProficiencyBonus() int {
+	return ProficiencyBonus(c.Level)
+}
+
+// CarryingCapacity returns the maximum weight in pounds the character can
+// carry, based on the effective strength score.
+func (c *Character) CarryingCapacity() float64 {
+	return CarryingCapacity(c.EffectiveAttribute(Str))
+}
+
+// Encumbrance returns the encumbrance level of the character, based on the
+// weight of the inventory and the effective strength score.
+func (c *Character) Encumbrance(data *ItemData) EncumbranceLevel {
+	return GetEncumbrance(c.Inventory.Weight(data), c.EffectiveAttribute(Str))
+}
+
+// RollStartingGold rolls the starting gold for the character class and
+// adds it to the wallet.
+// It returns the number of gold pieces rolled, or an error if the class has
+// no starting gold roll.
+func (c *Character) RollStartingGold(data *ItemData) (int, error) {
+	notation, ok := data.GetStartingGold(c.Job)
+	if !ok {
+		return 0, fmt.Errorf("no starting gold for job %q", c.Job)
+	}
+	expr, err := dice.Parse(notation)
+	if err != nil {
+		return 0, err
+	}
+	gold := expr.Roll()
+	c.Wallet.Add(GP, gold)
+	return gold, nil
+}
@@ -3,51 +3,134 @@ package character
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"maps"
+
+	"github.com/jrecuero/DandD/internal/dataformat"
 )
 
 // CharacterCreationData represents the structure of the character data JSON file.
-// It includes starting attributes and a list of questions.
+// It includes starting attributes, optional attribute bounds and a list of questions.
+// Hash identifies the file the data was loaded from, as returned by
+// HashCreationData, and is recorded in creation transcripts.
 type CharacterCreationData struct {
 	StartingAttributes map[string]int `json:"starting_attributes"`
+	AttributeBounds    *Bounds        `json:"attribute_bounds,omitempty"`
 	Questions          []Question     `json:"questions"`
+	Hash               string         `json:"-"`
+}
+
+// Bounds returns the attribute bounds declared by the creation data.
+// If the data does not declare any bounds, CreationBounds is returned.
+func (d *CharacterCreationData) Bounds() Bounds {
+	if d.AttributeBounds == nil {
+		return CreationBounds
+	}
+	return *d.AttributeBounds
 }
 
 // Question represents a single question in the character creation process.
 // It includes the year, the prompt (yest), and a pool of possible answers.
+// Disabled marks a question a data pack removes when merged with others.
 type Question struct {
 	Year     int      `json:"year"`
 	Question string   `json:"question"`
 	Answers  []Answer `json:"answers_pool"`
+	Disabled bool     `json:"disabled,omitempty"`
 }
 
 // Answer represents a possible answer to a question.
 // It includes the answer ID, description, attribute increases, test details,
-// and fail effects.
+// fail effects and the flags describing the upbringing, such as "lore" or
+// "wilderness", used to infer a background. Disabled marks an answer a data
+// pack removes when merged with others.
 type Answer struct {
 	AnswerID    string         `json:"id"`
 	Description string         `json:"description"`
-	Increases   map[string]int `json:"attribute_rewards"`
+	Increases   map[string]int `json:"attribute_rewards,omitempty"`
 	Test        string         `json:"test_attribute"`
 	DC          int            `json:"dc"`
-	FailEffect  map[string]int `json:"fail_penalty"`
+	FailEffect  map[string]int `json:"fail_penalty,omitempty"`
+	Flags       []string       `json:"flags,omitempty"`
+	Disabled    bool           `json:"disabled,omitempty"`
+}
+
+// Upbringing records the answers chosen during character creation and the
+// flags they collected, in the order they were chosen.
+// A flag collected by several answers appears once for each of them.
+type Upbringing struct {
+	Answers []string `json:"answers"`
+	Flags   []string `json:"flags,omitempty"`
 }
 
-// LoadCharacterData reads the character data from a JSON file and unmarshals
-// it into a CharacterData struct.
+// Record adds an answer and its flags to the upbringing.
+func (u *Upbringing) Record(answer Answer) {
+	u.Answers = append(u.Answers, answer.AnswerID)
+	u.Flags = append(u.Flags, answer.Flags...)
+}
+
+// FlagCount returns how many times each flag was collected.
+func (u Upbringing) FlagCount() map[string]int {
+	count := map[string]int{}
+	for _, flag := range u.Flags {
+		count[flag]++
+	}
+	return count
+}
+
+// LoadCharacterData reads the character data from a JSON, YAML or TOML file,
+// chosen by its extension, and unmarshals it into a CharacterData struct.
 // It returns the CharacterData and any error encountered during the process.
 func LoadCharacterData(filename string) (*CharacterCreationData, error) {
-	file, err := os.ReadFile(filename)
+	file, err := dataformat.ReadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read JSON file: %w", err)
+		return nil, err
 	}
+	return ParseCharacterData(file)
+}
+
+// ParseCharacterData unmarshals the character data from JSON content into
+// a CharacterCreationData struct, without zero attribute rewards and fail
+// penalties, and hashes it.
+// Attribute bounds missing their min or max take it from CreationBounds.
+// It returns the CharacterCreationData and any error encountered during the
+// process, including attribute bounds that are not valid.
+func ParseCharacterData(content []byte) (*CharacterCreationData, error) {
 	var data CharacterCreationData
-	if err := json.Unmarshal(file, &data); err != nil {
+	if err := json.Unmarshal(content, &data); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
 	}
+	if bounds := data.AttributeBounds; bounds != nil {
+		if bounds.Min == 0 {
+			bounds.Min = CreationBounds.Min
+		}
+		if bounds.Max == 0 {
+			bounds.Max = CreationBounds.Max
+		}
+		if err := bounds.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid attribute bounds: %w", err)
+		}
+	}
+	data.Sparse()
+	canonical, err := json.Marshal(&data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON data: %w", err)
+	}
+	data.Hash = HashCreationData(canonical)
 	return &data, nil
 }
 
+// Sparse removes the zero attribute rewards and fail penalties of every
+// answer, which have no effect, so the data is the same whether its file
+// lists them or not.
+func (d *CharacterCreationData) Sparse() {
+	for _, q := range d.Questions {
+		for _, a := range q.Answers {
+			maps.DeleteFunc(a.Increases, func(_ string, value int) bool { return value == 0 })
+			maps.DeleteFunc(a.FailEffect, func(_ string, value int) bool { return value == 0 })
+		}
+	}
+}
+
 // GetAttributeIncreases converts the attribute increases from an Answer
 // into an AttributesMap. It maps attribute short names to their corresponding
 // Attribute values and includes only non-zero increases.
@@ -65,7 +148,8 @@ func GetAttributeIncreases(answer Answer) map[Attribute]int {
 
 // GetAttributeFailEffects converts the fail effects from an Answer
 // into an AttributesMap. It maps attribute short names to their corresponding
-// Attribute values and includes only non-zero effects.
+// Attribute values and includes only non-zero effects, which are signed
+// amounts added to the attributes, negative for a penalty.
 func GetAttributeFailEffects(answer Answer) map[Attribute]int {
 	attributes := map[Attribute]int{}
 	for attrName, value := range answer.FailEffect {
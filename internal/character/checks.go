@@ -0,0 +1,274 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Skill represents a skill used in ability checks, such as Athletics.
+type Skill string
+
+// Enumeration of skills.
+const (
+	Acrobatics     Skill = "acrobatics"
+	AnimalHandling Skill = "animal_handling"
+	Arcana         Skill = "arcana"
+	Athletics      Skill = "athletics"
+	Deception      Skill = "deception"
+	History        Skill = "history"
+	Insight        Skill = "insight"
+	Intimidation   Skill = "intimidation"
+	Investigation  Skill = "investigation"
+	Medicine       Skill = "medicine"
+	Nature         Skill = "nature"
+	Perception     Skill = "perception"
+	Performance    Skill = "performance"
+	Persuasion     Skill = "persuasion"
+	Religion       Skill = "religion"
+	SleightOfHand  Skill = "sleight_of_hand"
+	Stealth        Skill = "stealth"
+	Survival       Skill = "survival"
+)
+
+// skillAttributes maps each skill to the attribute it is based on.
+var skillAttributes = map[Skill]Attribute{
+	Acrobatics:     Dex,
+	AnimalHandling: Wis,
+	Arcana:         Int,
+	Athletics:      Str,
+	Deception:      Cha,
+	History:        Int,
+	Insight:        Wis,
+	Intimidation:   Cha,
+	Investigation:  Int,
+	Medicine:       Wis,
+	Nature:         Int,
+	Perception:     Wis,
+	Performance:    Cha,
+	Persuasion:     Cha,
+	Religion:       Int,
+	SleightOfHand:  Dex,
+	Stealth:        Dex,
+	Survival:       Wis,
+}
+
+// ParseSkill returns the Skill with the given name, ignoring case and
+// accepting spaces instead of underscores, as in "Sleight of Hand".
+// It returns an error if the name does not correspond to any Skill.
+func ParseSkill(name string) (Skill, error) {
+	skill := Skill(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_"))
+	if _, ok := skillAttributes[skill]; !ok {
+		return "", fmt.Errorf("unknown skill %q", name)
+	}
+	return skill, nil
+}
+
+// Attribute returns the attribute the skill is based on.
+func (s Skill) Attribute() Attribute {
+	return skillAttributes[s]
+}
+
+// Check identifies what an ability check rolls: an attribute, or a skill
+// and the attribute it is based on.
+type Check struct {
+	Attribute Attribute `json:"attribute"`
+	Skill     Skill     `json:"skill,omitempty"`
+}
+
+// AttributeCheck returns a Check of the given attribute without a skill.
+func AttributeCheck(attr Attribute) Check {
+	return Check{Attribute: attr}
+}
+
+// SkillCheck returns a Check of the given skill with its usual attribute.
+func SkillCheck(skill Skill) Check {
+	return Check{Attribute: skill.Attribute(), Skill: skill}
+}
+
+// String returns the check as "STR (athletics)", or only the attribute.
+func (c Check) String() string {
+	if c.Skill == "" {
+		return GetAttributeShortName(c.Attribute)
+	}
+	return fmt.Sprintf("%s (%s)", GetAttributeShortName(c.Attribute), strings.ReplaceAll(string(c.Skill), "_", " "))
+}
+
+// IsProficient returns true if the character is proficient in the skill.
+func (c *Character) IsProficient(skill Skill) bool {
+	return slices.Contains(c.Skills, skill)
+}
+
+// CheckModifier returns the modifier of a check: the modifier of the
+// effective attribute score, plus the proficiency bonus when the character
+// is proficient in the skill.
+func (c *Character) CheckModifier(check Check) int {
+	mod := AbilityModifier(c.EffectiveAttribute(check.Attribute))
+	if check.Skill != "" && c.IsProficient(check.Skill) {
+		mod += c.ProficiencyBonus()
+	}
+	return mod
+}
+
+// RollCheck rolls an ability check, with or without a skill, against a DC.
+// Conditions may impose advantage or disadvantage.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func (c *Character) RollCheck(check Check, dc int, r dice.Roller) RollResult {
+	result := c.Conditions.Roll(AbilityCheck, check.Attribute, c.CheckModifier(check), dc, r)
+	result.Name = c.Name
+	result.Skill = check.Skill
+	return result
+}
+
+// PassiveScore returns the passive score of a check, 10 plus the check
+// modifier, with 5 more under advantage or 5 less under disadvantage.
+func (c *Character) PassiveScore(check Check) int {
+	score := 10 + c.CheckModifier(check)
+	switch c.Conditions.RollMode(AbilityCheck, check.Attribute) {
+	case Advantage:
+		score += 5
+	case Disadvantage:
+		score -= 5
+	}
+	return score
+}
+
+// BestCheck returns the check with the highest modifier for the character,
+// such as Athletics or Acrobatics to escape a grapple.
+// The first check wins ties.
+func (c *Character) BestCheck(first Check, rest ...Check) Check {
+	best := first
+	for _, check := range rest {
+		if c.CheckModifier(check) > c.CheckModifier(best) {
+			best = check
+		}
+	}
+	return best
+}
+
+// ContestResult represents the outcome of a contest between two characters.
+// Active is the check of the character starting the contest and Opposing
+// the check of the character resisting it. When Passive is set, the
+// opposing character did not roll and its total is its passive score.
+// Ties favor the opposing character, who keeps the situation unchanged.
+type ContestResult struct {
+	Active   RollResult `json:"active"`
+	Opposing RollResult `json:"opposing"`
+	Passive  bool       `json:"passive,omitempty"`
+	Winner   string     `json:"winner"`
+}
+
+// ActiveWins returns true if the character starting the contest won.
+// The outcome is read from the rolls, not from Winner, so it holds when both
+// characters share a name.
+func (r ContestResult) ActiveWins() bool {
+	return r.Active.Success
+}
+
+// String returns a string representation of the ContestResult.
+func (r ContestResult) String() string {
+	opposing := r.Opposing.String()
+	if r.Passive {
+		opposing = fmt.Sprintf("%s passive %s %d", r.Opposing.Name, r.Opposing.Check(), r.Opposing.Total)
+	}
+	return fmt.Sprintf("%s vs %s: %s wins", r.Active, opposing, r.Winner)
+}
+
+// resolve sets the DC of each roll to the total of the other one and picks
+// the winner, with ties going to the opposing character.
+func (r *ContestResult) resolve() {
+	r.Active.DC = r.Opposing.Total
+	r.Opposing.DC = r.Active.Total
+	r.Active.Success = r.Active.Total > r.Opposing.Total
+	r.Opposing.Success = !r.Active.Success
+	r.Winner = r.Opposing.Name
+	if r.Active.Success {
+		r.Winner = r.Active.Name
+	}
+}
+
+// Contest rolls a contested check between two characters, such as
+// Athletics against Athletics or Acrobatics to grapple. The character with
+// the higher total wins, and the opposing character wins ties.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func Contest(active *Character, activeCheck Check, opposing *Character, opposingCheck Check, r dice.Roller) ContestResult {
+	result := ContestResult{
+		Active:   active.RollCheck(activeCheck, 0, r),
+		Opposing: opposing.RollCheck(opposingCheck, 0, r),
+	}
+	result.resolve()
+	return result
+}
+
+// ContestPassive compares an active check against the passive score of the
+// opposing character, such as Stealth against passive Perception.
+// The active check must beat the passive score.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func ContestPassive(active *Character, activeCheck Check, opposing *Character, passiveCheck Check, r dice.Roller) ContestResult {
+	score := opposing.PassiveScore(passiveCheck)
+	result := ContestResult{
+		Active: active.RollCheck(activeCheck, 0, r),
+		Opposing: RollResult{Name: opposing.Name, Kind: AbilityCheck, Attribute: passiveCheck.Attribute,
+			Skill: passiveCheck.Skill, Modifier: score - 10, Total: score},
+		Passive: true,
+	}
+	result.resolve()
+	return result
+}
+
+// Grapple has the attacker try to grapple the defender with an Athletics
+// check, contested by the better of the defender Athletics or Acrobatics.
+// On a success the defender is grappled by the attacker.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func Grapple(attacker *Character, defender *Character, r dice.Roller) ContestResult {
+	defense := defender.BestCheck(SkillCheck(Athletics), SkillCheck(Acrobatics))
+	result := Contest(attacker, SkillCheck(Athletics), defender, defense, r)
+	if result.ActiveWins() {
+		// Add only fails for unknown conditions or save attributes, and
+		// Grappled is known and has no save.
+		_ = defender.Conditions.Add(ActiveCondition{Condition: Grappled, Source: attacker.Name})
+	}
+	return result
+}
+
+// GroupResult represents the outcome of a group check, where the group
+// succeeds when at least half of its members succeed.
+type GroupResult struct {
+	Check     Check        `json:"check"`
+	DC        int          `json:"dc"`
+	Results   []RollResult `json:"results"`
+	Successes int          `json:"successes"`
+	Success   bool         `json:"success"`
+}
+
+// String returns a string representation of the GroupResult, with the
+// roll of every member on its own line.
+func (r GroupResult) String() string {
+	outcome := "failure"
+	if r.Success {
+		outcome = "success"
+	}
+	lines := []string{fmt.Sprintf("group %s check vs DC %d: %d/%d succeed, %s", r.Check, r.DC, r.Successes, len(r.Results), outcome)}
+	for _, result := range r.Results {
+		lines = append(lines, "  "+result.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GroupCheck rolls the same check for every member of a group against a DC.
+// The group succeeds when at least half of the members succeed.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func GroupCheck(members []*Character, check Check, dc int, r dice.Roller) GroupResult {
+	result := GroupResult{Check: check, DC: dc}
+	for _, member := range members {
+		roll := member.RollCheck(check, dc, r)
+		if roll.Success {
+			result.Successes++
+		}
+		result.Results = append(result.Results, roll)
+	}
+	result.Success = len(members) > 0 && result.Successes*2 >= len(members)
+	return result
+}
@@ -0,0 +1,227 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ClassLevel represents the levels a character has in a single class.
+// It includes JSON struct tags for serialization.
+type ClassLevel struct {
+	Job   string `json:"job"`
+	Level int    `json:"level"`
+}
+
+// String returns the class and its level, as in "fighter 3".
+func (cl ClassLevel) String() string {
+	return fmt.Sprintf("%s %d", cl.Job, cl.Level)
+}
+
+// multiclassRule holds the rules for taking levels in a class as a second
+// or later class. Every score in All is required, and one of the scores in
+// Any. Proficiencies lists the armor and weapon proficiencies granted, and
+// Tools the tool proficiencies. Skills is the number of skill proficiencies
+// chosen from SkillList, or from any skill when SkillList is empty.
+type multiclassRule struct {
+	All           AttributesMap
+	Any           AttributesMap
+	Proficiencies []string
+	Tools         []string
+	Skills        int
+	SkillList     []Skill
+}
+
+// multiclassRules maps each class to its multiclassing rules.
+// Classes missing from the table can not be taken when multiclassing.
+var multiclassRules = map[string]multiclassRule{
+	"barbarian": {All: AttributesMap{Str: 13},
+		Proficiencies: []string{"shields", "simple weapons", "martial weapons"}},
+	"bard": {All: AttributesMap{Cha: 13},
+		Proficiencies: []string{"light armor"}, Tools: []string{"musical instrument"}, Skills: 1},
+	"cleric": {All: AttributesMap{Wis: 13},
+		Proficiencies: []string{"light armor", "medium armor", "shields"}},
+	"druid": {All: AttributesMap{Wis: 13},
+		Proficiencies: []string{"light armor", "medium armor", "shields"}},
+	"fighter": {Any: AttributesMap{Str: 13, Dex: 13},
+		Proficiencies: []string{"light armor", "medium armor", "shields", "simple weapons", "martial weapons"}},
+	"monk": {All: AttributesMap{Dex: 13, Wis: 13},
+		Proficiencies: []string{"simple weapons", "shortswords"}},
+	"paladin": {All: AttributesMap{Str: 13, Cha: 13},
+		Proficiencies: []string{"light armor", "medium armor", "shields", "simple weapons", "martial weapons"}},
+	"ranger": {All: AttributesMap{Dex: 13, Wis: 13},
+		Proficiencies: []string{"light armor", "medium armor", "shields", "simple weapons", "martial weapons"},
+		Skills:        1,
+		SkillList:     []Skill{AnimalHandling, Athletics, Insight, Investigation, Nature, Perception, Stealth, Survival}},
+	"rogue": {All: AttributesMap{Dex: 13},
+		Proficiencies: []string{"light armor"}, Tools: []string{"thieves' tools"}, Skills: 1,
+		SkillList: []Skill{Acrobatics, Athletics, Deception, Insight, Intimidation, Investigation, Perception,
+			Performance, Persuasion, SleightOfHand, Stealth}},
+	"sorcerer": {All: AttributesMap{Cha: 13}},
+	"warlock": {All: AttributesMap{Cha: 13},
+		Proficiencies: []string{"light armor", "simple weapons"}},
+	"wizard": {All: AttributesMap{Int: 13}},
+}
+
+// saveProficiencies maps each class to the saving throws it is proficient
+// in. Only the first class of a character grants them.
+var saveProficiencies = map[string][]Attribute{
+	"barbarian": {Str, Con},
+	"bard":      {Dex, Cha},
+	"cleric":    {Wis, Cha},
+	"druid":     {Int, Wis},
+	"fighter":   {Str, Con},
+	"monk":      {Str, Dex},
+	"paladin":   {Wis, Cha},
+	"ranger":    {Str, Dex},
+	"rogue":     {Dex, Int},
+	"sorcerer":  {Con, Cha},
+	"warlock":   {Wis, Cha},
+	"wizard":    {Int, Wis},
+}
+
+// unmetScores returns a description of every score requirement the
+// attributes do not meet. Every score in all is required, and at least one
+// of the scores in any.
+func unmetScores(attributes AttributesMap, all AttributesMap, any AttributesMap) []string {
+	var unmet []string
+	for _, attr := range sortedAttributes(all) {
+		if score := attributes.Get(attr); score < all.Get(attr) {
+			unmet = append(unmet, fmt.Sprintf("requires %s %d (has %d)", attr, all.Get(attr), score))
+		}
+	}
+	if len(any) > 0 {
+		met := false
+		var options []string
+		for _, attr := range sortedAttributes(any) {
+			met = met || attributes.Get(attr) >= any.Get(attr)
+			options = append(options, fmt.Sprintf("%s %d", attr, any.Get(attr)))
+		}
+		if !met {
+			unmet = append(unmet, "requires "+strings.Join(options, " or "))
+		}
+	}
+	return unmet
+}
+
+// ClassLevels returns the levels the character has in each class, the
+// first class first. Characters with a single class may not list it, in
+// which case their job and level are returned.
+func (c *Character) ClassLevels() []ClassLevel {
+	if len(c.Classes) == 0 {
+		return []ClassLevel{{Job: normalizeJob(c.Job), Level: c.Level}}
+	}
+	return slices.Clone(c.Classes)
+}
+
+// ClassLevel returns the levels the character has in the given class.
+func (c *Character) ClassLevel(job string) int {
+	for _, cl := range c.ClassLevels() {
+		if cl.Job == normalizeJob(job) {
+			return cl.Level
+		}
+	}
+	return 0
+}
+
+// IsSaveProficient returns true if the character is proficient in saving
+// throws for the given attribute, as granted by its first class or listed
+// in Saves.
+func (c *Character) IsSaveProficient(attr Attribute) bool {
+	return slices.Contains(saveProficiencies[c.ClassLevels()[0].Job], attr) || slices.Contains(c.Saves, attr)
+}
+
+// SaveModifier returns the modifier of a saving throw: the modifier of the
+// effective attribute score, plus the proficiency bonus when the character
+// is proficient in the save.
+func (c *Character) SaveModifier(attr Attribute) int {
+	mod := AbilityModifier(c.EffectiveAttribute(attr))
+	if c.IsSaveProficient(attr) {
+		mod += c.ProficiencyBonus()
+	}
+	return mod
+}
+
+// IsMulticlass returns true if the character has levels in several classes.
+func (c *Character) IsMulticlass() bool {
+	return len(c.ClassLevels()) > 1
+}
+
+// MulticlassUnmet returns a description of every multiclassing prerequisite
+// the character does not meet to take a level in a new class, or nil.
+// The character must meet the prerequisites of the new class and of every
+// class it already has, checked against the base attributes.
+func (c *Character) MulticlassUnmet(job string) []string {
+	var unmet []string
+	jobs := []string{normalizeJob(job)}
+	for _, cl := range c.ClassLevels() {
+		if cl.Job != jobs[0] {
+			jobs = append(jobs, cl.Job)
+		}
+	}
+	for _, j := range jobs {
+		rule := multiclassRules[j]
+		for _, reason := range unmetScores(c.Attributes, rule.All, rule.Any) {
+			unmet = append(unmet, j+" "+reason)
+		}
+	}
+	return unmet
+}
+
+// addClass adds a first level in a new class, checking the multiclassing
+// prerequisites and granting the multiclass proficiencies of the class,
+// with the skills chosen by the player.
+// It returns an error if the class is unknown, or a skill is chosen twice
+// or already known. The character is not modified on error.
+func (c *Character) addClass(job string, skills []Skill) error {
+	rule, ok := multiclassRules[job]
+	if !ok {
+		return fmt.Errorf("unknown class %q", job)
+	}
+	if unmet := c.MulticlassUnmet(job); len(unmet) > 0 {
+		return fmt.Errorf("cannot multiclass into %s: %s", job, strings.Join(unmet, "; "))
+	}
+	for i, skill := range skills {
+		if slices.Contains(skills[:i], skill) {
+			return fmt.Errorf("skill %s is chosen more than once", skill)
+		}
+	}
+	if len(skills) != rule.Skills {
+		return fmt.Errorf("multiclassing into %s grants %d skills, got %d", job, rule.Skills, len(skills))
+	}
+	for _, skill := range skills {
+		if _, err := ParseSkill(string(skill)); err != nil {
+			return err
+		}
+		if len(rule.SkillList) > 0 && !slices.Contains(rule.SkillList, skill) {
+			return fmt.Errorf("skill %s is not available to %s", skill, job)
+		}
+		if c.IsProficient(skill) {
+			return fmt.Errorf("%s is already proficient in %s", c.Name, skill)
+		}
+	}
+	c.Classes = append(c.ClassLevels(), ClassLevel{Job: job})
+	c.Skills = append(c.Skills, skills...)
+	for _, proficiency := range rule.Proficiencies {
+		if !slices.Contains(c.Proficiencies, proficiency) {
+			c.Proficiencies = append(c.Proficiencies, proficiency)
+		}
+	}
+	for _, tool := range rule.Tools {
+		if !slices.Contains(c.Tools, tool) {
+			c.Tools = append(c.Tools, tool)
+		}
+	}
+	return nil
+}
+
+// ClassString returns the classes of the character with their levels, as
+// in "fighter 3 / wizard 2".
+func (c *Character) ClassString() string {
+	classes := c.ClassLevels()
+	parts := make([]string, len(classes))
+	for i, cl := range classes {
+		parts[i] = cl.String()
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,341 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Condition represents a standard condition altering what a creature can do.
+type Condition string
+
+// Enumeration of the standard conditions.
+// Exhaustion is measured in levels, from 1 to MaxExhaustion.
+const (
+	Blinded       Condition = "blinded"
+	Charmed       Condition = "charmed"
+	Deafened      Condition = "deafened"
+	Frightened    Condition = "frightened"
+	Grappled      Condition = "grappled"
+	Incapacitated Condition = "incapacitated"
+	Invisible     Condition = "invisible"
+	Paralyzed     Condition = "paralyzed"
+	Petrified     Condition = "petrified"
+	Poisoned      Condition = "poisoned"
+	Prone         Condition = "prone"
+	Restrained    Condition = "restrained"
+	Stunned       Condition = "stunned"
+	Unconscious   Condition = "unconscious"
+	Exhaustion    Condition = "exhaustion"
+)
+
+// MaxExhaustion is the exhaustion level at which a creature dies.
+const MaxExhaustion = 6
+
+// conditionEffect describes how a condition alters the rolls of a creature.
+// Attack applies to attacks made by the creature, Attacked to attacks made
+// against it, Check to its ability checks and Saves to its saving throws
+// for specific attributes. FailSaves lists the saving throws that fail
+// automatically, and Incapacitated creatures cannot take actions.
+type conditionEffect struct {
+	Attack        RollMode
+	Attacked      RollMode
+	Check         RollMode
+	Saves         map[Attribute]RollMode
+	FailSaves     []Attribute
+	Incapacitated bool
+}
+
+// conditionEffects maps each condition to its effect on rolls.
+// Conditions without effects on rolls, like charmed or grappled, are listed
+// so they are recognized. Attacks against prone creatures are assumed to be
+// made within 5 feet. Exhaustion effects depend on the level and are
+// computed separately.
+var conditionEffects = map[Condition]conditionEffect{
+	Blinded:       {Attack: Disadvantage, Attacked: Advantage},
+	Charmed:       {},
+	Deafened:      {},
+	Frightened:    {Attack: Disadvantage, Check: Disadvantage},
+	Grappled:      {},
+	Incapacitated: {Incapacitated: true},
+	Invisible:     {Attack: Advantage, Attacked: Disadvantage},
+	Paralyzed:     {Attacked: Advantage, FailSaves: []Attribute{Str, Dex}, Incapacitated: true},
+	Petrified:     {Attacked: Advantage, FailSaves: []Attribute{Str, Dex}, Incapacitated: true},
+	Poisoned:      {Attack: Disadvantage, Check: Disadvantage},
+	Prone:         {Attack: Disadvantage, Attacked: Advantage},
+	Restrained:    {Attack: Disadvantage, Attacked: Advantage, Saves: map[Attribute]RollMode{Dex: Disadvantage}},
+	Stunned:       {Attacked: Advantage, FailSaves: []Attribute{Str, Dex}, Incapacitated: true},
+	Unconscious:   {Attacked: Advantage, FailSaves: []Attribute{Str, Dex}, Incapacitated: true},
+	Exhaustion:    {},
+}
+
+// ParseCondition returns the Condition with the given name, ignoring case.
+// It returns an error if the name does not correspond to any Condition.
+func ParseCondition(name string) (Condition, error) {
+	condition := Condition(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := conditionEffects[condition]; !ok {
+		return "", fmt.Errorf("unknown condition %q", name)
+	}
+	return condition, nil
+}
+
+// ActiveCondition represents a condition affecting a creature.
+// It includes JSON struct tags for serialization.
+// Duration is the number of turns the condition lasts; zero means until it
+// is removed. When SaveDC is set, the creature repeats a saving throw of
+// the Save attribute, given by its short name, at the end of each of its
+// turns and the condition ends on a success. Level is only used by
+// exhaustion.
+type ActiveCondition struct {
+	Condition Condition `json:"condition"`
+	Source    string    `json:"source,omitempty"`
+	Level     int       `json:"level,omitempty"`
+	Duration  int       `json:"duration,omitempty"`
+	Save      string    `json:"save,omitempty"`
+	SaveDC    int       `json:"save_dc,omitempty"`
+}
+
+// String returns a string representation of the ActiveCondition.
+func (ac ActiveCondition) String() string {
+	result := string(ac.Condition)
+	if ac.Condition == Exhaustion {
+		result += fmt.Sprintf(" %d", ac.Level)
+	}
+	if ac.Source != "" {
+		result += fmt.Sprintf(" (%s)", ac.Source)
+	}
+	if ac.Duration > 0 {
+		result += fmt.Sprintf(" for %d turns", ac.Duration)
+	}
+	if ac.SaveDC > 0 {
+		result += fmt.Sprintf(", DC %d %s save ends", ac.SaveDC, ac.Save)
+	}
+	return result
+}
+
+// ConditionChange describes a condition that ended.
+// Save holds the saving throw that ended the condition, or nil when its
+// duration expired.
+type ConditionChange struct {
+	Condition ActiveCondition
+	Save      *RollResult
+}
+
+// String returns a string representation of the ConditionChange.
+func (cc ConditionChange) String() string {
+	if cc.Save != nil {
+		return fmt.Sprintf("%s ends: %s", cc.Condition.Condition, cc.Save)
+	}
+	return fmt.Sprintf("%s ends: duration expired", cc.Condition.Condition)
+}
+
+// Conditions is the list of conditions affecting a creature.
+type Conditions []ActiveCondition
+
+// Add applies a condition.
+// Exhaustion adds its level, one by default, to the current exhaustion up
+// to MaxExhaustion. Any other condition replaces the same condition from
+// the same source, so a creature can be frightened by two sources at once.
+// It returns an error if the condition is unknown or its save attribute
+// is not valid.
+func (cs *Conditions) Add(ac ActiveCondition) error {
+	if _, ok := conditionEffects[ac.Condition]; !ok {
+		return fmt.Errorf("unknown condition %q", ac.Condition)
+	}
+	if ac.SaveDC > 0 {
+		if _, ok := GetAttributeFromShortName(ac.Save); !ok {
+			return fmt.Errorf("invalid save attribute %q for condition %s", ac.Save, ac.Condition)
+		}
+	}
+	if ac.Condition == Exhaustion {
+		levels := max(ac.Level, 1)
+		for i := range *cs {
+			if (*cs)[i].Condition == Exhaustion {
+				(*cs)[i].Level = min((*cs)[i].Level+levels, MaxExhaustion)
+				return nil
+			}
+		}
+		ac.Level = min(levels, MaxExhaustion)
+		*cs = append(*cs, ac)
+		return nil
+	}
+	for i := range *cs {
+		if (*cs)[i].Condition == ac.Condition && (*cs)[i].Source == ac.Source {
+			(*cs)[i] = ac
+			return nil
+		}
+	}
+	*cs = append(*cs, ac)
+	return nil
+}
+
+// Remove ends every instance of the given condition.
+// It returns the number of conditions removed.
+func (cs *Conditions) Remove(condition Condition) int {
+	kept := (*cs)[:0]
+	removed := 0
+	for _, ac := range *cs {
+		if ac.Condition == condition {
+			removed++
+			continue
+		}
+		kept = append(kept, ac)
+	}
+	*cs = kept
+	return removed
+}
+
+// RemoveFrom ends the given condition caused by the given source, keeping
+// the same condition from other sources.
+// It returns the number of conditions removed.
+func (cs *Conditions) RemoveFrom(condition Condition, source string) int {
+	kept := (*cs)[:0]
+	removed := 0
+	for _, ac := range *cs {
+		if ac.Condition == condition && ac.Source == source {
+			removed++
+			continue
+		}
+		kept = append(kept, ac)
+	}
+	*cs = kept
+	return removed
+}
+
+// Has returns true if the creature is affected by the given condition.
+func (cs Conditions) Has(condition Condition) bool {
+	for _, ac := range cs {
+		if ac.Condition == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// ExhaustionLevel returns the current exhaustion level, or zero.
+func (cs Conditions) ExhaustionLevel() int {
+	for _, ac := range cs {
+		if ac.Condition == Exhaustion {
+			return ac.Level
+		}
+	}
+	return 0
+}
+
+// ReduceExhaustion lowers the exhaustion level by the given number of
+// levels, removing exhaustion when it reaches zero.
+// It returns the remaining exhaustion level.
+func (cs *Conditions) ReduceExhaustion(levels int) int {
+	for i := range *cs {
+		if (*cs)[i].Condition == Exhaustion {
+			(*cs)[i].Level -= levels
+			if (*cs)[i].Level > 0 {
+				return (*cs)[i].Level
+			}
+			cs.Remove(Exhaustion)
+			return 0
+		}
+	}
+	return 0
+}
+
+// IsIncapacitated returns true if a condition prevents the creature from
+// taking actions.
+func (cs Conditions) IsIncapacitated() bool {
+	for _, ac := range cs {
+		if conditionEffects[ac.Condition].Incapacitated {
+			return true
+		}
+	}
+	return false
+}
+
+// RollMode returns the roll mode the conditions impose on a roll of the
+// given kind. The attribute is only used by ability checks and saves.
+// Exhaustion imposes disadvantage on ability checks from level 1, and on
+// attack rolls and saving throws from level 3.
+func (cs Conditions) RollMode(kind RollKind, attr Attribute) RollMode {
+	var modes []RollMode
+	for _, ac := range cs {
+		effect := conditionEffects[ac.Condition]
+		switch kind {
+		case AbilityCheck:
+			modes = append(modes, effect.Check)
+		case SavingThrow:
+			modes = append(modes, effect.Saves[attr])
+		case AttackRoll:
+			modes = append(modes, effect.Attack)
+		case Attacked:
+			modes = append(modes, effect.Attacked)
+		}
+	}
+	level := cs.ExhaustionLevel()
+	if (kind == AbilityCheck && level >= 1) || ((kind == AttackRoll || kind == SavingThrow) && level >= 3) {
+		modes = append(modes, Disadvantage)
+	}
+	return CombineRollModes(modes...)
+}
+
+// AutoFailsSave returns true if a condition makes saving throws of the
+// given attribute fail automatically.
+func (cs Conditions) AutoFailsSave(attr Attribute) bool {
+	for _, ac := range cs {
+		for _, failed := range conditionEffects[ac.Condition].FailSaves {
+			if failed == attr {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EndTurn resolves the end of the creature turn.
+// Conditions with a save DC call save with their save attribute and DC and
+// end on a success. Conditions with a duration then lose one turn and end
+// when it reaches zero. The save function may read the conditions, which
+// are only updated once every condition is resolved.
+// It returns the conditions that ended.
+func (cs *Conditions) EndTurn(save func(attr Attribute, dc int) RollResult) []ConditionChange {
+	kept := make(Conditions, 0, len(*cs))
+	var ended []ConditionChange
+	for _, ac := range *cs {
+		if ac.SaveDC > 0 {
+			attr, _ := GetAttributeFromShortName(ac.Save)
+			result := save(attr, ac.SaveDC)
+			if result.Success {
+				ended = append(ended, ConditionChange{Condition: ac, Save: &result})
+				continue
+			}
+		}
+		if ac.Duration > 0 {
+			ac.Duration--
+			if ac.Duration == 0 {
+				ended = append(ended, ConditionChange{Condition: ac})
+				continue
+			}
+		}
+		kept = append(kept, ac)
+	}
+	*cs = kept
+	return ended
+}
+
+// String returns the conditions separated by commas.
+func (cs Conditions) String() string {
+	parts := make([]string, len(cs))
+	for i, ac := range cs {
+		parts[i] = ac.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// EndTurn resolves the end of the character turn, repeating saving throws
+// against its conditions and advancing their durations.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+// It returns the conditions that ended.
+func (c *Character) EndTurn(r dice.Roller) []ConditionChange {
+	return c.Conditions.EndTurn(func(attr Attribute, dc int) RollResult {
+		return c.SavingThrow(attr, dc, r)
+	})
+}
@@ -0,0 +1,210 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// CreationPack represents the creation data of a named data pack.
+type CreationPack struct {
+	Name string
+	Data *CharacterCreationData
+}
+
+// MergeEvent represents a change a data pack made to the merged creation
+// data: an entry added, overridden or disabled.
+type MergeEvent struct {
+	Action string
+	Entry  string
+	Pack   string
+}
+
+// String returns the event as "pack: action entry".
+func (e MergeEvent) String() string {
+	return fmt.Sprintf("%s: %s %s", e.Pack, e.Action, e.Entry)
+}
+
+// MergeConflict represents an entry changed by several data packs, or that
+// a data pack could not change.
+type MergeConflict struct {
+	Entry  string
+	Packs  []string
+	Reason string
+}
+
+// String returns the conflict as "entry: reason (packs)".
+func (c MergeConflict) String() string {
+	return fmt.Sprintf("%s: %s (%s)", c.Entry, c.Reason, strings.Join(c.Packs, ", "))
+}
+
+// MergeReport lists the changes the data packs made on top of the first
+// one, and the conflicts found while merging them.
+type MergeReport struct {
+	Events    []MergeEvent
+	Conflicts []MergeConflict
+}
+
+// creationMerge holds the state of a creation data merge.
+type creationMerge struct {
+	data      *CharacterCreationData
+	report    MergeReport
+	changedBy map[string][]string
+	pack      string
+	base      bool
+}
+
+// record reports a change made by the current pack. Changes made by the
+// first pack are the base data and are not reported. A pack changing the
+// same entry several times is counted once.
+func (m *creationMerge) record(action string, entry string) {
+	if m.base {
+		return
+	}
+	m.report.Events = append(m.report.Events, MergeEvent{Action: action, Entry: entry, Pack: m.pack})
+	if action != "added" && !slices.Contains(m.changedBy[entry], m.pack) {
+		m.changedBy[entry] = append(m.changedBy[entry], m.pack)
+	}
+}
+
+// conflict reports a change the current pack could not make.
+func (m *creationMerge) conflict(entry string, reason string) {
+	m.report.Conflicts = append(m.report.Conflicts, MergeConflict{Entry: entry, Packs: []string{m.pack}, Reason: reason})
+}
+
+// question returns the index of the question for the year, or -1.
+func (m *creationMerge) question(year int) int {
+	return slices.IndexFunc(m.data.Questions, func(q Question) bool { return q.Year == year })
+}
+
+// answer returns the index of the question and answer with the ID, or -1.
+func (m *creationMerge) answer(id string) (int, int) {
+	for qi, q := range m.data.Questions {
+		if ai := slices.IndexFunc(q.Answers, func(a Answer) bool { return a.AnswerID == id }); ai >= 0 {
+			return qi, ai
+		}
+	}
+	return -1, -1
+}
+
+// mergeQuestion merges a question of the current pack: a new year adds the
+// question, and an existing one merges its answers into the question.
+func (m *creationMerge) mergeQuestion(q Question) {
+	entry := fmt.Sprintf("question %d", q.Year)
+	qi := m.question(q.Year)
+	if q.Disabled {
+		if qi < 0 {
+			m.conflict(entry, "disabled but not defined")
+			return
+		}
+		m.data.Questions = slices.Delete(m.data.Questions, qi, qi+1)
+		m.record("disabled", entry)
+		return
+	}
+	if qi < 0 {
+		m.data.Questions = append(m.data.Questions, Question{Year: q.Year, Question: q.Question})
+		qi = len(m.data.Questions) - 1
+		m.record("added", entry)
+	} else if q.Question != "" && q.Question != m.data.Questions[qi].Question {
+		m.data.Questions[qi].Question = q.Question
+		m.record("overridden", entry)
+	}
+	for _, a := range q.Answers {
+		m.mergeAnswer(q.Year, a)
+	}
+}
+
+// mergeAnswer merges an answer of the current pack into the question for
+// the year: a new ID adds the answer, an existing one overrides it and a
+// disabled one removes it. An answer defined for another year is moved.
+func (m *creationMerge) mergeAnswer(year int, a Answer) {
+	entry := "answer " + a.AnswerID
+	qi, ai := m.answer(a.AnswerID)
+	if a.Disabled {
+		if qi < 0 {
+			m.conflict(entry, "disabled but not defined")
+			return
+		}
+		m.data.Questions[qi].Answers = slices.Delete(m.data.Questions[qi].Answers, ai, ai+1)
+		m.record("disabled", entry)
+		return
+	}
+	target := m.question(year)
+	if qi >= 0 && qi != target {
+		m.conflict(entry, fmt.Sprintf("moved from question %d to %d", m.data.Questions[qi].Year, year))
+		m.data.Questions[qi].Answers = slices.Delete(m.data.Questions[qi].Answers, ai, ai+1)
+		qi = -1
+	}
+	if qi < 0 {
+		m.data.Questions[target].Answers = append(m.data.Questions[target].Answers, a)
+		m.record("added", entry)
+		return
+	}
+	if !reflect.DeepEqual(m.data.Questions[qi].Answers[ai], a) {
+		m.data.Questions[qi].Answers[ai] = a
+		m.record("overridden", entry)
+	}
+}
+
+// MergeCreationData merges the creation data of several data packs, the
+// first one being the base data and each of the others applied on top of
+// the previous ones, in order.
+// A pack sets starting attributes one by one and replaces the attribute
+// bounds. Questions are matched by year and answers by AnswerID: new
+// entries are added, existing ones overridden, and entries marked Disabled
+// removed. Questions are sorted by year.
+// An entry changed by several packs is reported as a conflict, and the
+// last pack wins, as are disabled entries that are not defined and answers
+// moved to another question.
+// The merged data keeps the hash of a single pack, or hashes its own JSON
+// form when several packs are merged.
+func MergeCreationData(packs ...CreationPack) (*CharacterCreationData, MergeReport) {
+	m := &creationMerge{data: &CharacterCreationData{StartingAttributes: map[string]int{}}, changedBy: map[string][]string{}}
+	for i, p := range packs {
+		m.pack, m.base = p.Name, i == 0
+		for _, name := range slices.Sorted(maps.Keys(p.Data.StartingAttributes)) {
+			value := p.Data.StartingAttributes[name]
+			old, ok := m.data.StartingAttributes[name]
+			m.data.StartingAttributes[name] = value
+			if !ok {
+				m.record("added", "starting attribute "+name)
+			} else if old != value {
+				m.record("overridden", "starting attribute "+name)
+			}
+		}
+		if p.Data.AttributeBounds != nil {
+			bounds := *p.Data.AttributeBounds
+			if m.data.AttributeBounds == nil || *m.data.AttributeBounds != bounds {
+				m.record("overridden", "attribute bounds")
+			}
+			m.data.AttributeBounds = &bounds
+		}
+		for _, q := range p.Data.Questions {
+			m.mergeQuestion(q)
+		}
+	}
+	slices.SortStableFunc(m.data.Questions, func(a, b Question) int { return a.Year - b.Year })
+
+	for _, entry := range slices.Sorted(maps.Keys(m.changedBy)) {
+		if packs := m.changedBy[entry]; len(packs) > 1 {
+			m.report.Conflicts = append(m.report.Conflicts, MergeConflict{
+				Entry:  entry,
+				Packs:  packs,
+				Reason: fmt.Sprintf("changed by %d packs, %s wins", len(packs), packs[len(packs)-1]),
+			})
+		}
+	}
+
+	switch len(packs) {
+	case 0:
+	case 1:
+		m.data.Hash = packs[0].Data.Hash
+	default:
+		content, _ := json.Marshal(m.data)
+		m.data.Hash = HashCreationData(content)
+	}
+	return m.data, m.report
+}
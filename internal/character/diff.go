@@ -0,0 +1,259 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ValueDiff represents a value that differs between two characters.
+// Old or New is empty when the value only exists in one of them.
+type ValueDiff struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// String returns the difference as "name: old -> new".
+func (vd ValueDiff) String() string {
+	value := func(v string) string {
+		if v == "" {
+			return "none"
+		}
+		return v
+	}
+	return fmt.Sprintf("%s: %s -> %s", vd.Name, value(vd.Old), value(vd.New))
+}
+
+// ListDiff represents the entries added to and removed from a list.
+type ListDiff struct {
+	Name    string
+	Added   []string
+	Removed []string
+}
+
+// IsEmpty returns true if no entry was added or removed.
+func (ld ListDiff) IsEmpty() bool {
+	return len(ld.Added) == 0 && len(ld.Removed) == 0
+}
+
+// String returns the difference as "name: +added -removed".
+func (ld ListDiff) String() string {
+	var parts []string
+	for _, entry := range ld.Added {
+		parts = append(parts, "+"+entry)
+	}
+	for _, entry := range ld.Removed {
+		parts = append(parts, "-"+entry)
+	}
+	return fmt.Sprintf("%s: %s", ld.Name, strings.Join(parts, ", "))
+}
+
+// CharacterDiff reports the differences between two characters, or two
+// versions of the same character, grouped by section. Summary holds the
+// identity, level, hit points and background, Attributes the base and
+// effective scores, Modifiers the active modifiers, Proficiencies the
+// skills, tools, languages, armor and weapon proficiencies and feats,
+// Equipment the carried, equipped and attuned items and the wallet, and
+// Spells the known and prepared spells and the spell slots.
+type CharacterDiff struct {
+	Summary       []ValueDiff
+	Attributes    []ValueDiff
+	Modifiers     ListDiff
+	Proficiencies []ListDiff
+	Equipment     []ValueDiff
+	Spells        []ListDiff
+	SpellSlots    []ValueDiff
+}
+
+// IsEmpty returns true if the characters have no differences.
+func (d CharacterDiff) IsEmpty() bool {
+	return d.String() == ""
+}
+
+// String returns every difference, one per line, under the name of its
+// section, or an empty string if there are none.
+func (d CharacterDiff) String() string {
+	var b strings.Builder
+	section := func(name string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "%s:\n", name)
+		for _, line := range lines {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+	values := func(diffs []ValueDiff) []string {
+		var lines []string
+		for _, vd := range diffs {
+			lines = append(lines, vd.String())
+		}
+		return lines
+	}
+	lists := func(diffs ...ListDiff) []string {
+		var lines []string
+		for _, ld := range diffs {
+			if !ld.IsEmpty() {
+				lines = append(lines, ld.String())
+			}
+		}
+		return lines
+	}
+	section("Summary", values(d.Summary))
+	section("Attributes", values(d.Attributes))
+	section("Modifiers", lists(d.Modifiers))
+	section("Proficiencies", lists(d.Proficiencies...))
+	section("Equipment", values(d.Equipment))
+	section("Spells", append(lists(d.Spells...), values(d.SpellSlots)...))
+	return b.String()
+}
+
+// diffValue appends a ValueDiff to diffs if old and new differ.
+func diffValue(diffs []ValueDiff, name string, old string, new string) []ValueDiff {
+	if old == new {
+		return diffs
+	}
+	return append(diffs, ValueDiff{Name: name, Old: old, New: new})
+}
+
+// diffList returns the entries of new missing from old as added, and the
+// entries of old missing from new as removed, both in list order.
+func diffList(name string, old []string, new []string) ListDiff {
+	ld := ListDiff{Name: name}
+	for _, entry := range new {
+		if !slices.Contains(old, entry) {
+			ld.Added = append(ld.Added, entry)
+		}
+	}
+	for _, entry := range old {
+		if !slices.Contains(new, entry) {
+			ld.Removed = append(ld.Removed, entry)
+		}
+	}
+	return ld
+}
+
+// stringsOf returns the string form of every value.
+func stringsOf[T fmt.Stringer](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = v.String()
+	}
+	return result
+}
+
+// skillNames returns the names of the skills.
+func skillNames(skills []Skill) []string {
+	result := make([]string, len(skills))
+	for i, skill := range skills {
+		result[i] = string(skill)
+	}
+	return result
+}
+
+// Diff reports the differences between the old and new characters.
+func Diff(old *Character, new *Character) CharacterDiff {
+	var d CharacterDiff
+	d.Summary = diffValue(d.Summary, "name", old.Name, new.Name)
+	d.Summary = diffValue(d.Summary, "classes", old.ClassString(), new.ClassString())
+	d.Summary = diffValue(d.Summary, "level", strconv.Itoa(old.Level), strconv.Itoa(new.Level))
+	d.Summary = diffValue(d.Summary, "hit points", old.HitPoints.String(), new.HitPoints.String())
+	d.Summary = diffValue(d.Summary, "hit dice", strconv.Itoa(old.HitDiceAvailable()), strconv.Itoa(new.HitDiceAvailable()))
+	d.Summary = diffValue(d.Summary, "state", string(old.State), string(new.State))
+	d.Summary = diffValue(d.Summary, "conditions", old.Conditions.String(), new.Conditions.String())
+	d.Summary = diffValue(d.Summary, "background", old.Background, new.Background)
+	d.Summary = diffValue(d.Summary, "improvements", strconv.Itoa(old.Improvements), strconv.Itoa(new.Improvements))
+
+	for attr := Str; attr <= Cha; attr++ {
+		name := GetAttributeShortName(attr)
+		d.Attributes = diffValue(d.Attributes, name, strconv.Itoa(old.Attributes.Get(attr)), strconv.Itoa(new.Attributes.Get(attr)))
+		oldScore, newScore := old.EffectiveAttribute(attr), new.EffectiveAttribute(attr)
+		if oldScore != old.Attributes.Get(attr) || newScore != new.Attributes.Get(attr) {
+			d.Attributes = diffValue(d.Attributes, name+" effective", strconv.Itoa(oldScore), strconv.Itoa(newScore))
+		}
+	}
+
+	d.Modifiers = diffList("modifiers", stringsOf(old.Modifiers), stringsOf(new.Modifiers))
+
+	for _, ld := range []ListDiff{
+		diffList("skills", skillNames(old.Skills), skillNames(new.Skills)),
+		diffList("tools", old.Tools, new.Tools),
+		diffList("languages", old.Languages, new.Languages),
+		diffList("armor and weapons", old.Proficiencies, new.Proficiencies),
+		diffList("saving throws", stringsOf(old.Saves), stringsOf(new.Saves)),
+		diffList("feats", old.Feats, new.Feats),
+	} {
+		if !ld.IsEmpty() {
+			d.Proficiencies = append(d.Proficiencies, ld)
+		}
+	}
+
+	d.Equipment = diffEquipment(old, new)
+
+	var oldCasting, newCasting Spellcasting
+	if old.Spellcasting != nil {
+		oldCasting = *old.Spellcasting
+	}
+	if new.Spellcasting != nil {
+		newCasting = *new.Spellcasting
+	}
+	for _, ld := range []ListDiff{
+		diffList("known", oldCasting.Known, newCasting.Known),
+		diffList("prepared", oldCasting.Prepared, newCasting.Prepared),
+	} {
+		if !ld.IsEmpty() {
+			d.Spells = append(d.Spells, ld)
+		}
+	}
+	d.SpellSlots = diffValue(d.SpellSlots, "slots", oldCasting.Slots.String(), newCasting.Slots.String())
+	var oldPact, newPact string
+	if oldCasting.PactSlots != nil {
+		oldPact = oldCasting.PactSlots.String()
+	}
+	if newCasting.PactSlots != nil {
+		newPact = newCasting.PactSlots.String()
+	}
+	d.SpellSlots = diffValue(d.SpellSlots, "pact slots", oldPact, newPact)
+	return d
+}
+
+// diffEquipment reports the item quantities, equipped slots, attunements
+// and wallet that differ between the characters. Items are listed in the
+// order they appear in the old inventory, followed by the new items.
+func diffEquipment(old *Character, new *Character) []ValueDiff {
+	var diffs []ValueDiff
+	var items []string
+	for _, inv := range []Inventory{old.Inventory, new.Inventory} {
+		for _, entry := range inv.Items {
+			if !slices.Contains(items, entry.ItemID) {
+				items = append(items, entry.ItemID)
+			}
+		}
+	}
+	quantity := func(inv Inventory, id string) string {
+		if q := inv.Quantity(id); q > 0 {
+			return strconv.Itoa(q)
+		}
+		return ""
+	}
+	for _, id := range items {
+		diffs = diffValue(diffs, id, quantity(old.Inventory, id), quantity(new.Inventory, id))
+	}
+	var slots []EquipSlot
+	for _, inv := range []Inventory{old.Inventory, new.Inventory} {
+		for slot := range inv.Equipped {
+			if !slices.Contains(slots, slot) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	slices.Sort(slots)
+	for _, slot := range slots {
+		diffs = diffValue(diffs, "equipped "+string(slot), old.Inventory.EquippedIn(slot), new.Inventory.EquippedIn(slot))
+	}
+	diffs = diffValue(diffs, "attuned", strings.Join(old.Inventory.Attuned, ", "), strings.Join(new.Inventory.Attuned, ", "))
+	diffs = diffValue(diffs, "wallet", old.Wallet.String(), new.Wallet.String())
+	return diffs
+}
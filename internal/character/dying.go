@@ -0,0 +1,185 @@
+package character
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// LifeState represents whether a character is up, dying, stable or dead.
+type LifeState string
+
+// Enumeration of life states.
+// A character drops to Dying at zero hit points, becomes Stable after three
+// successful death saves or a Medicine check, and is Dead after three failed
+// death saves or massive damage.
+const (
+	Conscious LifeState = "conscious"
+	Dying     LifeState = "dying"
+	Stable    LifeState = "stable"
+	Dead      LifeState = "dead"
+)
+
+// deathSavesNeeded is the number of successes or failures that ends dying.
+const deathSavesNeeded = 3
+
+// stabilizeDC is the DC of the Medicine check to stabilize a dying character.
+const stabilizeDC = 10
+
+// dyingSource is the source of the unconscious condition of a character at
+// zero hit points.
+const dyingSource = "0 hit points"
+
+// DeathSaves holds the death saving throws rolled since the character
+// dropped to zero hit points.
+type DeathSaves struct {
+	Successes int `json:"successes"`
+	Failures  int `json:"failures"`
+}
+
+// String returns a string representation of the DeathSaves.
+func (ds DeathSaves) String() string {
+	return fmt.Sprintf("%d successes, %d failures", ds.Successes, ds.Failures)
+}
+
+// DeathSaveResult represents the outcome of a death saving throw.
+// Roll is the natural d20 roll and State the life state after the save.
+type DeathSaveResult struct {
+	Roll       int
+	Success    bool
+	DeathSaves DeathSaves
+	State      LifeState
+}
+
+// String returns a string representation of the DeathSaveResult.
+func (r DeathSaveResult) String() string {
+	switch {
+	case r.Roll == 20:
+		return "death save: natural 20, regains 1 hit point"
+	case r.State == Dead:
+		return fmt.Sprintf("death save: %d, dies (%s)", r.Roll, r.DeathSaves)
+	case r.State == Stable:
+		return fmt.Sprintf("death save: %d, stabilizes", r.Roll)
+	case r.Success:
+		return fmt.Sprintf("death save: %d, success (%s)", r.Roll, r.DeathSaves)
+	}
+	return fmt.Sprintf("death save: %d, failure (%s)", r.Roll, r.DeathSaves)
+}
+
+// IsDead returns true if the character died, either from death saves,
+// massive damage or the highest exhaustion level.
+func (c *Character) IsDead() bool {
+	return c.State == Dead || c.Conditions.ExhaustionLevel() >= MaxExhaustion
+}
+
+// IsDying returns true if the character is at zero hit points and must
+// roll death saves.
+func (c *Character) IsDying() bool {
+	return c.State == Dying
+}
+
+// dropToZero makes the character fall unconscious at zero hit points,
+// or die when the remaining damage reaches its maximum hit points.
+func (c *Character) dropToZero(remaining int) {
+	if remaining >= c.HitPoints.Max {
+		c.die()
+		return
+	}
+	c.State = Dying
+	c.DeathSaves = DeathSaves{}
+	// Add only fails for unknown conditions or save attributes, and
+	// Unconscious is known and has no save.
+	_ = c.Conditions.Add(ActiveCondition{Condition: Unconscious, Source: dyingSource})
+}
+
+// damageWhileDown applies damage to a character already at zero hit points.
+// Damage equal to its maximum hit points kills it, and any other damage is
+// a failed death save, or two for a critical hit. A stable character starts
+// dying again.
+func (c *Character) damageWhileDown(amount int, critical bool) {
+	if amount >= c.HitPoints.Max {
+		c.die()
+		return
+	}
+	c.State = Dying
+	failures := 1
+	if critical {
+		failures = 2
+	}
+	c.failDeathSaves(failures)
+}
+
+// failDeathSaves adds failed death saves, killing the character on the third.
+func (c *Character) failDeathSaves(count int) {
+	c.DeathSaves.Failures = min(c.DeathSaves.Failures+count, deathSavesNeeded)
+	if c.DeathSaves.Failures >= deathSavesNeeded {
+		c.die()
+	}
+}
+
+// die marks the character as dead at zero hit points.
+func (c *Character) die() {
+	c.HitPoints.Current = 0
+	c.State = Dead
+	c.Conditions.RemoveFrom(Unconscious, dyingSource)
+}
+
+// revive brings a character at zero hit points back to consciousness.
+func (c *Character) revive() {
+	c.State = Conscious
+	c.DeathSaves = DeathSaves{}
+	c.Conditions.RemoveFrom(Unconscious, dyingSource)
+}
+
+// RollDeathSave rolls a death saving throw for a dying character.
+// A roll of 10 or higher is a success and lower a failure; a natural 1
+// counts as two failures and a natural 20 brings the character back with
+// one hit point. Three successes stabilize the character and three failures
+// kill it.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+// It returns an error if the character is not dying.
+func (c *Character) RollDeathSave(r dice.Roller) (DeathSaveResult, error) {
+	if c.State != Dying {
+		return DeathSaveResult{}, fmt.Errorf("%s is not dying", c.Name)
+	}
+	if r == nil {
+		r = dice.DefaultRoller
+	}
+	roll := r.RollDie(20)
+	result := DeathSaveResult{Roll: roll, Success: roll >= 10}
+	switch {
+	case roll == 20:
+		c.HitPoints.Current = 1
+		c.revive()
+	case roll == 1:
+		c.failDeathSaves(2)
+	case result.Success:
+		c.DeathSaves.Successes++
+		if c.DeathSaves.Successes >= deathSavesNeeded {
+			c.State = Stable
+			c.DeathSaves = DeathSaves{}
+		}
+	default:
+		c.failDeathSaves(1)
+	}
+	result.DeathSaves = c.DeathSaves
+	result.State = c.State
+	return result, nil
+}
+
+// Stabilize has the healer make a Wisdom (Medicine) check against DC 10 to
+// stabilize the dying character. A stable character stays unconscious at
+// zero hit points but no longer rolls death saves.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+// It returns the result of the check, or an error if the character is not dying.
+func (c *Character) Stabilize(healer *Character, r dice.Roller) (RollResult, error) {
+	if c.State != Dying {
+		return RollResult{}, fmt.Errorf("%s is not dying", c.Name)
+	}
+	result := healer.RollCheck(SkillCheck(Medicine), stabilizeDC, r)
+	if result.Success {
+		c.State = Stable
+		c.DeathSaves = DeathSaves{}
+	}
+	return result, nil
+}
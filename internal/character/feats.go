@@ -0,0 +1,271 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/dataformat"
+)
+
+// FeatPrerequisites lists what a character needs to take a feat.
+// It includes JSON struct tags for serialization.
+// Every score in Attributes must be met, while AnyAttributes is met when
+// at least one of its scores is. Scores are checked against the base
+// attributes. Skills, Tools and Proficiencies, for armor and weapons, are
+// required proficiencies, Level is the minimum character level and
+// Spellcasting requires the ability to cast at least one spell.
+type FeatPrerequisites struct {
+	Attributes    AttributesMap `json:"attributes,omitempty"`
+	AnyAttributes AttributesMap `json:"any_attributes,omitempty"`
+	Skills        []Skill       `json:"skills,omitempty"`
+	Tools         []string      `json:"tools,omitempty"`
+	Proficiencies []string      `json:"proficiencies,omitempty"`
+	Level         int           `json:"level,omitempty"`
+	Spellcasting  bool          `json:"spellcasting,omitempty"`
+}
+
+// FeatEffects lists what a character gains by taking a feat.
+// It includes JSON struct tags for serialization.
+// Increases raises base scores, and IncreaseChoice lets the character raise
+// one of the listed attributes by one, both up to MaxAbilityScore. With
+// SaveChoice the character also becomes proficient in saving throws of the
+// chosen attribute. Skills, Tools and Languages are new proficiencies, and
+// Modifiers are added with the feat name as their source.
+type FeatEffects struct {
+	Increases      AttributesMap `json:"increases,omitempty"`
+	IncreaseChoice []Attribute   `json:"increase_choice,omitempty"`
+	SaveChoice     bool          `json:"save_choice,omitempty"`
+	Skills         []Skill       `json:"skills,omitempty"`
+	Tools          []string      `json:"tools,omitempty"`
+	Languages      []string      `json:"languages,omitempty"`
+	Modifiers      []Modifier    `json:"modifiers,omitempty"`
+}
+
+// Feat represents a feat definition.
+// It includes JSON struct tags for serialization.
+type Feat struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	Prerequisites FeatPrerequisites `json:"prerequisites"`
+	Effects       FeatEffects       `json:"effects"`
+}
+
+// Unmet returns a description of every prerequisite of the feat the
+// character does not meet, or nil if the character can take it.
+func (f *Feat) Unmet(c *Character) []string {
+	p := f.Prerequisites
+	unmet := unmetScores(c.Attributes, p.Attributes, p.AnyAttributes)
+	for _, skill := range p.Skills {
+		if !c.IsProficient(skill) {
+			unmet = append(unmet, fmt.Sprintf("requires proficiency in %s", strings.ReplaceAll(string(skill), "_", " ")))
+		}
+	}
+	for _, tool := range p.Tools {
+		if !slices.Contains(c.Tools, tool) {
+			unmet = append(unmet, fmt.Sprintf("requires proficiency with %s", tool))
+		}
+	}
+	for _, proficiency := range p.Proficiencies {
+		if !slices.Contains(c.Proficiencies, proficiency) {
+			unmet = append(unmet, fmt.Sprintf("requires proficiency with %s", proficiency))
+		}
+	}
+	if c.Level < p.Level {
+		unmet = append(unmet, fmt.Sprintf("requires level %d (is %d)", p.Level, c.Level))
+	}
+	if p.Spellcasting && c.Spellcasting == nil {
+		unmet = append(unmet, "requires the ability to cast at least one spell")
+	}
+	return unmet
+}
+
+// sortedAttributes returns the attributes of the map in attribute order.
+func sortedAttributes(am AttributesMap) []Attribute {
+	attrs := make([]Attribute, 0, len(am))
+	for attr := range am {
+		attrs = append(attrs, attr)
+	}
+	slices.Sort(attrs)
+	return attrs
+}
+
+// FeatData represents the structure of the feats JSON file.
+type FeatData struct {
+	Feats []Feat `json:"feats"`
+	index map[string]int
+}
+
+// LoadFeatData reads the feat data from a JSON, YAML or TOML file, chosen by
+// its extension, unmarshals it into a FeatData struct and validates it.
+// It returns the FeatData and any error encountered during the process.
+func LoadFeatData(filename string) (*FeatData, error) {
+	file, err := dataformat.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFeatData(file)
+}
+
+// ParseFeatData unmarshals the feat data from JSON content into
+// a FeatData struct and validates it.
+// It returns the FeatData and any error encountered during the process.
+func ParseFeatData(content []byte) (*FeatData, error) {
+	var data FeatData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Validate checks that feat IDs are unique, that every skill is known, that
+// increases and modifiers are valid and that saving throw choices come with
+// attributes to choose from.
+func (d *FeatData) Validate() error {
+	d.index = make(map[string]int, len(d.Feats))
+	for i, f := range d.Feats {
+		if f.ID == "" {
+			return fmt.Errorf("feat %q has no id", f.Name)
+		}
+		key := strings.ToLower(f.ID)
+		if _, ok := d.index[key]; ok {
+			return fmt.Errorf("duplicate feat id %q", f.ID)
+		}
+		d.index[key] = i
+		for _, skill := range append(slices.Clone(f.Prerequisites.Skills), f.Effects.Skills...) {
+			if _, err := ParseSkill(string(skill)); err != nil {
+				return fmt.Errorf("feat %q: %w", f.ID, err)
+			}
+		}
+		for attr, value := range f.Effects.Increases {
+			if value <= 0 {
+				return fmt.Errorf("feat %q: invalid %s increase %d", f.ID, attr, value)
+			}
+		}
+		if f.Effects.SaveChoice && len(f.Effects.IncreaseChoice) == 0 {
+			return fmt.Errorf("feat %q: saving throw choice without attributes to choose", f.ID)
+		}
+		for _, m := range f.Effects.Modifiers {
+			if m.Layer == LayerBase {
+				return fmt.Errorf("feat %q: modifiers cannot use the base layer", f.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns the feat with the given ID, ignoring case.
+// It returns the Feat and a boolean indicating whether it was found.
+func (d *FeatData) Get(id string) (*Feat, bool) {
+	if d.index == nil {
+		d.index = make(map[string]int, len(d.Feats))
+		for i, f := range d.Feats {
+			d.index[strings.ToLower(f.ID)] = i
+		}
+	}
+	i, ok := d.index[strings.ToLower(strings.TrimSpace(id))]
+	if !ok {
+		return nil, false
+	}
+	return &d.Feats[i], true
+}
+
+// IDs returns the sorted list of feat IDs.
+func (d *FeatData) IDs() []string {
+	ids := make([]string, len(d.Feats))
+	for i, f := range d.Feats {
+		ids[i] = f.ID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// HasFeat returns true if the character has taken the feat with the given ID.
+func (c *Character) HasFeat(id string) bool {
+	return slices.ContainsFunc(c.Feats, func(feat string) bool {
+		return strings.EqualFold(feat, id)
+	})
+}
+
+// CanTakeFeat checks whether the character can take the feat.
+// It returns an error explaining every unmet prerequisite, or nil.
+func (c *Character) CanTakeFeat(f *Feat) error {
+	if c.HasFeat(f.ID) {
+		return fmt.Errorf("%s already has feat %q", c.Name, f.Name)
+	}
+	if unmet := f.Unmet(c); len(unmet) > 0 {
+		return fmt.Errorf("feat %q unavailable: %s", f.Name, strings.Join(unmet, "; "))
+	}
+	return nil
+}
+
+// AddFeat gives the character a feat outside of an ability score
+// improvement, such as one chosen at creation.
+// The increase attribute is used by feats letting the character choose the
+// attribute to raise, and ignored by any other feat.
+// It returns an error if the character cannot take the feat, the chosen
+// attribute is not one of the feat choices or a score would go above
+// MaxAbilityScore. The character is not modified on error.
+func (c *Character) AddFeat(f *Feat, increase Attribute) error {
+	if err := c.CanTakeFeat(f); err != nil {
+		return err
+	}
+	increases := map[Attribute]int{}
+	for attr, value := range f.Effects.Increases {
+		increases[attr] += value
+	}
+	if len(f.Effects.IncreaseChoice) > 0 {
+		if !slices.Contains(f.Effects.IncreaseChoice, increase) {
+			return fmt.Errorf("feat %q cannot increase %s", f.Name, increase)
+		}
+		increases[increase]++
+	}
+	if err := c.increaseAbilities(increases); err != nil {
+		return fmt.Errorf("feat %q: %w", f.Name, err)
+	}
+	if f.Effects.SaveChoice && !slices.Contains(c.Saves, increase) {
+		c.Saves = append(c.Saves, increase)
+	}
+	for _, skill := range f.Effects.Skills {
+		if !c.IsProficient(skill) {
+			c.Skills = append(c.Skills, skill)
+		}
+	}
+	for _, tool := range f.Effects.Tools {
+		if !slices.Contains(c.Tools, tool) {
+			c.Tools = append(c.Tools, tool)
+		}
+	}
+	for _, language := range f.Effects.Languages {
+		if !slices.Contains(c.Languages, language) {
+			c.Languages = append(c.Languages, language)
+		}
+	}
+	for _, m := range f.Effects.Modifiers {
+		m.Source = f.Name
+		c.Modifiers.Add(m)
+	}
+	c.Feats = append(c.Feats, f.ID)
+	return nil
+}
+
+// TakeFeat spends an ability score improvement to give the character a
+// feat, like AddFeat.
+// It returns an error if no improvement is available or the feat cannot be
+// taken.
+func (c *Character) TakeFeat(f *Feat, increase Attribute) error {
+	if c.Improvements == 0 {
+		return fmt.Errorf("%s has no ability score improvement available", c.Name)
+	}
+	if err := c.AddFeat(f, increase); err != nil {
+		return err
+	}
+	c.Improvements--
+	return nil
+}
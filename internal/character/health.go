@@ -0,0 +1,175 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jrecuero/DandD/internal/damage"
+)
+
+// defaultHitDie is the hit die used for classes without a known hit die.
+const defaultHitDie = 8
+
+// hitDice maps each class to the number of sides of its hit die.
+var hitDice = map[string]int{
+	"barbarian": 12,
+	"fighter":   10,
+	"paladin":   10,
+	"ranger":    10,
+	"bard":      8,
+	"cleric":    8,
+	"druid":     8,
+	"monk":      8,
+	"rogue":     8,
+	"warlock":   8,
+	"sorcerer":  6,
+	"wizard":    6,
+}
+
+// GetHitDie returns the number of sides of the hit die for the given class.
+// Classes without a known hit die use a d8.
+func GetHitDie(job string) int {
+	if die, ok := hitDice[normalizeJob(job)]; ok {
+		return die
+	}
+	return defaultHitDie
+}
+
+// HitPoints represents the hit points of a creature.
+// It includes JSON struct tags for serialization.
+// Temp holds temporary hit points, which are lost before Current.
+type HitPoints struct {
+	Current int `json:"current"`
+	Max     int `json:"max"`
+	Temp    int `json:"temp,omitempty"`
+}
+
+// String returns a string representation of the HitPoints.
+func (hp HitPoints) String() string {
+	if hp.Temp > 0 {
+		return fmt.Sprintf("%d/%d (+%d temp)", hp.Current, hp.Max, hp.Temp)
+	}
+	return fmt.Sprintf("%d/%d", hp.Current, hp.Max)
+}
+
+// MaxHitPoints calculates the maximum hit points for a class, level and
+// constitution score. The first level gets the full hit die and every other
+// level the fixed average, each adding the constitution modifier with a
+// minimum of one hit point per level.
+func MaxHitPoints(job string, level int, constitution int) int {
+	return ClassMaxHitPoints([]ClassLevel{{Job: job, Level: level}}, constitution)
+}
+
+// ClassMaxHitPoints calculates the maximum hit points for the levels of
+// one or more classes and a constitution score. The first level of the
+// first class gets the full hit die, and every other level the fixed
+// average of the hit die of its class, each adding the constitution
+// modifier with a minimum of one hit point per level.
+func ClassMaxHitPoints(classes []ClassLevel, constitution int) int {
+	mod := AbilityModifier(constitution)
+	total := 0
+	for i, cl := range classes {
+		die := GetHitDie(cl.Job)
+		for level := 1; level <= cl.Level; level++ {
+			if i == 0 && level == 1 {
+				total += max(1, die+mod)
+				continue
+			}
+			total += max(1, die/2+1+mod)
+		}
+	}
+	return total
+}
+
+// HitDicePool returns the size of every hit die of the character, one per
+// level of each class, from the largest to the smallest.
+func (c *Character) HitDicePool() []int {
+	var pool []int
+	for _, cl := range c.ClassLevels() {
+		for range cl.Level {
+			pool = append(pool, GetHitDie(cl.Job))
+		}
+	}
+	slices.SortFunc(pool, func(a, b int) int { return b - a })
+	return pool
+}
+
+// InitHitPoints sets the maximum hit points for the character classes,
+// levels and effective constitution, and fully heals the character.
+func (c *Character) InitHitPoints() {
+	c.HitPoints.Max = ClassMaxHitPoints(c.ClassLevels(), c.EffectiveAttribute(Con))
+	c.HitPoints.Current = c.HitPoints.Max
+}
+
+// TakeDamage reduces the hit points of the character, removing temporary
+// hit points first. Hit points never drop below zero.
+// It returns the amount of damage taken by the current hit points.
+func (c *Character) TakeDamage(amount int) int {
+	return c.TakeHit(amount, false)
+}
+
+// TakeHit reduces the hit points of the character like TakeDamage and
+// applies the dying rules. A character dropping to zero hit points falls
+// unconscious and starts dying, unless the remaining damage reaches its
+// maximum hit points, which kills it outright. Damage taken at zero hit
+// points counts as a failed death save, or two for a critical hit.
+// It returns the amount of damage taken by the current hit points.
+func (c *Character) TakeHit(amount int, critical bool) int {
+	if amount <= 0 || c.State == Dead {
+		return 0
+	}
+	c.HitPoints.Temp, amount = damage.AbsorbTemp(amount, c.HitPoints.Temp)
+	if amount == 0 {
+		return 0
+	}
+	if c.HitPoints.Current == 0 {
+		c.damageWhileDown(amount, critical)
+		return 0
+	}
+	taken := min(amount, c.HitPoints.Current)
+	c.HitPoints.Current -= taken
+	if c.HitPoints.Current == 0 {
+		c.dropToZero(amount - taken)
+	}
+	return taken
+}
+
+// TakeTypedDamage applies damage with one or more typed components to the
+// character. The character defenses are applied to each component before
+// the total is taken like TakeHit, temporary hit points first.
+// It returns the Result with the damage after defenses and how much of it
+// was absorbed by temporary hit points and taken from hit points.
+func (c *Character) TakeTypedDamage(dmg damage.Damage, critical bool) damage.Result {
+	result := c.Defenses.Apply(dmg)
+	temp := c.HitPoints.Temp
+	result.Taken = c.TakeHit(result.Total, critical)
+	result.TempAbsorbed = temp - c.HitPoints.Temp
+	return result
+}
+
+// Heal restores hit points to the character, up to the maximum.
+// A character at zero hit points regains consciousness, while a dead
+// character cannot be healed.
+// It returns the number of hit points restored.
+func (c *Character) Heal(amount int) int {
+	if amount <= 0 || c.State == Dead {
+		return 0
+	}
+	healed := min(amount, c.HitPoints.Max-c.HitPoints.Current)
+	c.HitPoints.Current += healed
+	if healed > 0 && (c.State == Dying || c.State == Stable) {
+		c.revive()
+	}
+	return healed
+}
+
+// AddTempHitPoints grants temporary hit points to the character.
+// Temporary hit points do not stack: the character keeps the higher value.
+func (c *Character) AddTempHitPoints(amount int) {
+	c.HitPoints.Temp = max(c.HitPoints.Temp, amount)
+}
+
+// IsDown returns true if the character has no hit points left.
+func (c *Character) IsDown() bool {
+	return c.HitPoints.Current <= 0
+}
@@ -0,0 +1,278 @@
+package character
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MaxAttunement is the maximum number of items a character can be attuned to.
+const MaxAttunement = 3
+
+// InventoryEntry represents a stack of items carried by a character.
+// It includes JSON struct tags for serialization.
+type InventoryEntry struct {
+	ItemID   string `json:"item"`
+	Quantity int    `json:"quantity"`
+}
+
+// Inventory represents the items carried, equipped and attuned by a character.
+// Equipped maps each slot to the ID of the item in it, and Attuned lists the
+// IDs of attuned items. Items are referenced by ID and resolved against an
+// ItemData catalog when their definition is needed.
+type Inventory struct {
+	Items    []InventoryEntry     `json:"items"`
+	Equipped map[EquipSlot]string `json:"equipped,omitempty"`
+	Attuned  []string             `json:"attuned,omitempty"`
+}
+
+// normalizeJob returns the key used to look up class based data.
+func normalizeJob(job string) string {
+	return strings.ToLower(strings.TrimSpace(job))
+}
+
+// Quantity returns the number of items with the given ID in the inventory.
+func (inv *Inventory) Quantity(itemID string) int {
+	for _, entry := range inv.Items {
+		if entry.ItemID == itemID {
+			return entry.Quantity
+		}
+	}
+	return 0
+}
+
+// Add puts the given quantity of an item in the inventory.
+// Items with the same ID are stacked together.
+func (inv *Inventory) Add(itemID string, quantity int) {
+	if quantity <= 0 {
+		return
+	}
+	for i, entry := range inv.Items {
+		if entry.ItemID == itemID {
+			inv.Items[i].Quantity += quantity
+			return
+		}
+	}
+	inv.Items = append(inv.Items, InventoryEntry{ItemID: itemID, Quantity: quantity})
+}
+
+// Remove takes the given quantity of an item from the inventory.
+// Slots holding the item are freed until no more units are equipped than
+// remain carried, off hand first, and the item is unattuned when the last
+// unit is removed.
+// It returns an error if the quantity is not positive or there are not
+// enough items.
+func (inv *Inventory) Remove(itemID string, quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("cannot remove %d %q: quantity must be positive", quantity, itemID)
+	}
+	for i, entry := range inv.Items {
+		if entry.ItemID != itemID {
+			continue
+		}
+		if entry.Quantity < quantity {
+			return fmt.Errorf("cannot remove %d %q: only %d carried", quantity, itemID, entry.Quantity)
+		}
+		remaining := entry.Quantity - quantity
+		inv.Items[i].Quantity = remaining
+		equipped := 0
+		for _, id := range inv.Equipped {
+			if id == itemID {
+				equipped++
+			}
+		}
+		for _, slot := range slices.Backward(slices.Sorted(maps.Keys(inv.Equipped))) {
+			if equipped <= remaining {
+				break
+			}
+			if inv.Equipped[slot] == itemID {
+				delete(inv.Equipped, slot)
+				equipped--
+			}
+		}
+		if remaining == 0 {
+			inv.Items = append(inv.Items[:i], inv.Items[i+1:]...)
+			inv.Unattune(itemID)
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot remove %q: not carried", itemID)
+}
+
+// EquippedIn returns the ID of the item equipped in the given slot.
+// It returns an empty string if the slot is free.
+func (inv *Inventory) EquippedIn(slot EquipSlot) string {
+	return inv.Equipped[slot]
+}
+
+// IsEquipped returns true if the item with the given ID is equipped in any slot.
+func (inv *Inventory) IsEquipped(itemID string) bool {
+	for _, equipped := range inv.Equipped {
+		if equipped == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// Equip puts a carried item in the given slot.
+// If slot is empty, the item default slot is used. One-handed weapons can be
+// equipped in either hand, while two-handed weapons are kept in the main hand
+// and also block the off hand.
+// It returns an error if the item is unknown or not carried, cannot go in the
+// slot, or the slot is already taken.
+func (inv *Inventory) Equip(data *ItemData, itemID string, slot EquipSlot) error {
+	item, ok := data.GetItem(itemID)
+	if !ok {
+		return fmt.Errorf("unknown item %q", itemID)
+	}
+	if !item.IsEquippable() {
+		return fmt.Errorf("item %q cannot be equipped", itemID)
+	}
+	if slot == "" {
+		slot = item.Slot
+	}
+	if slot != item.Slot && !(item.Type == ItemWeapon && !item.TwoHanded && slot == SlotOffHand) {
+		return fmt.Errorf("item %q cannot be equipped in %s", itemID, slot)
+	}
+	equipped := 0
+	for _, id := range inv.Equipped {
+		if id == itemID {
+			equipped++
+		}
+	}
+	if inv.Quantity(itemID) <= equipped {
+		return fmt.Errorf("item %q is not carried", itemID)
+	}
+	if current := inv.Equipped[slot]; current != "" {
+		return fmt.Errorf("slot %s is already taken by %q", slot, current)
+	}
+	if item.TwoHanded && inv.Equipped[SlotOffHand] != "" {
+		return fmt.Errorf("item %q needs both hands free", itemID)
+	}
+	if slot == SlotOffHand {
+		if main, ok := data.GetItem(inv.Equipped[SlotMainHand]); ok && main.TwoHanded {
+			return fmt.Errorf("slot %s is blocked by two-handed %q", slot, main.ID)
+		}
+	}
+	if inv.Equipped == nil {
+		inv.Equipped = map[EquipSlot]string{}
+	}
+	inv.Equipped[slot] = itemID
+	return nil
+}
+
+// Unequip frees the given slot.
+// It returns the ID of the item removed from the slot, or an empty string if
+// the slot was free.
+func (inv *Inventory) Unequip(slot EquipSlot) string {
+	itemID := inv.Equipped[slot]
+	delete(inv.Equipped, slot)
+	return itemID
+}
+
+// IsAttuned returns true if the character is attuned to the item with the given ID.
+func (inv *Inventory) IsAttuned(itemID string) bool {
+	for _, attuned := range inv.Attuned {
+		if attuned == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// Attune attunes the character to a carried item.
+// It returns an error if the item is unknown, does not require attunement,
+// is not carried, is already attuned, or the MaxAttunement limit is reached.
+func (inv *Inventory) Attune(data *ItemData, itemID string) error {
+	item, ok := data.GetItem(itemID)
+	if !ok {
+		return fmt.Errorf("unknown item %q", itemID)
+	}
+	if !item.RequiresAttunement {
+		return fmt.Errorf("item %q does not require attunement", itemID)
+	}
+	if inv.Quantity(itemID) == 0 {
+		return fmt.Errorf("item %q is not carried", itemID)
+	}
+	if inv.IsAttuned(itemID) {
+		return fmt.Errorf("already attuned to %q", itemID)
+	}
+	if len(inv.Attuned) >= MaxAttunement {
+		return fmt.Errorf("cannot attune to %q: limit of %d items reached", itemID, MaxAttunement)
+	}
+	inv.Attuned = append(inv.Attuned, itemID)
+	return nil
+}
+
+// Unattune ends the attunement to the item with the given ID.
+// It returns true if the character was attuned to the item.
+func (inv *Inventory) Unattune(itemID string) bool {
+	for i, attuned := range inv.Attuned {
+		if attuned == itemID {
+			inv.Attuned = append(inv.Attuned[:i], inv.Attuned[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Weight returns the total weight in pounds of the carried items.
+// Items not found in the catalog are ignored.
+func (inv *Inventory) Weight(data *ItemData) float64 {
+	total := 0.0
+	for _, entry := range inv.Items {
+		if item, ok := data.GetItem(entry.ItemID); ok {
+			total += item.Weight * float64(entry.Quantity)
+		}
+	}
+	return total
+}
+
+// EncumbranceLevel represents how burdened a character is by the carried weight.
+type EncumbranceLevel int
+
+// Enumeration of encumbrance levels.
+// They follow the variant encumbrance rules: more than 5 times STR is
+// encumbered, more than 10 times STR is heavily encumbered and more than
+// 15 times STR is over the carrying capacity.
+const (
+	Unencumbered EncumbranceLevel = iota
+	Encumbered
+	HeavilyEncumbered
+	OverCapacity
+)
+
+// encumbranceNames maps each EncumbranceLevel to its name.
+var encumbranceNames = map[EncumbranceLevel]string{
+	Unencumbered:      "unencumbered",
+	Encumbered:        "encumbered",
+	HeavilyEncumbered: "heavily encumbered",
+	OverCapacity:      "over capacity",
+}
+
+// String returns the name of the encumbrance level.
+func (e EncumbranceLevel) String() string {
+	return encumbranceNames[e]
+}
+
+// CarryingCapacity returns the maximum weight in pounds a creature with the
+// given strength score can carry.
+func CarryingCapacity(strength int) float64 {
+	return float64(strength * 15)
+}
+
+// GetEncumbrance returns the encumbrance level for the given carried weight
+// and strength score.
+func GetEncumbrance(weight float64, strength int) EncumbranceLevel {
+	switch str := float64(strength); {
+	case weight > str*15:
+		return OverCapacity
+	case weight > str*10:
+		return HeavilyEncumbered
+	case weight > str*5:
+		return Encumbered
+	}
+	return Unencumbered
+}
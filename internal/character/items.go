@@ -0,0 +1,176 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/jrecuero/DandD/internal/damage"
+	"github.com/jrecuero/DandD/internal/dataformat"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// ItemType represents the category of an item.
+type ItemType string
+
+// Enumeration of item types.
+const (
+	ItemWeapon     ItemType = "weapon"
+	ItemArmor      ItemType = "armor"
+	ItemShield     ItemType = "shield"
+	ItemGear       ItemType = "gear"
+	ItemConsumable ItemType = "consumable"
+)
+
+// EquipSlot represents a body slot where an item can be equipped.
+type EquipSlot string
+
+// Enumeration of equip slots.
+const (
+	SlotMainHand EquipSlot = "main_hand"
+	SlotOffHand  EquipSlot = "off_hand"
+	SlotBody     EquipSlot = "body"
+	SlotHead     EquipSlot = "head"
+	SlotHands    EquipSlot = "hands"
+	SlotFeet     EquipSlot = "feet"
+	SlotNeck     EquipSlot = "neck"
+	SlotCloak    EquipSlot = "cloak"
+	SlotRing     EquipSlot = "ring"
+)
+
+// Item represents an item definition.
+// It includes JSON struct tags for serialization.
+// Value is expressed in copper pieces and Weight in pounds.
+// Weapon and armor specific fields are empty for other item types.
+type Item struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	Type               ItemType  `json:"type"`
+	Weight             float64   `json:"weight"`
+	Value              int       `json:"value"`
+	Slot               EquipSlot `json:"slot,omitempty"`
+	RequiresAttunement bool      `json:"requires_attunement,omitempty"`
+	Damage             string    `json:"damage,omitempty"`
+	DamageType         string    `json:"damage_type,omitempty"`
+	TwoHanded          bool      `json:"two_handed,omitempty"`
+	Finesse            bool      `json:"finesse,omitempty"`
+	Ranged             bool      `json:"ranged,omitempty"`
+	ArmorClass         int       `json:"armor_class,omitempty"`
+	MaxDexBonus        *int      `json:"max_dex_bonus,omitempty"`
+}
+
+// IsEquippable returns true if the item can be equipped in a slot.
+func (i Item) IsEquippable() bool {
+	return i.Slot != ""
+}
+
+// ItemData represents the structure of the items JSON file.
+// It includes the item definitions, the starting equipment packages by class
+// and the starting gold by class, in gold pieces using dice notation.
+type ItemData struct {
+	Items             []Item                      `json:"items"`
+	StartingEquipment map[string][]InventoryEntry `json:"starting_equipment"`
+	StartingGold      map[string]string           `json:"starting_gold"`
+	catalog           map[string]Item
+}
+
+// LoadItemData reads the item data from a JSON, YAML or TOML file, chosen by
+// its extension, and unmarshals it into an ItemData struct.
+// It returns the ItemData and any error encountered during the process.
+func LoadItemData(filename string) (*ItemData, error) {
+	file, err := dataformat.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseItemData(file)
+}
+
+// ParseItemData unmarshals the item data from JSON content into
+// an ItemData struct and validates it.
+// It returns the ItemData and any error encountered during the process.
+func ParseItemData(content []byte) (*ItemData, error) {
+	var data ItemData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Validate checks that item IDs are unique, that weapon damage is valid
+// dice notation with a known damage type, that every starting
+// equipment package only references known items and that every starting
+// gold roll is valid dice notation.
+func (d *ItemData) Validate() error {
+	seen := map[string]bool{}
+	for _, item := range d.Items {
+		if item.ID == "" {
+			return fmt.Errorf("item %q has no id", item.Name)
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("duplicate item id %q", item.ID)
+		}
+		seen[item.ID] = true
+		if item.Damage != "" {
+			if _, err := dice.Parse(item.Damage); err != nil {
+				return fmt.Errorf("item %q: %w", item.ID, err)
+			}
+		}
+		if item.DamageType != "" {
+			if _, err := damage.ParseType(item.DamageType); err != nil {
+				return fmt.Errorf("item %q: %w", item.ID, err)
+			}
+		}
+	}
+	for job, entries := range d.StartingEquipment {
+		for _, entry := range entries {
+			if !seen[entry.ItemID] {
+				return fmt.Errorf("starting equipment for %q references unknown item %q", job, entry.ItemID)
+			}
+		}
+	}
+	for job, notation := range d.StartingGold {
+		if _, err := dice.Parse(notation); err != nil {
+			return fmt.Errorf("starting gold for %q: %w", job, err)
+		}
+	}
+	return nil
+}
+
+// GetItem returns the item definition with the given ID.
+// It returns the Item and a boolean indicating whether the item was found.
+func (d *ItemData) GetItem(id string) (Item, bool) {
+	if d.catalog == nil {
+		d.catalog = make(map[string]Item, len(d.Items))
+		for _, item := range d.Items {
+			d.catalog[item.ID] = item
+		}
+	}
+	item, ok := d.catalog[id]
+	return item, ok
+}
+
+// GetStartingEquipment returns the starting equipment package for the given class.
+// It returns nil if the class has no package.
+func (d *ItemData) GetStartingEquipment(job string) []InventoryEntry {
+	return d.StartingEquipment[normalizeJob(job)]
+}
+
+// GetStartingGold returns the starting gold dice notation for the given class.
+// It returns the notation and a boolean indicating whether the class was found.
+func (d *ItemData) GetStartingGold(job string) (string, bool) {
+	notation, ok := d.StartingGold[normalizeJob(job)]
+	return notation, ok
+}
+
+// Jobs returns the sorted list of classes with a starting equipment package.
+func (d *ItemData) Jobs() []string {
+	jobs := make([]string, 0, len(d.StartingEquipment))
+	for job := range d.StartingEquipment {
+		jobs = append(jobs, job)
+	}
+	sort.Strings(jobs)
+	return jobs
+}
@@ -0,0 +1,369 @@
+package character
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ChangeAction represents the kind of entry in a character journal.
+type ChangeAction string
+
+// Enumeration of change actions.
+// Edit is a change made to the character, while Undo and Redo revert and
+// reapply an earlier Edit.
+const (
+	Edit ChangeAction = "edit"
+	Undo ChangeAction = "undo"
+	Redo ChangeAction = "redo"
+)
+
+// FieldChange represents the change of a single value of a character.
+// It includes JSON struct tags for serialization.
+// Path locates the value in the JSON form of the character, as in
+// "attributes.STR" or "hit_points.current". Old and New hold the JSON
+// values before and after the change, and are empty when the value was
+// added or removed.
+type FieldChange struct {
+	Path string          `json:"path"`
+	Old  json.RawMessage `json:"old,omitempty"`
+	New  json.RawMessage `json:"new,omitempty"`
+}
+
+// String returns the change as "path old -> new".
+func (fc FieldChange) String() string {
+	value := func(raw json.RawMessage) string {
+		if len(raw) == 0 {
+			return "none"
+		}
+		var b bytes.Buffer
+		if err := json.Compact(&b, raw); err != nil {
+			return string(raw)
+		}
+		return b.String()
+	}
+	return fmt.Sprintf("%s %s -> %s", fc.Path, value(fc.Old), value(fc.New))
+}
+
+// Change represents an entry of a character journal: who changed what,
+// why and when, with the old and new values.
+// It includes JSON struct tags for serialization.
+// Seq numbers entries from one. Target is the sequence number of the Edit
+// reverted or reapplied by Undo and Redo entries.
+type Change struct {
+	Seq    int           `json:"seq"`
+	Time   time.Time     `json:"time"`
+	Author string        `json:"author,omitempty"`
+	Reason string        `json:"reason,omitempty"`
+	Action ChangeAction  `json:"action"`
+	Target int           `json:"target,omitempty"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// String returns a string representation of the Change.
+func (ch Change) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%d %s", ch.Seq, ch.Time.Format(time.DateTime))
+	if ch.Author != "" {
+		fmt.Fprintf(&b, " %s", ch.Author)
+	}
+	if ch.Action != Edit {
+		fmt.Fprintf(&b, " %s #%d", ch.Action, ch.Target)
+	}
+	if ch.Reason != "" {
+		fmt.Fprintf(&b, ": %s", ch.Reason)
+	}
+	fields := make([]string, len(ch.Fields))
+	for i, fc := range ch.Fields {
+		fields[i] = fc.String()
+	}
+	fmt.Fprintf(&b, " (%s)", strings.Join(fields, ", "))
+	return b.String()
+}
+
+// Journal is the append-only list of changes made to a character.
+// Entries are never removed: undoing a change appends an Undo entry.
+// Now returns the time recorded in new entries; time.Now is used when it is
+// nil. It is not serialized.
+type Journal struct {
+	Changes []Change         `json:"changes,omitempty"`
+	Now     func() time.Time `json:"-"`
+}
+
+// IsZero returns true if the journal has no changes, so characters without
+// changes omit it from their JSON form.
+func (j Journal) IsZero() bool {
+	return len(j.Changes) == 0
+}
+
+// stacks replays the journal and returns the sequence numbers of the Edit
+// entries that can be undone and redone, the next one last.
+// Recording a new Edit clears the changes to redo.
+func (j *Journal) stacks() (undo []int, redo []int) {
+	for _, ch := range j.Changes {
+		switch ch.Action {
+		case Edit:
+			undo = append(undo, ch.Seq)
+			redo = nil
+		case Undo:
+			undo = undo[:len(undo)-1]
+			redo = append(redo, ch.Target)
+		case Redo:
+			redo = redo[:len(redo)-1]
+			undo = append(undo, ch.Target)
+		}
+	}
+	return undo, redo
+}
+
+// Undoable returns the sequence numbers of the changes that can be undone,
+// the next one to undo first.
+func (j *Journal) Undoable() []int {
+	undo, _ := j.stacks()
+	slices.Reverse(undo)
+	return undo
+}
+
+// Redoable returns the sequence numbers of the changes that can be redone,
+// the next one to redo first.
+func (j *Journal) Redoable() []int {
+	_, redo := j.stacks()
+	slices.Reverse(redo)
+	return redo
+}
+
+// get returns the entry with the given sequence number.
+func (j *Journal) get(seq int) Change {
+	return j.Changes[seq-1]
+}
+
+// append adds an entry to the journal, numbering it.
+func (j *Journal) append(ch Change) Change {
+	ch.Seq = len(j.Changes) + 1
+	ch.Time = time.Now()
+	if j.Now != nil {
+		ch.Time = j.Now()
+	}
+	j.Changes = append(j.Changes, ch)
+	return ch
+}
+
+// snapshot returns the JSON form of the character without its journal,
+// decoded as a tree of maps with numbers kept as json.Number.
+func (c *Character) snapshot() (map[string]any, error) {
+	clone := *c
+	clone.Journal = Journal{}
+	data, err := json.Marshal(&clone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal character: %w", err)
+	}
+	var tree map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal character: %w", err)
+	}
+	return tree, nil
+}
+
+// diffTrees returns the changes between two JSON trees, sorted by path.
+// Objects are compared key by key, and any other value as a whole.
+func diffTrees(prefix string, old map[string]any, new map[string]any) []FieldChange {
+	keys := make([]string, 0, len(old)+len(new))
+	for key := range old {
+		keys = append(keys, key)
+	}
+	for key := range new {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	var changes []FieldChange
+	for _, key := range keys {
+		path := prefix + key
+		oldValue, inOld := old[key]
+		newValue, inNew := new[key]
+		oldMap, oldIsMap := oldValue.(map[string]any)
+		newMap, newIsMap := newValue.(map[string]any)
+		switch {
+		case oldIsMap && newIsMap:
+			changes = append(changes, diffTrees(path+".", oldMap, newMap)...)
+		case inOld && inNew && reflect.DeepEqual(oldValue, newValue):
+		default:
+			fc := FieldChange{Path: path}
+			if inOld {
+				fc.Old, _ = json.Marshal(oldValue)
+			}
+			if inNew {
+				fc.New, _ = json.Marshal(newValue)
+			}
+			changes = append(changes, fc)
+		}
+	}
+	return changes
+}
+
+// setPath sets the value at the path of a JSON tree, creating intermediate
+// objects as needed, or removes it when raw is empty.
+func setPath(tree map[string]any, path string, raw json.RawMessage) error {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := tree[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			tree[key] = next
+		}
+		tree = next
+	}
+	last := keys[len(keys)-1]
+	if len(raw) == 0 {
+		delete(tree, last)
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", path, err)
+	}
+	tree[last] = value
+	return nil
+}
+
+// applyFields sets the character values from the given field changes,
+// using the new values, or the old ones when revert is true.
+// The journal is kept as it is.
+func (c *Character) applyFields(fields []FieldChange, revert bool) error {
+	tree, err := c.snapshot()
+	if err != nil {
+		return err
+	}
+	for _, fc := range fields {
+		value := fc.New
+		if revert {
+			value = fc.Old
+		}
+		if err := setPath(tree, fc.Path, value); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("failed to marshal character: %w", err)
+	}
+	var restored Character
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return fmt.Errorf("failed to unmarshal character: %w", err)
+	}
+	restored.Journal = c.Journal
+	*c = restored
+	return nil
+}
+
+// Record applies a change to the character and journals every value it
+// modified, with the author and reason of the change.
+// It returns the journal entry, or nil if the change modified nothing.
+// It returns an error if the change fails, in which case the character is
+// restored and nothing is journaled.
+func (c *Character) Record(author string, reason string, change func(c *Character) error) (*Change, error) {
+	before, err := c.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	if err := change(c); err != nil {
+		after, _ := c.snapshot()
+		if restoreErr := c.applyFields(diffTrees("", before, after), true); restoreErr != nil {
+			return nil, fmt.Errorf("%w (restore failed: %v)", err, restoreErr)
+		}
+		return nil, err
+	}
+	after, err := c.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	fields := diffTrees("", before, after)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	ch := c.Journal.append(Change{Author: author, Reason: reason, Action: Edit, Fields: fields})
+	return &ch, nil
+}
+
+// Undo reverts the last n changes that have not been undone yet, newest
+// first, journaling an Undo entry for each one.
+// It returns the new journal entries, or an error if fewer than n changes
+// can be undone, in which case nothing is undone.
+func (c *Character) Undo(n int, author string) ([]Change, error) {
+	return c.step(n, author, Undo)
+}
+
+// Redo reapplies the last n undone changes, journaling a Redo entry for
+// each one. Changes can no longer be redone once a new change is recorded.
+// It returns the new journal entries, or an error if fewer than n changes
+// can be redone, in which case nothing is redone.
+func (c *Character) Redo(n int, author string) ([]Change, error) {
+	return c.step(n, author, Redo)
+}
+
+// step undoes or redoes the last n changes.
+func (c *Character) step(n int, author string, action ChangeAction) ([]Change, error) {
+	targets := c.Journal.Undoable()
+	if action == Redo {
+		targets = c.Journal.Redoable()
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("invalid number of changes to %s: %d", action, n)
+	}
+	if n > len(targets) {
+		return nil, fmt.Errorf("only %d changes to %s, not %d", len(targets), action, n)
+	}
+	var entries []Change
+	for _, seq := range targets[:n] {
+		target := c.Journal.get(seq)
+		fields := make([]FieldChange, len(target.Fields))
+		for i, fc := range target.Fields {
+			fields[i] = fc
+			if action == Undo {
+				fields[i] = FieldChange{Path: fc.Path, Old: fc.New, New: fc.Old}
+			}
+		}
+		if err := c.applyFields(fields, false); err != nil {
+			return entries, err
+		}
+		entry := c.Journal.append(Change{Author: author, Reason: target.Reason, Action: action, Target: seq, Fields: fields})
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Reconstruct returns a copy of the character as it was right after the
+// journal entry with the given sequence number, or before any journaled
+// change for zero. The copy keeps the journal up to that entry.
+// It returns an error if the sequence number is not in the journal.
+func (c *Character) Reconstruct(seq int) (*Character, error) {
+	if seq < 0 || seq > len(c.Journal.Changes) {
+		return nil, fmt.Errorf("journal has no change #%d", seq)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal character: %w", err)
+	}
+	var past Character
+	if err := json.Unmarshal(data, &past); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal character: %w", err)
+	}
+	for i := len(past.Journal.Changes) - 1; i >= seq; i-- {
+		if err := past.applyFields(past.Journal.Changes[i].Fields, true); err != nil {
+			return nil, err
+		}
+	}
+	past.Journal.Changes = slices.Clip(past.Journal.Changes[:seq])
+	past.Journal.Now = c.Journal.Now
+	if seq == 0 {
+		past.Journal.Changes = nil
+	}
+	return &past, nil
+}
@@ -0,0 +1,183 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxLevel is the highest character level.
+const MaxLevel = 20
+
+// MaxAbilityScore is the highest score an ability score improvement or a
+// feat can raise an attribute to.
+const MaxAbilityScore = 20
+
+// improvementLevels lists the class levels granting an ability score
+// improvement, for the classes that do not use the default levels.
+var improvementLevels = map[string][]int{
+	"":        {4, 8, 12, 16, 19},
+	"fighter": {4, 6, 8, 12, 14, 16, 19},
+	"rogue":   {4, 8, 10, 12, 16, 19},
+}
+
+// IsImprovementLevel returns true if reaching the given level in the class
+// grants an ability score improvement, which can be traded for a feat.
+// Fighters gain extra improvements at levels 6 and 14, and rogues at 10.
+func IsImprovementLevel(job string, level int) bool {
+	levels, ok := improvementLevels[normalizeJob(job)]
+	if !ok {
+		levels = improvementLevels[""]
+	}
+	return slices.Contains(levels, level)
+}
+
+// LevelUpResult describes what a character gained by leveling up.
+// Level is the character level and ClassLevel the level reached in the
+// class Job.
+type LevelUpResult struct {
+	Job         string `json:"job"`
+	Level       int    `json:"level"`
+	ClassLevel  int    `json:"class_level"`
+	HitPoints   int    `json:"hit_points"`
+	Improvement bool   `json:"improvement,omitempty"`
+}
+
+// String returns a string representation of the LevelUpResult.
+func (r LevelUpResult) String() string {
+	result := fmt.Sprintf("reached level %d (%s %d), +%d max hit points", r.Level, r.Job, r.ClassLevel, r.HitPoints)
+	if r.Improvement {
+		result += ", ability score improvement available"
+	}
+	return result
+}
+
+// LevelUp raises the level of the first class of the character by one,
+// like AddClassLevel. Spell slots follow the new level for characters with
+// a single class.
+// It returns an error if the character is dead or already at MaxLevel.
+func (c *Character) LevelUp() (LevelUpResult, error) {
+	return c.AddClassLevel(c.ClassLevels()[0].Job, nil)
+}
+
+// AddClassLevel raises the level of the character by one, taking the level
+// in the given class. A class the character does not have yet requires the
+// multiclassing prerequisites, grants the multiclass proficiencies and
+// takes the skills chosen from those the class offers.
+// Maximum hit points grow by the fixed average of the class hit die plus
+// the constitution modifier, and current hit points grow by the same
+// amount. Spell slots are computed from the levels of every caster class
+// with the given spell data, which can be nil for characters with a single
+// class. Reaching an improvement level in the class grants an ability score
+// improvement to spend with ImproveAbilities or TakeFeat.
+// It returns an error if the character is dead, already at MaxLevel or
+// does not meet the multiclassing prerequisites. The character is not
+// modified on error.
+func (c *Character) AddClassLevel(job string, spells *SpellData, skills ...Skill) (LevelUpResult, error) {
+	if c.IsDead() {
+		return LevelUpResult{}, fmt.Errorf("%s is dead", c.Name)
+	}
+	if c.Level >= MaxLevel {
+		return LevelUpResult{}, fmt.Errorf("%s is already level %d", c.Name, MaxLevel)
+	}
+	job = normalizeJob(job)
+	if c.ClassLevel(job) == 0 {
+		if err := c.addClass(job, skills); err != nil {
+			return LevelUpResult{}, err
+		}
+	} else if len(skills) > 0 {
+		return LevelUpResult{}, fmt.Errorf("skills are only chosen when multiclassing into a new class")
+	}
+	if c.HitPoints.Max == 0 {
+		c.InitHitPoints()
+	}
+	for i := range c.Classes {
+		if c.Classes[i].Job == job {
+			c.Classes[i].Level++
+		}
+	}
+	c.Level++
+	before := c.HitPoints.Max
+	c.HitPoints.Max = ClassMaxHitPoints(c.ClassLevels(), c.EffectiveAttribute(Con))
+	gained := max(c.HitPoints.Max-before, 0)
+	if c.HitPoints.Current > 0 {
+		c.HitPoints.Current += gained
+	}
+	switch {
+	case spells != nil:
+		c.InitSpellcasting(spells)
+	case c.Spellcasting != nil && !c.IsMulticlass():
+		c.Spellcasting.Slots.Max = GetSpellSlots(c.Spellcasting.Progression, c.Level)
+	}
+	result := LevelUpResult{Job: job, Level: c.Level, ClassLevel: c.ClassLevel(job), HitPoints: gained}
+	result.Improvement = IsImprovementLevel(job, result.ClassLevel)
+	if result.Improvement {
+		c.Improvements++
+	}
+	return result, nil
+}
+
+// increaseAbilities raises the base score of each attribute by the given
+// amount, checking first that no score goes above MaxAbilityScore.
+func (c *Character) increaseAbilities(increases map[Attribute]int) error {
+	for attr, value := range increases {
+		if value < 0 {
+			return fmt.Errorf("invalid %s increase %d", attr, value)
+		}
+		if score := c.Attributes.Get(attr) + value; score > MaxAbilityScore {
+			return fmt.Errorf("%s would be %d, above the maximum of %d", attr, score, MaxAbilityScore)
+		}
+	}
+	if c.Attributes == nil {
+		c.Attributes = NewAttributesMap()
+	}
+	for attr, value := range increases {
+		c.Attributes.Increase(attr, value)
+	}
+	return nil
+}
+
+// ImproveAbilities spends an ability score improvement to raise the base
+// scores by two points in total, either one attribute by two or two
+// attributes by one, up to MaxAbilityScore.
+// It returns an error if no improvement is available or the increases are
+// not valid.
+func (c *Character) ImproveAbilities(increases map[Attribute]int) error {
+	if c.Improvements == 0 {
+		return fmt.Errorf("%s has no ability score improvement available", c.Name)
+	}
+	total := 0
+	for _, value := range increases {
+		total += value
+	}
+	if total != 2 {
+		return fmt.Errorf("ability score improvement must add 2 points, got %d", total)
+	}
+	if err := c.increaseAbilities(increases); err != nil {
+		return err
+	}
+	c.Improvements--
+	return nil
+}
+
+// ParseIncreases parses attribute increases written as "STR:1,DEX:1".
+// An attribute without a value is increased by one, so "CON" is "CON:1".
+// It returns an error if an attribute or value is not valid.
+func ParseIncreases(text string) (map[Attribute]int, error) {
+	increases := map[Attribute]int{}
+	for _, part := range strings.Split(text, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(part), ":")
+		var attr Attribute
+		if err := attr.UnmarshalText([]byte(name)); err != nil {
+			return nil, err
+		}
+		amount := 1
+		if found {
+			if _, err := fmt.Sscanf(value, "%d", &amount); err != nil {
+				return nil, fmt.Errorf("invalid increase %q: %w", part, err)
+			}
+		}
+		increases[attr] += amount
+	}
+	return increases, nil
+}
@@ -0,0 +1,204 @@
+package character
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ModifierLayer identifies the layer a Modifier belongs to.
+// Layers are applied in order, from base to minimum, when computing
+// effective attribute scores.
+type ModifierLayer int
+
+// Enumeration of modifier layers.
+// LayerBase is reserved for the character base scores and is never stored
+// as a Modifier, but it is reported as the first contribution of a score.
+const (
+	LayerBase ModifierLayer = iota
+	LayerRacial
+	LayerItem
+	LayerTemporary
+	LayerOverride
+	LayerMinimum
+)
+
+// modifierLayerNames maps each ModifierLayer to its name.
+var modifierLayerNames = map[ModifierLayer]string{
+	LayerBase:      "base",
+	LayerRacial:    "racial",
+	LayerItem:      "item",
+	LayerTemporary: "temporary",
+	LayerOverride:  "override",
+	LayerMinimum:   "minimum",
+}
+
+// String returns the name of the modifier layer.
+func (l ModifierLayer) String() string {
+	if name, ok := modifierLayerNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("layer(%d)", int(l))
+}
+
+// SetsScore returns true if modifiers of the layer set the score instead of
+// adding to it.
+func (l ModifierLayer) SetsScore() bool {
+	return l == LayerBase || l == LayerOverride || l == LayerMinimum
+}
+
+// Modifier represents a single effect on an attribute score.
+// Racial, item and temporary modifiers add Value to the score, override
+// modifiers set the score to Value, like a curse setting STR to 3, and
+// minimum modifiers set the score to Value unless it is already higher,
+// like a potion of giant strength.
+// Duration is the number of rounds the modifier lasts; zero means permanent.
+type Modifier struct {
+	Source    string        `json:"source"`
+	Layer     ModifierLayer `json:"layer"`
+	Attribute Attribute     `json:"attribute"`
+	Value     int           `json:"value"`
+	Duration  int           `json:"duration,omitempty"`
+}
+
+// IsPermanent returns true if the modifier has no duration.
+func (m Modifier) IsPermanent() bool {
+	return m.Duration == 0
+}
+
+// String returns a string representation of the Modifier, as in
+// "Bull's Strength (temporary): STR +4 for 10 rounds".
+func (m Modifier) String() string {
+	value := fmt.Sprintf("%+d", m.Value)
+	if m.Layer.SetsScore() {
+		value = strconv.Itoa(m.Value)
+	}
+	result := fmt.Sprintf("%s (%s): %s %s", m.Source, m.Layer, GetAttributeShortName(m.Attribute), value)
+	if !m.IsPermanent() {
+		result += fmt.Sprintf(" for %d rounds", m.Duration)
+	}
+	return result
+}
+
+// Contribution describes how a source contributed to an effective score.
+// For override and minimum contributions, Value is the score that was set.
+type Contribution struct {
+	Source string
+	Layer  ModifierLayer
+	Value  int
+}
+
+// String returns a string representation of the Contribution.
+func (c Contribution) String() string {
+	if c.Layer.SetsScore() {
+		return fmt.Sprintf("%s (%s): %d", c.Source, c.Layer, c.Value)
+	}
+	return fmt.Sprintf("%s (%s): %+d", c.Source, c.Layer, c.Value)
+}
+
+// Modifiers is the list of modifiers affecting a character.
+// It computes effective scores on demand from a base AttributesMap,
+// so base scores are never mutated by temporary effects.
+type Modifiers []Modifier
+
+// Add appends a modifier to the list.
+func (ms *Modifiers) Add(m Modifier) {
+	*ms = append(*ms, m)
+}
+
+// Remove deletes every modifier with the given source.
+// It returns the number of modifiers removed.
+func (ms *Modifiers) Remove(source string) int {
+	kept := (*ms)[:0]
+	removed := 0
+	for _, m := range *ms {
+		if m.Source == source {
+			removed++
+			continue
+		}
+		kept = append(kept, m)
+	}
+	*ms = kept
+	return removed
+}
+
+// Tick advances temporary modifiers by one round.
+// Modifiers with a duration are decremented and removed when they reach zero.
+// It returns the modifiers that expired.
+func (ms *Modifiers) Tick() []Modifier {
+	kept := (*ms)[:0]
+	var expired []Modifier
+	for _, m := range *ms {
+		if m.Duration > 0 {
+			m.Duration--
+			if m.Duration == 0 {
+				expired = append(expired, m)
+				continue
+			}
+		}
+		kept = append(kept, m)
+	}
+	*ms = kept
+	return expired
+}
+
+// Effective computes the effective score for the given attribute.
+// Modifiers are applied layer by layer on top of the base score.
+func (ms Modifiers) Effective(base AttributesMap, attr Attribute) int {
+	score := base.Get(attr)
+	for _, c := range ms.Sources(base, attr)[1:] {
+		if c.Layer.SetsScore() {
+			score = c.Value
+		} else {
+			score += c.Value
+		}
+	}
+	return score
+}
+
+// Apply returns a new AttributesMap with the effective score of all six
+// attributes, including those missing from the base, which count as zero.
+// The base AttributesMap is not modified.
+func (ms Modifiers) Apply(base AttributesMap) AttributesMap {
+	result := NewAttributesMap()
+	for attr := Str; attr <= Cha; attr++ {
+		result.Set(attr, ms.Effective(base, attr))
+	}
+	return result
+}
+
+// Sources lists the contributions to the effective score of the given attribute.
+// The first contribution is always the base score, followed by the additive
+// layers in order. Then comes the last override added, if any, and last the
+// highest minimum, only when it raises the score.
+func (ms Modifiers) Sources(base AttributesMap, attr Attribute) []Contribution {
+	contributions := []Contribution{{Source: "base", Layer: LayerBase, Value: base.Get(attr)}}
+	score := base.Get(attr)
+	for _, layer := range []ModifierLayer{LayerRacial, LayerItem, LayerTemporary} {
+		for _, m := range ms {
+			if m.Attribute == attr && m.Layer == layer {
+				contributions = append(contributions, Contribution{Source: m.Source, Layer: m.Layer, Value: m.Value})
+				score += m.Value
+			}
+		}
+	}
+	var override, minimum *Modifier
+	for i, m := range ms {
+		if m.Attribute != attr {
+			continue
+		}
+		switch {
+		case m.Layer == LayerOverride:
+			override = &ms[i]
+		case m.Layer == LayerMinimum && (minimum == nil || m.Value > minimum.Value):
+			minimum = &ms[i]
+		}
+	}
+	if override != nil {
+		contributions = append(contributions, Contribution{Source: override.Source, Layer: LayerOverride, Value: override.Value})
+		score = override.Value
+	}
+	if minimum != nil && minimum.Value > score {
+		contributions = append(contributions, Contribution{Source: minimum.Source, Layer: LayerMinimum, Value: minimum.Value})
+	}
+	return contributions
+}
@@ -0,0 +1,187 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Recharge tells when a feature regains its uses.
+type Recharge string
+
+// Enumeration of feature recharges.
+// Features recharging on a short rest also recharge on a long rest.
+const (
+	RechargeShortRest Recharge = "short_rest"
+	RechargeLongRest  Recharge = "long_rest"
+)
+
+// Feature represents a class or racial feature with limited uses, such as
+// Second Wind or Action Surge.
+// It includes JSON struct tags for serialization.
+// Uses is the number of uses left, up to MaxUses.
+type Feature struct {
+	Name     string   `json:"name"`
+	Uses     int      `json:"uses"`
+	MaxUses  int      `json:"max_uses"`
+	Recharge Recharge `json:"recharge"`
+}
+
+// String returns a string representation of the Feature.
+func (f Feature) String() string {
+	return fmt.Sprintf("%s %d/%d (%s)", f.Name, f.Uses, f.MaxUses, strings.ReplaceAll(string(f.Recharge), "_", " "))
+}
+
+// feature returns the feature with the given name, ignoring case, or nil.
+func (c *Character) feature(name string) *Feature {
+	for i := range c.Features {
+		if strings.EqualFold(c.Features[i].Name, name) {
+			return &c.Features[i]
+		}
+	}
+	return nil
+}
+
+// AddFeature gives the character a feature with all its uses available.
+// A feature with the same name is replaced.
+func (c *Character) AddFeature(name string, maxUses int, recharge Recharge) {
+	feature := Feature{Name: name, Uses: maxUses, MaxUses: maxUses, Recharge: recharge}
+	if f := c.feature(name); f != nil {
+		*f = feature
+		return
+	}
+	c.Features = append(c.Features, feature)
+}
+
+// UseFeature spends one use of the feature with the given name.
+// It returns an error if the character does not have the feature or has no
+// uses left.
+func (c *Character) UseFeature(name string) error {
+	f := c.feature(name)
+	if f == nil {
+		return fmt.Errorf("%s does not have feature %q", c.Name, name)
+	}
+	if f.Uses <= 0 {
+		return fmt.Errorf("no uses of %s left", f.Name)
+	}
+	f.Uses--
+	return nil
+}
+
+// restoreFeatures recovers the uses of the features recharging on the
+// given rest. It returns the names of the features that recovered uses.
+func (c *Character) restoreFeatures(longRest bool) []string {
+	var restored []string
+	for i := range c.Features {
+		f := &c.Features[i]
+		if f.Uses >= f.MaxUses || (f.Recharge == RechargeLongRest && !longRest) {
+			continue
+		}
+		f.Uses = f.MaxUses
+		restored = append(restored, f.Name)
+	}
+	return restored
+}
+
+// HitDiceAvailable returns the number of hit dice the character can spend,
+// one per level minus the ones already spent.
+func (c *Character) HitDiceAvailable() int {
+	return max(c.Level-c.HitDiceSpent, 0)
+}
+
+// RestResult represents what a character recovered during a rest.
+// HitDiceRolls holds every hit die rolled on a short rest, before adding
+// the constitution modifier.
+type RestResult struct {
+	LongRest          bool
+	HitDiceRolls      []int
+	HitPointsRestored int
+	HitDiceRecovered  int
+	FeaturesRestored  []string
+	ExhaustionLevel   int
+}
+
+// String returns a string representation of the RestResult.
+func (r RestResult) String() string {
+	var parts []string
+	if r.LongRest {
+		parts = append(parts, "long rest")
+	} else {
+		parts = append(parts, "short rest")
+	}
+	if len(r.HitDiceRolls) > 0 {
+		parts = append(parts, fmt.Sprintf("hit dice %v", r.HitDiceRolls))
+	}
+	parts = append(parts, fmt.Sprintf("%d HP restored", r.HitPointsRestored))
+	if r.HitDiceRecovered > 0 {
+		parts = append(parts, fmt.Sprintf("%d hit dice recovered", r.HitDiceRecovered))
+	}
+	if len(r.FeaturesRestored) > 0 {
+		parts = append(parts, "features restored: "+strings.Join(r.FeaturesRestored, ", "))
+	}
+	if r.LongRest {
+		parts = append(parts, fmt.Sprintf("exhaustion %d", r.ExhaustionLevel))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ShortRest has the character spend up to the given number of hit dice,
+// rolling each die and adding the constitution modifier to recover hit
+// points, with a minimum of zero per die. Multiclass characters spend their
+// largest hit dice first. No more dice are spent once the
+// character is at maximum hit points. Pact magic slots and features
+// recharging on a short rest are restored.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+// It returns an error if the character is dead or dying, since only a
+// conscious or stable character can rest, or asks for more hit dice than
+// available.
+func (c *Character) ShortRest(hitDice int, r dice.Roller) (RestResult, error) {
+	if c.IsDead() {
+		return RestResult{}, fmt.Errorf("%s is dead", c.Name)
+	}
+	if c.IsDying() {
+		return RestResult{}, fmt.Errorf("%s is dying and must be stabilized before resting", c.Name)
+	}
+	if hitDice > c.HitDiceAvailable() {
+		return RestResult{}, fmt.Errorf("%s has %d hit dice available, not %d", c.Name, c.HitDiceAvailable(), hitDice)
+	}
+	if r == nil {
+		r = dice.DefaultRoller
+	}
+	var result RestResult
+	pool := c.HitDicePool()
+	mod := AbilityModifier(c.EffectiveAttribute(Con))
+	for i := 0; i < hitDice && c.HitPoints.Current < c.HitPoints.Max; i++ {
+		roll := r.RollDie(pool[c.HitDiceSpent])
+		c.HitDiceSpent++
+		result.HitDiceRolls = append(result.HitDiceRolls, roll)
+		result.HitPointsRestored += c.Heal(max(roll+mod, 0))
+	}
+	c.RestoreSpellSlots(false)
+	result.FeaturesRestored = c.restoreFeatures(false)
+	result.ExhaustionLevel = c.Conditions.ExhaustionLevel()
+	return result, nil
+}
+
+// LongRest restores the character to maximum hit points, recovers spent
+// hit dice up to half its level, with a minimum of one, restores every spell
+// slot and feature use, and reduces exhaustion by one level.
+// It returns an error if the character is dead or at zero hit points, since
+// a character needs at least one hit point to benefit from a long rest.
+func (c *Character) LongRest() (RestResult, error) {
+	if c.IsDead() {
+		return RestResult{}, fmt.Errorf("%s is dead", c.Name)
+	}
+	if c.HitPoints.Current <= 0 {
+		return RestResult{}, fmt.Errorf("%s needs at least 1 hit point to benefit from a long rest", c.Name)
+	}
+	result := RestResult{LongRest: true}
+	result.HitPointsRestored = c.Heal(c.HitPoints.Max - c.HitPoints.Current)
+	result.HitDiceRecovered = min(max(c.Level/2, 1), c.HitDiceSpent)
+	c.HitDiceSpent -= result.HitDiceRecovered
+	c.RestoreSpellSlots(true)
+	result.FeaturesRestored = c.restoreFeatures(true)
+	result.ExhaustionLevel = c.Conditions.ReduceExhaustion(1)
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package character
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// RollMode tells how many d20 are rolled and which one is kept.
+type RollMode int
+
+// Enumeration of roll modes.
+// Advantage keeps the higher of two d20 and Disadvantage the lower one.
+const (
+	Normal RollMode = iota
+	Advantage
+	Disadvantage
+)
+
+// rollModeNames maps each RollMode to its name.
+var rollModeNames = map[RollMode]string{
+	Normal:       "normal",
+	Advantage:    "advantage",
+	Disadvantage: "disadvantage",
+}
+
+// String returns the name of the roll mode.
+func (m RollMode) String() string {
+	if name, ok := rollModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("RollMode(%d)", int(m))
+}
+
+// CombineRollModes returns the roll mode resulting from several sources.
+// Any number of advantages and disadvantages cancel each other out, so a
+// roll with both is a normal roll.
+func CombineRollModes(modes ...RollMode) RollMode {
+	var advantage, disadvantage bool
+	for _, mode := range modes {
+		switch mode {
+		case Advantage:
+			advantage = true
+		case Disadvantage:
+			disadvantage = true
+		}
+	}
+	switch {
+	case advantage && !disadvantage:
+		return Advantage
+	case disadvantage && !advantage:
+		return Disadvantage
+	}
+	return Normal
+}
+
+// RollD20 rolls a d20 with the given Roller and roll mode.
+// It returns the kept roll and every die rolled.
+func RollD20(r dice.Roller, mode RollMode) (int, []int) {
+	first := r.RollDie(20)
+	if mode == Normal {
+		return first, []int{first}
+	}
+	second := r.RollDie(20)
+	rolls := []int{first, second}
+	if mode == Advantage {
+		return max(first, second), rolls
+	}
+	return min(first, second), rolls
+}
+
+// RollKind identifies the kind of d20 roll a creature makes.
+type RollKind string
+
+// Enumeration of roll kinds.
+// AttackRoll is an attack made by the creature, while Attacked is an attack
+// made against it.
+const (
+	AbilityCheck RollKind = "check"
+	SavingThrow  RollKind = "save"
+	AttackRoll   RollKind = "attack"
+	Attacked     RollKind = "attacked"
+)
+
+// RollResult represents the outcome of an ability check or saving throw.
+// Name is the creature rolling, when known, and Skill the skill used by
+// the check, if any. Roll is the kept d20 roll, Rolls every die rolled and
+// Total the roll plus the modifier. AutoFail is set when a condition makes
+// the roll fail without rolling.
+type RollResult struct {
+	Name      string    `json:"name,omitempty"`
+	Kind      RollKind  `json:"kind"`
+	Attribute Attribute `json:"attribute"`
+	Skill     Skill     `json:"skill,omitempty"`
+	Mode      RollMode  `json:"mode"`
+	Rolls     []int     `json:"rolls,omitempty"`
+	Roll      int       `json:"roll"`
+	Modifier  int       `json:"modifier"`
+	Total     int       `json:"total"`
+	DC        int       `json:"dc"`
+	Success   bool      `json:"success"`
+	AutoFail  bool      `json:"auto_fail,omitempty"`
+}
+
+// Check returns the attribute and skill of the roll.
+func (r RollResult) Check() Check {
+	return Check{Attribute: r.Attribute, Skill: r.Skill}
+}
+
+// String returns a string representation of the RollResult.
+func (r RollResult) String() string {
+	outcome := "failure"
+	if r.Success {
+		outcome = "success"
+	}
+	name := ""
+	if r.Name != "" {
+		name = r.Name + " "
+	}
+	if r.AutoFail {
+		return fmt.Sprintf("%s%s %s vs DC %d: automatic %s", name, r.Check(), r.Kind, r.DC, outcome)
+	}
+	mode := ""
+	if r.Mode != Normal {
+		mode = fmt.Sprintf(" with %s %v", r.Mode, r.Rolls)
+	}
+	return fmt.Sprintf("%s%s %s%s: %d %+d = %d vs DC %d, %s",
+		name, r.Check(), r.Kind, mode, r.Roll, r.Modifier, r.Total, r.DC, outcome)
+}
+
+// Roll makes a d20 roll of the given kind with a modifier against a DC,
+// applying the roll mode and automatic failures of the conditions.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func (cs Conditions) Roll(kind RollKind, attr Attribute, modifier int, dc int, r dice.Roller) RollResult {
+	if r == nil {
+		r = dice.DefaultRoller
+	}
+	result := RollResult{
+		Kind:      kind,
+		Attribute: attr,
+		Mode:      cs.RollMode(kind, attr),
+		Modifier:  modifier,
+		DC:        dc,
+	}
+	if kind == SavingThrow && cs.AutoFailsSave(attr) {
+		result.AutoFail = true
+		return result
+	}
+	result.Roll, result.Rolls = RollD20(r, result.Mode)
+	result.Total = result.Roll + result.Modifier
+	result.Success = result.Total >= dc
+	return result
+}
+
+// AbilityCheck rolls an ability check for the given attribute against a DC.
+// The modifier comes from the effective attribute score, and conditions may
+// impose advantage or disadvantage.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func (c *Character) AbilityCheck(attr Attribute, dc int, r dice.Roller) RollResult {
+	return c.RollCheck(AttributeCheck(attr), dc, r)
+}
+
+// SavingThrow rolls a saving throw for the given attribute against a DC.
+// The modifier comes from SaveModifier, and conditions may
+// impose advantage or disadvantage, or make the save fail automatically.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func (c *Character) SavingThrow(attr Attribute, dc int, r dice.Roller) RollResult {
+	result := c.Conditions.Roll(SavingThrow, attr, c.SaveModifier(attr), dc, r)
+	result.Name = c.Name
+	return result
+}
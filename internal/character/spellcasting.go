@@ -0,0 +1,270 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Spellcasting represents the spellcasting state of a character.
+// It includes JSON struct tags for serialization.
+// Known lists the spells the character knows, or has in its spellbook, and
+// Prepared the spells ready to be cast by classes that prepare spells.
+// Class is the class providing the spellcasting ability, or the character
+// job when empty. PactSlots holds the pact magic slots of a multiclass
+// character combining pact magic with another caster class, whose slots
+// are in Slots; a single class pact caster keeps its pact slots in Slots.
+type Spellcasting struct {
+	Class       string            `json:"class,omitempty"`
+	Ability     Attribute         `json:"ability"`
+	Progression CasterProgression `json:"progression"`
+	Prepares    bool              `json:"prepares"`
+	Known       []string          `json:"known"`
+	Prepared    []string          `json:"prepared,omitempty"`
+	Slots       SpellSlots        `json:"slots"`
+	PactSlots   *SpellSlots       `json:"pact_slots,omitempty"`
+}
+
+// CastResult represents the outcome of casting a spell.
+// Damage and Healing are the rolled totals, or zero when the spell does not
+// deal damage or heal.
+type CastResult struct {
+	Spell     Spell
+	SlotLevel int
+	Damage    int
+	Healing   int
+}
+
+// String returns a string representation of the CastResult.
+func (r CastResult) String() string {
+	result := r.Spell.Name
+	if r.SlotLevel > 0 {
+		result += fmt.Sprintf(" (level %d slot)", r.SlotLevel)
+	}
+	if r.Damage > 0 {
+		result += fmt.Sprintf(": %d %s damage", r.Damage, r.Spell.DamageType)
+	}
+	if r.Healing > 0 {
+		result += fmt.Sprintf(": %d healing", r.Healing)
+	}
+	return result
+}
+
+// InitSpellcasting sets up the spellcasting state for the character classes
+// and levels, keeping any known and prepared spells and used slots.
+// The spellcasting ability comes from the first caster class, and the spell
+// slots from the levels of every caster class.
+// It returns an error if none of the classes can cast spells.
+func (c *Character) InitSpellcasting(data *SpellData) error {
+	var caster CasterClass
+	class := ""
+	for _, cl := range c.ClassLevels() {
+		if cc, ok := data.GetCaster(cl.Job); ok {
+			caster, class = cc, cl.Job
+			break
+		}
+	}
+	if class == "" {
+		return fmt.Errorf("job %q cannot cast spells", c.Job)
+	}
+	ability, _ := GetAttributeFromShortName(caster.Ability)
+	if c.Spellcasting == nil {
+		c.Spellcasting = &Spellcasting{}
+	}
+	if class != normalizeJob(c.Job) {
+		c.Spellcasting.Class = class
+	}
+	c.Spellcasting.Ability = ability
+	c.Spellcasting.Progression = caster.Progression
+	c.Spellcasting.Prepares = caster.Prepares
+	slots, pact := ClassSpellSlots(c.ClassLevels(), data)
+	switch {
+	case slots == [MaxSpellLevel]int{}:
+		c.Spellcasting.Slots.Max = pact
+		c.Spellcasting.PactSlots = nil
+	case pact == [MaxSpellLevel]int{}:
+		c.Spellcasting.Slots.Max = slots
+		c.Spellcasting.PactSlots = nil
+	default:
+		c.Spellcasting.Slots.Max = slots
+		if c.Spellcasting.PactSlots == nil {
+			c.Spellcasting.PactSlots = &SpellSlots{}
+		}
+		c.Spellcasting.PactSlots.Max = pact
+	}
+	return nil
+}
+
+// spellcastingLevel returns the level of the class providing the
+// spellcasting ability.
+func (c *Character) spellcastingLevel() int {
+	if c.Spellcasting == nil || c.Spellcasting.Class == "" {
+		return c.ClassLevel(c.Job)
+	}
+	return c.ClassLevel(c.Spellcasting.Class)
+}
+
+// SpellcastingModifier returns the ability modifier of the spellcasting attribute.
+// It returns zero if the character cannot cast spells.
+func (c *Character) SpellcastingModifier() int {
+	if c.Spellcasting == nil {
+		return 0
+	}
+	return AbilityModifier(c.EffectiveAttribute(c.Spellcasting.Ability))
+}
+
+// SpellSaveDC returns the difficulty class for saving throws against the
+// character spells: 8 + proficiency bonus + spellcasting modifier.
+func (c *Character) SpellSaveDC() int {
+	return 8 + c.ProficiencyBonus() + c.SpellcastingModifier()
+}
+
+// SpellAttackBonus returns the bonus for spell attack rolls:
+// proficiency bonus + spellcasting modifier.
+func (c *Character) SpellAttackBonus() int {
+	return c.ProficiencyBonus() + c.SpellcastingModifier()
+}
+
+// PreparedLimit returns how many spells the character can prepare:
+// spellcasting modifier + level of the spellcasting class, with a minimum
+// of one. Half casters count half their class level, rounded down with a
+// minimum of one.
+func (c *Character) PreparedLimit() int {
+	level := c.spellcastingLevel()
+	if c.Spellcasting != nil && c.Spellcasting.Progression == HalfCaster {
+		level = max(1, level/2)
+	}
+	return max(1, c.SpellcastingModifier()+level)
+}
+
+// LearnSpell adds a spell to the known spells of the character.
+// It returns an error if the character cannot cast spells, the spell is not
+// in the list of any of its classes, or it is already known.
+func (c *Character) LearnSpell(spell Spell) error {
+	if c.Spellcasting == nil {
+		return fmt.Errorf("%s cannot cast spells", c.Name)
+	}
+	if !slices.ContainsFunc(spell.Classes, func(class string) bool { return c.ClassLevel(class) > 0 }) {
+		return fmt.Errorf("spell %q is not available to %s", spell.ID, c.Job)
+	}
+	if slices.Contains(c.Spellcasting.Known, spell.ID) {
+		return fmt.Errorf("spell %q is already known", spell.ID)
+	}
+	c.Spellcasting.Known = append(c.Spellcasting.Known, spell.ID)
+	return nil
+}
+
+// PrepareSpell adds a known spell to the prepared spells of the character.
+// Cantrips are always ready and do not need to be prepared.
+// It returns an error if the class does not prepare spells, the spell is not
+// known, or the PreparedLimit is reached.
+func (c *Character) PrepareSpell(spell Spell) error {
+	if c.Spellcasting == nil || !c.Spellcasting.Prepares {
+		return fmt.Errorf("%s does not prepare spells", c.Name)
+	}
+	if !slices.Contains(c.Spellcasting.Known, spell.ID) {
+		return fmt.Errorf("spell %q is not known", spell.ID)
+	}
+	if spell.IsCantrip() || slices.Contains(c.Spellcasting.Prepared, spell.ID) {
+		return nil
+	}
+	if len(c.Spellcasting.Prepared) >= c.PreparedLimit() {
+		return fmt.Errorf("cannot prepare %q: limit of %d spells reached", spell.ID, c.PreparedLimit())
+	}
+	c.Spellcasting.Prepared = append(c.Spellcasting.Prepared, spell.ID)
+	return nil
+}
+
+// UnprepareSpell removes a spell from the prepared spells of the character.
+// It returns true if the spell was prepared.
+func (c *Character) UnprepareSpell(spellID string) bool {
+	if c.Spellcasting == nil {
+		return false
+	}
+	i := slices.Index(c.Spellcasting.Prepared, spellID)
+	if i < 0 {
+		return false
+	}
+	c.Spellcasting.Prepared = slices.Delete(c.Spellcasting.Prepared, i, i+1)
+	return true
+}
+
+// CanCast returns true if the spell is ready to be cast: it is known and,
+// for classes that prepare spells, a cantrip or prepared.
+func (c *Character) CanCast(spell Spell) bool {
+	if c.Spellcasting == nil || !slices.Contains(c.Spellcasting.Known, spell.ID) {
+		return false
+	}
+	if !c.Spellcasting.Prepares || spell.IsCantrip() {
+		return true
+	}
+	return slices.Contains(c.Spellcasting.Prepared, spell.ID)
+}
+
+// Cast casts a spell using a slot of the given level, and rolls its damage
+// or healing. Healing also adds the spellcasting modifier. Cantrips do not use
+// a slot and ignore slotLevel. Casting with a slot above the spell level adds
+// the spell scaling dice for each extra level.
+// It returns the CastResult, or an error if the spell is not ready, has
+// invalid dice expressions, the slot level is too low, or no slot of that
+// level is available.
+func (c *Character) Cast(spell Spell, slotLevel int) (*CastResult, error) {
+	if !c.CanCast(spell) {
+		return nil, fmt.Errorf("%s cannot cast %q", c.Name, spell.ID)
+	}
+	exprs := map[string]dice.Expression{}
+	for name, notation := range map[string]string{"damage": spell.Damage, "healing": spell.Healing, "scaling": spell.Scaling} {
+		if notation == "" {
+			continue
+		}
+		expr, err := dice.Parse(notation)
+		if err != nil {
+			return nil, fmt.Errorf("spell %q %s: %w", spell.ID, name, err)
+		}
+		exprs[name] = expr
+	}
+	if spell.IsCantrip() {
+		slotLevel = 0
+	} else {
+		if slotLevel < spell.Level {
+			return nil, fmt.Errorf("cannot cast level %d spell %q with a level %d slot", spell.Level, spell.ID, slotLevel)
+		}
+		if err := c.Spellcasting.Slots.Use(slotLevel); err != nil {
+			if c.Spellcasting.PactSlots == nil || c.Spellcasting.PactSlots.Use(slotLevel) != nil {
+				return nil, err
+			}
+		}
+	}
+	result := &CastResult{Spell: spell, SlotLevel: slotLevel}
+	extra := 0
+	if scaling, ok := exprs["scaling"]; ok {
+		for i := spell.Level; i < slotLevel; i++ {
+			extra += scaling.Roll()
+		}
+	}
+	if damage, ok := exprs["damage"]; ok {
+		result.Damage = damage.Roll() + extra
+	}
+	if healing, ok := exprs["healing"]; ok {
+		result.Healing = healing.Roll() + extra + c.SpellcastingModifier()
+	}
+	return result, nil
+}
+
+// RestoreSpellSlots recovers spell slots after a rest.
+// A long rest recovers every slot, while a short rest only recovers pact
+// magic slots.
+func (c *Character) RestoreSpellSlots(longRest bool) {
+	if c.Spellcasting == nil {
+		return
+	}
+	if c.Spellcasting.PactSlots != nil {
+		c.Spellcasting.PactSlots.RestoreAll()
+	} else if c.Spellcasting.Progression == PactCaster {
+		c.Spellcasting.Slots.RestoreAll()
+	}
+	if longRest {
+		c.Spellcasting.Slots.RestoreAll()
+	}
+}
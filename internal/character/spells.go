@@ -0,0 +1,358 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/jrecuero/DandD/internal/damage"
+	"github.com/jrecuero/DandD/internal/dataformat"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// SpellSchool represents the school of magic a spell belongs to.
+type SpellSchool string
+
+// Enumeration of spell schools.
+const (
+	Abjuration    SpellSchool = "abjuration"
+	Conjuration   SpellSchool = "conjuration"
+	Divination    SpellSchool = "divination"
+	Enchantment   SpellSchool = "enchantment"
+	Evocation     SpellSchool = "evocation"
+	Illusion      SpellSchool = "illusion"
+	Necromancy    SpellSchool = "necromancy"
+	Transmutation SpellSchool = "transmutation"
+)
+
+// MaxSpellLevel is the highest spell level.
+const MaxSpellLevel = 9
+
+// Spell represents a spell definition.
+// It includes JSON struct tags for serialization.
+// Level zero is a cantrip. Damage and Healing are dice notation expressions,
+// and Scaling is the extra dice added for each slot level above the spell level.
+type Spell struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	Level         int         `json:"level"`
+	School        SpellSchool `json:"school"`
+	CastingTime   string      `json:"casting_time"`
+	Range         string      `json:"range"`
+	Components    []string    `json:"components"`
+	Material      string      `json:"material,omitempty"`
+	Duration      string      `json:"duration"`
+	Concentration bool        `json:"concentration,omitempty"`
+	Ritual        bool        `json:"ritual,omitempty"`
+	Damage        string      `json:"damage,omitempty"`
+	DamageType    string      `json:"damage_type,omitempty"`
+	Healing       string      `json:"healing,omitempty"`
+	Scaling       string      `json:"scaling,omitempty"`
+	Save          string      `json:"save,omitempty"`
+	Attack        bool        `json:"attack,omitempty"`
+	Classes       []string    `json:"classes"`
+	Description   string      `json:"description"`
+}
+
+// IsCantrip returns true if the spell is a cantrip.
+func (s Spell) IsCantrip() bool {
+	return s.Level == 0
+}
+
+// CasterProgression represents how a class gains spell slots.
+type CasterProgression string
+
+// Enumeration of caster progressions.
+// Full casters use the full slot table, half casters gain slots as a full
+// caster of half their level starting at level 2, and pact casters use the
+// warlock pact magic table.
+const (
+	FullCaster CasterProgression = "full"
+	HalfCaster CasterProgression = "half"
+	PactCaster CasterProgression = "pact"
+)
+
+// CasterClass represents the spellcasting rules of a class.
+// Ability is the attribute short name used for spellcasting, and Prepares
+// tells whether the class prepares spells from its known list each day.
+type CasterClass struct {
+	Ability     string            `json:"ability"`
+	Progression CasterProgression `json:"progression"`
+	Prepares    bool              `json:"prepares"`
+}
+
+// SpellData represents the structure of the spells JSON file.
+// It includes the spell definitions and the spellcasting rules by class.
+type SpellData struct {
+	Spells  []Spell                `json:"spells"`
+	Casters map[string]CasterClass `json:"casters"`
+	catalog map[string]Spell
+}
+
+// LoadSpellData reads the spell data from a JSON, YAML or TOML file, chosen
+// by its extension, and unmarshals it into a SpellData struct.
+// It returns the SpellData and any error encountered during the process.
+func LoadSpellData(filename string) (*SpellData, error) {
+	file, err := dataformat.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSpellData(file)
+}
+
+// ParseSpellData unmarshals the spell data from JSON content into
+// a SpellData struct and validates it.
+// It returns the SpellData and any error encountered during the process.
+func ParseSpellData(content []byte) (*SpellData, error) {
+	var data SpellData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Validate checks that spell IDs are unique, levels are in range, dice
+// expressions and damage types are valid and every caster class uses a known attribute.
+func (d *SpellData) Validate() error {
+	seen := map[string]bool{}
+	for _, spell := range d.Spells {
+		if spell.ID == "" {
+			return fmt.Errorf("spell %q has no id", spell.Name)
+		}
+		if seen[spell.ID] {
+			return fmt.Errorf("duplicate spell id %q", spell.ID)
+		}
+		seen[spell.ID] = true
+		if spell.Level < 0 || spell.Level > MaxSpellLevel {
+			return fmt.Errorf("spell %q has invalid level %d", spell.ID, spell.Level)
+		}
+		for _, notation := range []string{spell.Damage, spell.Healing, spell.Scaling} {
+			if notation == "" {
+				continue
+			}
+			if _, err := dice.Parse(notation); err != nil {
+				return fmt.Errorf("spell %q: %w", spell.ID, err)
+			}
+		}
+		if spell.DamageType != "" {
+			if _, err := damage.ParseType(spell.DamageType); err != nil {
+				return fmt.Errorf("spell %q: %w", spell.ID, err)
+			}
+		}
+	}
+	for job, caster := range d.Casters {
+		if _, ok := GetAttributeFromShortName(caster.Ability); !ok {
+			return fmt.Errorf("caster %q has invalid ability %q", job, caster.Ability)
+		}
+		switch caster.Progression {
+		case FullCaster, HalfCaster, PactCaster:
+		default:
+			return fmt.Errorf("caster %q has invalid progression %q", job, caster.Progression)
+		}
+	}
+	return nil
+}
+
+// GetSpell returns the spell definition with the given ID.
+// It returns the Spell and a boolean indicating whether the spell was found.
+func (d *SpellData) GetSpell(id string) (Spell, bool) {
+	if d.catalog == nil {
+		d.catalog = make(map[string]Spell, len(d.Spells))
+		for _, spell := range d.Spells {
+			d.catalog[spell.ID] = spell
+		}
+	}
+	spell, ok := d.catalog[id]
+	return spell, ok
+}
+
+// GetCaster returns the spellcasting rules for the given class.
+// It returns the CasterClass and a boolean indicating whether the class can cast spells.
+func (d *SpellData) GetCaster(job string) (CasterClass, bool) {
+	caster, ok := d.Casters[normalizeJob(job)]
+	return caster, ok
+}
+
+// ClassSpells returns the spells available to the given class up to the
+// given spell level, sorted by level and name.
+func (d *SpellData) ClassSpells(job string, maxLevel int) []Spell {
+	job = normalizeJob(job)
+	var spells []Spell
+	for _, spell := range d.Spells {
+		if spell.Level > maxLevel {
+			continue
+		}
+		for _, class := range spell.Classes {
+			if normalizeJob(class) == job {
+				spells = append(spells, spell)
+				break
+			}
+		}
+	}
+	sort.Slice(spells, func(i, j int) bool {
+		if spells[i].Level != spells[j].Level {
+			return spells[i].Level < spells[j].Level
+		}
+		return spells[i].Name < spells[j].Name
+	})
+	return spells
+}
+
+// fullCasterSlots is the spell slot table for full casters.
+// Each row is a caster level and each column a spell level.
+var fullCasterSlots = [20][MaxSpellLevel]int{
+	{2},
+	{3},
+	{4, 2},
+	{4, 3},
+	{4, 3, 2},
+	{4, 3, 3},
+	{4, 3, 3, 1},
+	{4, 3, 3, 2},
+	{4, 3, 3, 3, 1},
+	{4, 3, 3, 3, 2},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 2, 1, 1},
+}
+
+// pactSlots lists the number of pact magic slots and their level by warlock level.
+var pactSlots = [20][2]int{
+	{1, 1}, {2, 1}, {2, 2}, {2, 2}, {2, 3}, {2, 3}, {2, 4}, {2, 4}, {2, 5}, {2, 5},
+	{3, 5}, {3, 5}, {3, 5}, {3, 5}, {3, 5}, {3, 5}, {4, 5}, {4, 5}, {4, 5}, {4, 5},
+}
+
+// FullCasterSlots returns the spell slots of a full caster of the given level.
+// The returned array is indexed by spell level minus one.
+func FullCasterSlots(casterLevel int) [MaxSpellLevel]int {
+	if casterLevel < 1 {
+		return [MaxSpellLevel]int{}
+	}
+	return fullCasterSlots[min(casterLevel, 20)-1]
+}
+
+// GetSpellSlots returns the maximum spell slots for the given progression
+// and class level. The returned array is indexed by spell level minus one.
+func GetSpellSlots(progression CasterProgression, level int) [MaxSpellLevel]int {
+	switch progression {
+	case FullCaster:
+		return FullCasterSlots(level)
+	case HalfCaster:
+		if level < 2 {
+			return [MaxSpellLevel]int{}
+		}
+		return FullCasterSlots((level + 1) / 2)
+	case PactCaster:
+		var slots [MaxSpellLevel]int
+		if level >= 1 {
+			pact := pactSlots[min(level, 20)-1]
+			slots[pact[1]-1] = pact[0]
+		}
+		return slots
+	}
+	return [MaxSpellLevel]int{}
+}
+
+// ClassSpellSlots returns the spell slots and the pact magic slots for the
+// levels of one or more classes, using the caster classes of the spell data.
+// A single caster class uses its own progression. Several caster classes
+// combine into a full caster level adding every full caster level and half
+// of each half caster level, rounded down. Pact magic slots are kept apart.
+// The returned arrays are indexed by spell level minus one.
+func ClassSpellSlots(classes []ClassLevel, data *SpellData) ([MaxSpellLevel]int, [MaxSpellLevel]int) {
+	var slots, pact [MaxSpellLevel]int
+	var casters []ClassLevel
+	var progression CasterProgression
+	casterLevel := 0
+	for _, cl := range classes {
+		caster, ok := data.GetCaster(cl.Job)
+		if !ok {
+			continue
+		}
+		switch caster.Progression {
+		case FullCaster:
+			casterLevel += cl.Level
+		case HalfCaster:
+			casterLevel += cl.Level / 2
+		case PactCaster:
+			pact = GetSpellSlots(PactCaster, cl.Level)
+			continue
+		}
+		casters = append(casters, cl)
+		progression = caster.Progression
+	}
+	switch len(casters) {
+	case 0:
+	case 1:
+		slots = GetSpellSlots(progression, casters[0].Level)
+	default:
+		slots = FullCasterSlots(casterLevel)
+	}
+	return slots, pact
+}
+
+// SpellSlots tracks the maximum and used spell slots for each spell level.
+// Arrays are indexed by spell level minus one.
+type SpellSlots struct {
+	Max  [MaxSpellLevel]int `json:"max"`
+	Used [MaxSpellLevel]int `json:"used"`
+}
+
+// Available returns the number of unused slots of the given spell level.
+func (s *SpellSlots) Available(level int) int {
+	if level < 1 || level > MaxSpellLevel {
+		return 0
+	}
+	return s.Max[level-1] - s.Used[level-1]
+}
+
+// Use consumes one slot of the given spell level.
+// It returns an error if no slot of that level is available.
+func (s *SpellSlots) Use(level int) error {
+	if s.Available(level) <= 0 {
+		return fmt.Errorf("no spell slot of level %d available", level)
+	}
+	s.Used[level-1]++
+	return nil
+}
+
+// Restore recovers up to count used slots of the given spell level.
+// It returns the number of slots recovered.
+func (s *SpellSlots) Restore(level int, count int) int {
+	if level < 1 || level > MaxSpellLevel {
+		return 0
+	}
+	restored := min(count, s.Used[level-1])
+	s.Used[level-1] -= restored
+	return restored
+}
+
+// RestoreAll recovers every used slot.
+func (s *SpellSlots) RestoreAll() {
+	s.Used = [MaxSpellLevel]int{}
+}
+
+// String returns a string representation of the SpellSlots, listing the
+// available and maximum slots of each spell level with any slots.
+func (s *SpellSlots) String() string {
+	result := ""
+	for i, slots := range s.Max {
+		if slots > 0 {
+			result += fmt.Sprintf("%d: %d/%d, ", i+1, slots-s.Used[i], slots)
+		}
+	}
+	if len(result) > 2 {
+		result = result[:len(result)-2]
+	}
+	return result
+}
@@ -0,0 +1,246 @@
+package character
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Transcript records a character creation run so it can be replayed.
+// It includes JSON struct tags for serialization.
+// DataHash identifies the creation data the run used, Seed the seed of its
+// random sources, Answers the IDs of the chosen answers and Rolls the d20
+// rolled for each of them, in question order. Attributes holds the scores
+// the run ended with.
+type Transcript struct {
+	DataHash   string        `json:"data_hash"`
+	Seed       int64         `json:"seed"`
+	Answers    []string      `json:"answers"`
+	Rolls      []int         `json:"rolls"`
+	Attributes AttributesMap `json:"attributes"`
+}
+
+// HashCreationData returns the hash identifying creation data from its
+// content, as the hex encoded SHA-256 of the content. ParseCharacterData
+// hashes the JSON form of the parsed data, so the same data has the same
+// hash whatever the format and layout of its file.
+func HashCreationData(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// TestResult represents the outcome of the attribute test of an answer.
+// Score is the tested attribute score before the test and Changes the
+// signed amounts added to the attributes: the answer increases when the
+// test passed, or its fail penalties, negative, when it failed. Events
+// lists the changes clamped to the attribute bounds.
+type TestResult struct {
+	Attribute Attribute
+	Score     int
+	DC        int
+	Roll      int
+	Modifier  int
+	Passed    bool
+	Changes   map[Attribute]int
+	Events    []ClampEvent
+}
+
+// Total returns the d20 roll plus the ability modifier.
+func (tr TestResult) Total() int {
+	return tr.Roll + tr.Modifier
+}
+
+// TestAnswer rolls the attribute test of the answer, a d20 plus the ability
+// modifier of the tested attribute against the answer DC, and adds the
+// answer increases to the attributes when it passes, or its fail penalties
+// when it fails, keeping every attribute within the given bounds.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+// It returns an error if the answer tests an unknown attribute.
+func TestAnswer(attributes AttributesMap, answer Answer, bounds Bounds, r dice.Roller) (TestResult, error) {
+	attr, ok := GetAttributeFromShortName(answer.Test)
+	if !ok {
+		return TestResult{}, fmt.Errorf("answer %s tests unknown attribute %q", answer.AnswerID, answer.Test)
+	}
+	if r == nil {
+		r = dice.DefaultRoller
+	}
+	result := TestResult{
+		Attribute: attr,
+		Score:     attributes.Get(attr),
+		DC:        answer.DC,
+		Roll:      r.RollDie(20),
+	}
+	result.Modifier = AbilityModifier(result.Score)
+	result.Passed = result.Total() >= result.DC
+	result.Changes = GetAttributeFailEffects(answer)
+	if result.Passed {
+		result.Changes = GetAttributeIncreases(answer)
+	}
+	for _, attr := range slices.Sorted(maps.Keys(result.Changes)) {
+		if event := attributes.IncreaseWithin(attr, result.Changes[attr], bounds); event != nil {
+			result.Events = append(result.Events, *event)
+		}
+	}
+	return result, nil
+}
+
+// CreationStep represents a question answered during a creation run, with
+// the chosen answer and the result of its attribute test.
+type CreationStep struct {
+	Question Question
+	Answer   Answer
+	Result   TestResult
+}
+
+// Creation is a character creation run. It answers the creation questions
+// one at a time, testing the attribute of each answer and recording the
+// run in its Transcript.
+// Attributes holds the current scores and Upbringing the chosen answers.
+type Creation struct {
+	Attributes AttributesMap
+	Upbringing Upbringing
+	data       *CharacterCreationData
+	transcript Transcript
+	choose     func(q Question) (Answer, error)
+	roller     dice.Roller
+}
+
+// newCreation creates a creation run for the data starting from its
+// starting attributes clamped to its bounds.
+// It returns the run and the clamped starting attributes.
+func newCreation(d *CharacterCreationData, seed int64) (*Creation, []ClampEvent) {
+	attributes := NewAttributesMap()
+	for attrName, value := range d.StartingAttributes {
+		if attr, ok := GetAttributeFromShortName(attrName); ok {
+			attributes.Set(attr, value)
+		}
+	}
+	events := attributes.Clamp(d.Bounds())
+	c := &Creation{
+		Attributes: attributes,
+		data:       d,
+		transcript: Transcript{DataHash: d.Hash, Seed: seed},
+	}
+	return c, events
+}
+
+// NewCreation creates a creation run for the data. Answers are picked at
+// random and tests rolled with random sources seeded by seed, so the same
+// data and seed always produce the same run.
+// It returns the run and the starting attributes clamped to the bounds.
+func (d *CharacterCreationData) NewCreation(seed int64) (*Creation, []ClampEvent) {
+	c, events := newCreation(d, seed)
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+	c.choose = func(q Question) (Answer, error) {
+		if len(q.Answers) == 0 {
+			return Answer{}, fmt.Errorf("question for year %d has no answers", q.Year)
+		}
+		return q.Answers[rng.IntN(len(q.Answers))], nil
+	}
+	c.roller = dice.NewRandRoller(seed)
+	return c, events
+}
+
+// Answer picks an answer to the question and tests its attribute,
+// updating the attributes, the upbringing and the transcript.
+// It returns the step answered, or an error if no answer can be picked.
+func (c *Creation) Answer(q Question) (CreationStep, error) {
+	answer, err := c.choose(q)
+	if err != nil {
+		return CreationStep{}, err
+	}
+	result, err := TestAnswer(c.Attributes, answer, c.data.Bounds(), c.roller)
+	if err != nil {
+		return CreationStep{}, err
+	}
+	c.Upbringing.Record(answer)
+	c.transcript.Answers = append(c.transcript.Answers, answer.AnswerID)
+	c.transcript.Rolls = append(c.transcript.Rolls, result.Roll)
+	return CreationStep{Question: q, Answer: answer, Result: result}, nil
+}
+
+// Run answers every creation question in order.
+// It returns the steps answered, or an error if a question can not be
+// answered.
+func (c *Creation) Run() ([]CreationStep, error) {
+	steps := make([]CreationStep, 0, len(c.data.Questions))
+	for _, q := range c.data.Questions {
+		step, err := c.Answer(q)
+		if err != nil {
+			return steps, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// Transcript returns the transcript of the run so far, with a copy of the
+// current attributes.
+func (c *Creation) Transcript() *Transcript {
+	t := c.transcript
+	t.Answers = slices.Clone(t.Answers)
+	t.Rolls = slices.Clone(t.Rolls)
+	t.Attributes = maps.Clone(c.Attributes)
+	return &t
+}
+
+// Replay re-executes the creation run recorded in the transcript, with its
+// recorded answers and rolls, and verifies that it ends with the recorded
+// attributes. It then runs the data again from the transcript seed and
+// verifies that the seed still picks the same answers and rolls.
+// It returns the replayed steps, or an error if the transcript was recorded
+// with other data or the run does not match it.
+func (d *CharacterCreationData) Replay(t *Transcript) ([]CreationStep, error) {
+	if t.DataHash != d.Hash {
+		return nil, fmt.Errorf("transcript was recorded with creation data %s, not %s", t.DataHash, d.Hash)
+	}
+	if len(t.Answers) != len(d.Questions) || len(t.Rolls) != len(d.Questions) {
+		return nil, fmt.Errorf("transcript has %d answers and %d rolls for %d questions",
+			len(t.Answers), len(t.Rolls), len(d.Questions))
+	}
+	c, _ := newCreation(d, t.Seed)
+	c.choose = func(q Question) (Answer, error) {
+		id := t.Answers[len(c.transcript.Answers)]
+		for _, answer := range q.Answers {
+			if answer.AnswerID == id {
+				return answer, nil
+			}
+		}
+		return Answer{}, fmt.Errorf("question for year %d has no answer %s", q.Year, id)
+	}
+	c.roller = dice.NewScriptedRoller(t.Rolls...)
+	steps, err := c.Run()
+	if err != nil {
+		return steps, fmt.Errorf("failed to replay transcript: %w", err)
+	}
+	if diffs := diffAttributes(t.Attributes, c.Attributes); len(diffs) > 0 {
+		return steps, fmt.Errorf("replay attributes do not match the transcript: %s", strings.Join(diffs, ", "))
+	}
+
+	rerun, _ := d.NewCreation(t.Seed)
+	if _, err := rerun.Run(); err != nil {
+		return steps, fmt.Errorf("failed to rerun seed %d: %w", t.Seed, err)
+	}
+	if !slices.Equal(rerun.transcript.Answers, t.Answers) || !slices.Equal(rerun.transcript.Rolls, t.Rolls) {
+		return steps, fmt.Errorf("seed %d no longer reproduces the transcript answers and rolls", t.Seed)
+	}
+	return steps, nil
+}
+
+// diffAttributes returns the attributes that differ between the recorded
+// and the replayed scores, as "STR 12 != 13".
+func diffAttributes(recorded AttributesMap, replayed AttributesMap) []string {
+	var diffs []string
+	for attr := Str; attr <= Cha; attr++ {
+		if recorded.Get(attr) != replayed.Get(attr) {
+			diffs = append(diffs, fmt.Sprintf("%s %d != %d", GetAttributeShortName(attr), recorded.Get(attr), replayed.Get(attr)))
+		}
+	}
+	return diffs
+}
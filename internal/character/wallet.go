@@ -0,0 +1,232 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInsufficientFunds is returned when a wallet cannot cover a cost.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// Coin represents a coin denomination.
+type Coin int
+
+// Enumeration of coin denominations, from the smallest to the largest.
+// The value of each coin in copper pieces is returned by Coin.Value.
+const (
+	CP Coin = iota
+	SP
+	EP
+	GP
+	PP
+)
+
+// coinValues maps each Coin to its value in copper pieces.
+var coinValues = map[Coin]int{
+	CP: 1,
+	SP: 10,
+	EP: 50,
+	GP: 100,
+	PP: 1000,
+}
+
+// coinShortNames maps each Coin to its short name.
+var coinShortNames = map[Coin]string{
+	CP: "cp",
+	SP: "sp",
+	EP: "ep",
+	GP: "gp",
+	PP: "pp",
+}
+
+// coins lists every Coin from the smallest to the largest.
+var coins = []Coin{CP, SP, EP, GP, PP}
+
+// Value returns the value of the coin in copper pieces.
+func (c Coin) Value() int {
+	return coinValues[c]
+}
+
+// String returns the short name of the coin.
+func (c Coin) String() string {
+	return coinShortNames[c]
+}
+
+// GetCoinFromShortName returns the Coin corresponding to the given short name.
+// It returns the Coin and a boolean indicating whether the short name was found.
+func GetCoinFromShortName(shortName string) (Coin, bool) {
+	shortName = strings.ToLower(shortName)
+	for coin, name := range coinShortNames {
+		if name == shortName {
+			return coin, true
+		}
+	}
+	return 0, false
+}
+
+// ParseCost parses a cost such as "15 gp" or "2sp" and returns its value in
+// copper pieces. A plain number is read as copper pieces.
+// It returns an error if the cost is not valid.
+func ParseCost(cost string) (int, error) {
+	s := strings.ToLower(strings.ReplaceAll(cost, " ", ""))
+	coin := CP
+	if len(s) > 2 {
+		if c, ok := GetCoinFromShortName(s[len(s)-2:]); ok {
+			coin = c
+			s = s[:len(s)-2]
+		}
+	}
+	amount, err := strconv.Atoi(s)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid cost %q", cost)
+	}
+	return amount * coin.Value(), nil
+}
+
+// FormatCost returns a readable representation of a value in copper pieces,
+// using the fewest coins without electrum.
+func FormatCost(value int) string {
+	var w Wallet
+	w.Earn(value)
+	return w.String()
+}
+
+// Wallet represents the coins carried by a character.
+// It includes JSON struct tags for serialization.
+type Wallet struct {
+	CP int `json:"cp"`
+	SP int `json:"sp"`
+	EP int `json:"ep"`
+	GP int `json:"gp"`
+	PP int `json:"pp"`
+}
+
+// coin returns a pointer to the counter for the given coin.
+func (w *Wallet) coin(c Coin) *int {
+	switch c {
+	case SP:
+		return &w.SP
+	case EP:
+		return &w.EP
+	case GP:
+		return &w.GP
+	case PP:
+		return &w.PP
+	}
+	return &w.CP
+}
+
+// Get returns the number of coins of the given denomination.
+func (w *Wallet) Get(c Coin) int {
+	return *w.coin(c)
+}
+
+// Add puts the given number of coins of a denomination in the wallet.
+func (w *Wallet) Add(c Coin, amount int) {
+	if amount > 0 {
+		*w.coin(c) += amount
+	}
+}
+
+// Total returns the value of all coins in the wallet in copper pieces.
+func (w *Wallet) Total() int {
+	total := 0
+	for _, c := range coins {
+		total += w.Get(c) * c.Value()
+	}
+	return total
+}
+
+// Earn adds a value in copper pieces to the wallet, using the fewest coins
+// without electrum.
+func (w *Wallet) Earn(value int) {
+	w.makeChange(value, PP)
+}
+
+// makeChange adds a value in copper pieces to the wallet using coins not
+// larger than the given one. Electrum is never given as change.
+func (w *Wallet) makeChange(value int, largest Coin) {
+	for i := len(coins) - 1; i >= 0 && value > 0; i-- {
+		c := coins[i]
+		if c > largest || c == EP {
+			continue
+		}
+		*w.coin(c) += value / c.Value()
+		value %= c.Value()
+	}
+}
+
+// Spend removes a cost in copper pieces from the wallet.
+// Coins are spent from the smallest denomination up, and when no exact
+// payment is possible a larger coin is broken and the change is added
+// back to the wallet.
+// It returns ErrInsufficientFunds, leaving the wallet untouched, if the
+// wallet cannot cover the cost.
+func (w *Wallet) Spend(cost int) error {
+	if cost < 0 {
+		return fmt.Errorf("invalid cost %d", cost)
+	}
+	if total := w.Total(); total < cost {
+		return fmt.Errorf("%w: need %s, have %s", ErrInsufficientFunds, FormatCost(cost), FormatCost(total))
+	}
+	remaining := cost
+	for _, c := range coins {
+		used := min(w.Get(c), remaining/c.Value())
+		*w.coin(c) -= used
+		remaining -= used * c.Value()
+	}
+	if remaining == 0 {
+		return nil
+	}
+	for _, c := range coins {
+		if w.Get(c) > 0 {
+			*w.coin(c)--
+			w.makeChange(c.Value()-remaining, c-1)
+			return nil
+		}
+	}
+	return nil
+}
+
+// Exchange converts an amount of coins of one denomination into another.
+// It returns an error if the wallet does not hold enough coins or the
+// amount cannot be converted exactly.
+func (w *Wallet) Exchange(from Coin, amount int, to Coin) error {
+	if amount <= 0 {
+		return fmt.Errorf("invalid amount %d", amount)
+	}
+	if w.Get(from) < amount {
+		return fmt.Errorf("%w: need %d %s, have %d %s", ErrInsufficientFunds, amount, from, w.Get(from), from)
+	}
+	value := amount * from.Value()
+	if value%to.Value() != 0 {
+		return fmt.Errorf("cannot exchange %d %s into %s exactly", amount, from, to)
+	}
+	*w.coin(from) -= amount
+	*w.coin(to) += value / to.Value()
+	return nil
+}
+
+// Consolidate converts all coins into the fewest coins without electrum.
+func (w *Wallet) Consolidate() {
+	total := w.Total()
+	*w = Wallet{}
+	w.Earn(total)
+}
+
+// String returns a string representation of the Wallet, listing the
+// non-zero denominations from the largest to the smallest.
+func (w *Wallet) String() string {
+	var parts []string
+	for i := len(coins) - 1; i >= 0; i-- {
+		if n := w.Get(coins[i]); n != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, coins[i]))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 cp"
+	}
+	return strings.Join(parts, ", ")
+}
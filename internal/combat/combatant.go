@@ -0,0 +1,239 @@
+package combat
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/damage"
+	"github.com/jrecuero/DandD/internal/monster"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// DamageRoll represents extra damage of a given type dealt by an attack.
+// Damage is a dice notation expression.
+type DamageRoll struct {
+	Damage string
+	Type   damage.Type
+}
+
+// Attack represents an attack a combatant can make.
+// Bonus is added to the d20 attack roll and Damage is a dice notation
+// expression, including any damage modifier. Extra holds additional damage
+// of other types dealt on a hit.
+type Attack struct {
+	Name       string
+	Bonus      int
+	Damage     string
+	DamageType damage.Type
+	Extra      []DamageRoll
+}
+
+// String returns a string representation of the Attack.
+func (a Attack) String() string {
+	result := fmt.Sprintf("%s %+d (%s %s", a.Name, a.Bonus, a.Damage, a.DamageType)
+	for _, extra := range a.Extra {
+		result += fmt.Sprintf(" + %s %s", extra.Damage, extra.Type)
+	}
+	return result + ")"
+}
+
+// Rolls returns every damage roll of the attack, the main damage first.
+func (a Attack) Rolls() []DamageRoll {
+	return append([]DamageRoll{{Damage: a.Damage, Type: a.DamageType}}, a.Extra...)
+}
+
+// Combatant represents a creature taking part in an encounter.
+// Side groups combatants that fight together; the encounter ends when only
+// one side has combatants standing.
+// Saves holds the saving throw bonus of each attribute, Conditions the
+// conditions affecting the combatant and Defenses its damage resistances,
+// vulnerabilities and immunities.
+// When the combatant is created from a Character, damage is also applied to
+// the character hit points, and conditions and defenses are those of the
+// character.
+type Combatant struct {
+	Name            string
+	Side            string
+	ArmorClass      int
+	HitPoints       int
+	MaxHitPoints    int
+	InitiativeBonus int
+	Attacks         []Attack
+	Saves           character.AttributesMap
+	Conditions      character.Conditions
+	Defenses        damage.Defenses
+	Initiative      int
+	character       *character.Character
+}
+
+// NewCombatant creates and returns a new Combatant with full hit points.
+func NewCombatant(name string, side string, armorClass int, hitPoints int, initiativeBonus int, attacks ...Attack) *Combatant {
+	return &Combatant{
+		Name:            name,
+		Side:            side,
+		ArmorClass:      armorClass,
+		HitPoints:       hitPoints,
+		MaxHitPoints:    hitPoints,
+		InitiativeBonus: initiativeBonus,
+		Attacks:         attacks,
+		Saves:           character.NewAttributesMap(),
+	}
+}
+
+// FromCharacter creates a Combatant from a Character.
+// Armor class comes from the equipped armor and shield, the initiative bonus
+// from the dexterity modifier, and the attack from the weapon in the main
+// hand, or an unarmed strike when the hand is empty.
+func FromCharacter(c *character.Character, data *character.ItemData, side string) *Combatant {
+	if c.HitPoints.Max == 0 {
+		c.InitHitPoints()
+	}
+	combatant := NewCombatant(c.Name, side, c.ArmorClass(data), c.HitPoints.Current,
+		character.AbilityModifier(c.EffectiveAttribute(character.Dex)), WeaponAttack(c, data))
+	combatant.MaxHitPoints = c.HitPoints.Max
+	for attr := range combatant.Saves {
+		combatant.Saves.Set(attr, character.AbilityModifier(c.EffectiveAttribute(attr)))
+	}
+	combatant.character = c
+	return combatant
+}
+
+// FromMonster creates a Combatant from a Monster stat block.
+// Hit points are rolled with the given Roller, or set to the stat block
+// average when the Roller is nil. Only attack actions are used.
+func FromMonster(m *monster.Monster, side string, roller dice.Roller) *Combatant {
+	hp := m.AverageHitPoints()
+	if roller != nil {
+		hp = m.RollHitPoints(roller)
+	}
+	var attacks []Attack
+	for _, action := range m.Actions {
+		if !action.IsAttack() {
+			continue
+		}
+		attack := Attack{Name: action.Name, Bonus: action.AttackBonus, Damage: action.Damage, DamageType: damage.Type(action.DamageType)}
+		for _, extra := range action.Extra {
+			attack.Extra = append(attack.Extra, DamageRoll{Damage: extra.Damage, Type: damage.Type(extra.DamageType)})
+		}
+		attacks = append(attacks, attack)
+	}
+	combatant := NewCombatant(m.Name, side, m.ArmorClass, hp, m.Modifier(character.Dex), attacks...)
+	for attr := range combatant.Saves {
+		combatant.Saves.Set(attr, m.SaveBonus(attr))
+	}
+	combatant.Defenses = m.Defenses()
+	return combatant
+}
+
+// WeaponAttack returns the attack of a character with the weapon in its main hand.
+// Ranged weapons use the dexterity modifier, finesse weapons the better of
+// strength and dexterity, and any other weapon the strength modifier.
+// Characters are always proficient with their weapons, and weapons without
+// valid damage dice deal one point of damage plus the modifier.
+func WeaponAttack(c *character.Character, data *character.ItemData) Attack {
+	str := character.AbilityModifier(c.EffectiveAttribute(character.Str))
+	dex := character.AbilityModifier(c.EffectiveAttribute(character.Dex))
+	weapon, ok := data.GetItem(c.Inventory.EquippedIn(character.SlotMainHand))
+	if !ok || weapon.Type != character.ItemWeapon {
+		return Attack{Name: "Unarmed Strike", Bonus: c.ProficiencyBonus() + str, Damage: fmt.Sprint(max(1, 1+str)), DamageType: damage.Bludgeoning}
+	}
+	mod := str
+	if weapon.Ranged {
+		mod = dex
+	} else if weapon.Finesse {
+		mod = max(str, dex)
+	}
+	expr, err := dice.Parse(weapon.Damage)
+	if err != nil {
+		expr = dice.Expression{Modifier: 1, Multiplier: 1}
+	}
+	expr.Modifier += mod
+	return Attack{Name: weapon.Name, Bonus: c.ProficiencyBonus() + mod, Damage: expr.String(), DamageType: damage.Type(weapon.DamageType)}
+}
+
+// Character returns the Character the combatant was created from, or nil.
+func (c *Combatant) Character() *character.Character {
+	return c.character
+}
+
+// conditions returns the conditions affecting the combatant, which are the
+// character conditions for combatants created from a Character.
+func (c *Combatant) conditions() *character.Conditions {
+	if c.character != nil {
+		return &c.character.Conditions
+	}
+	return &c.Conditions
+}
+
+// AddCondition applies a condition to the combatant.
+// It returns an error if the condition is not valid.
+func (c *Combatant) AddCondition(ac character.ActiveCondition) error {
+	return c.conditions().Add(ac)
+}
+
+// RemoveCondition ends every instance of the given condition.
+// It returns the number of conditions removed.
+func (c *Combatant) RemoveCondition(condition character.Condition) int {
+	return c.conditions().Remove(condition)
+}
+
+// HasCondition returns true if the combatant is affected by the given condition.
+func (c *Combatant) HasCondition(condition character.Condition) bool {
+	return c.conditions().Has(condition)
+}
+
+// SavingThrow rolls a saving throw for the given attribute against a DC.
+// Characters roll through their own saving throw, while other combatants
+// add their save bonus, both subject to their conditions.
+func (c *Combatant) SavingThrow(attr character.Attribute, dc int, r dice.Roller) character.RollResult {
+	if c.character != nil {
+		return c.character.SavingThrow(attr, dc, r)
+	}
+	return c.conditions().Roll(character.SavingThrow, attr, c.Saves.Get(attr), dc, r)
+}
+
+// IsDown returns true if the combatant has no hit points left.
+func (c *Combatant) IsDown() bool {
+	return c.HitPoints <= 0
+}
+
+// TakeDamage reduces the hit points of the combatant, never below zero.
+// It returns the damage actually taken.
+func (c *Combatant) TakeDamage(amount int) int {
+	return c.TakeHit(amount, false)
+}
+
+// TakeHit reduces the hit points of the combatant like TakeDamage.
+// Combatants created from a Character follow the dying rules, where a
+// critical hit at zero hit points counts as two failed death saves.
+// It returns the damage actually taken.
+func (c *Combatant) TakeHit(amount int, critical bool) int {
+	if c.character != nil {
+		taken := c.character.TakeHit(amount, critical)
+		c.HitPoints = c.character.HitPoints.Current
+		return taken
+	}
+	taken := min(max(amount, 0), c.HitPoints)
+	c.HitPoints -= taken
+	return taken
+}
+
+// TakeTypedDamage applies damage with one or more typed components to the
+// combatant, after its defenses, like TakeHit.
+// It returns the Result with the damage after defenses and how much of it
+// was taken.
+func (c *Combatant) TakeTypedDamage(dmg damage.Damage, critical bool) damage.Result {
+	if c.character != nil {
+		result := c.character.TakeTypedDamage(dmg, critical)
+		c.HitPoints = c.character.HitPoints.Current
+		return result
+	}
+	result := c.Defenses.Apply(dmg)
+	result.Taken = c.TakeHit(result.Total, critical)
+	return result
+}
+
+// String returns a string representation of the Combatant.
+func (c *Combatant) String() string {
+	return fmt.Sprintf("%s [%s] AC %d HP %d/%d", c.Name, c.Side, c.ArmorClass, c.HitPoints, c.MaxHitPoints)
+}
@@ -0,0 +1,283 @@
+package combat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/damage"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// AttackResult represents the outcome of an attack roll.
+// Damage is the damage taken by the target hit points, and Result details
+// every damage component after the target defenses.
+// Roll is the natural d20 roll kept and Total the roll plus the attack bonus.
+// Mode tells whether the conditions of the attacker and target imposed
+// advantage or disadvantage, and Rolls holds every d20 rolled.
+type AttackResult struct {
+	Mode     character.RollMode
+	Rolls    []int
+	Roll     int
+	Total    int
+	Hit      bool
+	Critical bool
+	Damage   int
+	Result   damage.Result
+}
+
+// Encounter runs a fight between combatants.
+// Combatants holds the combatants still standing in initiative order, while
+// Downed holds the ones removed after dropping to zero hit points.
+// Every step of the fight is recorded in Log.
+type Encounter struct {
+	Combatants []*Combatant
+	Downed     []*Combatant
+	Round      int
+	Log        Log
+	roller     dice.Roller
+}
+
+// NewEncounter creates and returns a new Encounter with the given combatants.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+func NewEncounter(roller dice.Roller, combatants ...*Combatant) *Encounter {
+	if roller == nil {
+		roller = dice.DefaultRoller
+	}
+	return &Encounter{Combatants: combatants, roller: roller}
+}
+
+// record appends an event to the encounter log.
+func (e *Encounter) record(event Event) {
+	event.Round = e.Round
+	e.Log = append(e.Log, event)
+}
+
+// RollInitiative rolls a d20 plus the initiative bonus for every combatant
+// and sorts them in initiative order. Ties are broken by the higher
+// initiative bonus and then by the original order.
+func (e *Encounter) RollInitiative() {
+	for _, c := range e.Combatants {
+		roll := e.roller.RollDie(20)
+		c.Initiative = roll + c.InitiativeBonus
+		e.record(Event{Type: EventInitiative, Actor: c.Name, Roll: roll, Total: c.Initiative,
+			Message: fmt.Sprintf("%s rolls initiative: %d %+d = %d", c.Name, roll, c.InitiativeBonus, c.Initiative)})
+	}
+	sort.SliceStable(e.Combatants, func(i, j int) bool {
+		a, b := e.Combatants[i], e.Combatants[j]
+		if a.Initiative != b.Initiative {
+			return a.Initiative > b.Initiative
+		}
+		return a.InitiativeBonus > b.InitiativeBonus
+	})
+}
+
+// Attack resolves an attack from one combatant against another.
+// The conditions of the attacker and the target may impose advantage or
+// disadvantage on the attack roll. A natural 20 is a critical hit that doubles the damage dice, a natural 1
+// always misses, and any other roll hits when the total meets the target
+// armor class. Damage is applied to the target, which is removed from the
+// encounter when it drops to zero hit points.
+// It returns the AttackResult.
+func (e *Encounter) Attack(attacker *Combatant, target *Combatant, attack Attack) AttackResult {
+	mode := character.CombineRollModes(
+		attacker.conditions().RollMode(character.AttackRoll, character.Str),
+		target.conditions().RollMode(character.Attacked, character.Str))
+	roll, rolls := character.RollD20(e.roller, mode)
+	result := AttackResult{Mode: mode, Rolls: rolls, Roll: roll, Total: roll + attack.Bonus}
+	result.Critical = roll == 20
+	result.Hit = result.Critical || (roll != 1 && result.Total >= target.ArmorClass)
+	event := Event{Actor: attacker.Name, Target: target.Name, Roll: roll, Total: result.Total}
+	switch {
+	case result.Critical:
+		event.Type = EventCritical
+		event.Message = fmt.Sprintf("%s attacks %s with %s: natural 20, critical hit!", attacker.Name, target.Name, attack.Name)
+	case result.Hit:
+		event.Type = EventHit
+		event.Message = fmt.Sprintf("%s attacks %s with %s: %d vs AC %d, hit", attacker.Name, target.Name, attack.Name, result.Total, target.ArmorClass)
+	default:
+		event.Type = EventMiss
+		event.Message = fmt.Sprintf("%s attacks %s with %s: %d vs AC %d, miss", attacker.Name, target.Name, attack.Name, result.Total, target.ArmorClass)
+	}
+	if mode != character.Normal {
+		event.Message += fmt.Sprintf(" (%s %v)", mode, rolls)
+	}
+	e.record(event)
+	if !result.Hit {
+		return result
+	}
+	result.Result = target.TakeTypedDamage(e.rollDamage(attack, result.Critical), result.Critical)
+	result.Damage = result.Result.Taken
+	e.record(Event{Type: EventDamage, Actor: attacker.Name, Target: target.Name, Value: result.Damage,
+		Message: fmt.Sprintf("%s takes %s damage (%d/%d HP)", target.Name, result.Result, target.HitPoints, target.MaxHitPoints)})
+	if target.IsDown() {
+		e.remove(target)
+	}
+	return result
+}
+
+// rollDamage rolls every damage component of an attack. Critical hits
+// double the dice of every component.
+func (e *Encounter) rollDamage(attack Attack, critical bool) damage.Damage {
+	var dmg damage.Damage
+	for _, roll := range attack.Rolls() {
+		expr, err := dice.Parse(roll.Damage)
+		if err != nil {
+			expr = dice.Expression{Modifier: 1, Multiplier: 1}
+		}
+		var amount int
+		if critical {
+			amount = expr.RollCritical(e.roller)
+		} else {
+			amount = expr.RollWith(e.roller)
+		}
+		dmg = append(dmg, damage.Component{Amount: max(amount, 0), Type: roll.Type})
+	}
+	return dmg
+}
+
+// remove takes a downed combatant out of the initiative order.
+func (e *Encounter) remove(target *Combatant) {
+	for i, c := range e.Combatants {
+		if c == target {
+			e.Combatants = append(e.Combatants[:i], e.Combatants[i+1:]...)
+			e.Downed = append(e.Downed, target)
+			e.record(Event{Type: EventDown, Actor: target.Name, Message: fmt.Sprintf("%s is down", target.Name)})
+			return
+		}
+	}
+}
+
+// ChooseTarget returns the standing enemy with the fewest hit points.
+// It returns nil if there are no enemies left.
+func (e *Encounter) ChooseTarget(attacker *Combatant) *Combatant {
+	var target *Combatant
+	for _, c := range e.Combatants {
+		if c.Side == attacker.Side || c.IsDown() {
+			continue
+		}
+		if target == nil || c.HitPoints < target.HitPoints {
+			target = c
+		}
+	}
+	return target
+}
+
+// ApplyCondition applies a condition to a combatant and records it.
+// It returns an error if the condition is not valid.
+func (e *Encounter) ApplyCondition(target *Combatant, ac character.ActiveCondition) error {
+	if err := target.AddCondition(ac); err != nil {
+		return err
+	}
+	e.record(Event{Type: EventCondition, Actor: target.Name,
+		Message: fmt.Sprintf("%s is %s", target.Name, ac)})
+	return nil
+}
+
+// TakeTurn runs the turn of a combatant: it attacks the chosen target with
+// its first attack. Combatants without attacks do nothing, and incapacitated
+// combatants lose their action.
+// At the end of the turn the combatant repeats the saving throws against its
+// conditions and their durations advance.
+func (e *Encounter) TakeTurn(c *Combatant) {
+	e.record(Event{Type: EventTurnStart, Actor: c.Name, Message: fmt.Sprintf("%s's turn", c.Name)})
+	if c.conditions().IsIncapacitated() {
+		e.record(Event{Type: EventSkipTurn, Actor: c.Name, Message: fmt.Sprintf("%s is incapacitated", c.Name)})
+	} else if target := e.ChooseTarget(c); target != nil && len(c.Attacks) > 0 {
+		e.Attack(c, target, c.Attacks[0])
+	}
+	changes := c.conditions().EndTurn(func(attr character.Attribute, dc int) character.RollResult {
+		return c.SavingThrow(attr, dc, e.roller)
+	})
+	for _, change := range changes {
+		event := Event{Type: EventRecover, Actor: c.Name, Message: fmt.Sprintf("%s: %s", c.Name, change)}
+		if change.Save != nil {
+			event.Roll, event.Total = change.Save.Roll, change.Save.Total
+		}
+		e.record(event)
+	}
+}
+
+// IsOver returns true when combatants of at most one side are standing.
+func (e *Encounter) IsOver() bool {
+	_, ok := e.Winner()
+	return ok || len(e.Combatants) == 0
+}
+
+// Winner returns the side of the standing combatants.
+// It returns the side and a boolean indicating whether a single side is left.
+func (e *Encounter) Winner() (string, bool) {
+	if len(e.Combatants) == 0 {
+		return "", false
+	}
+	side := e.Combatants[0].Side
+	for _, c := range e.Combatants[1:] {
+		if c.Side != side {
+			return "", false
+		}
+	}
+	return side, true
+}
+
+// RunRound runs a full round, giving every standing combatant a turn in
+// initiative order. Combatants downed during the round lose their turn.
+// It returns false if the encounter is over.
+func (e *Encounter) RunRound() bool {
+	if e.IsOver() {
+		return false
+	}
+	e.Round++
+	e.record(Event{Type: EventRoundStart, Message: fmt.Sprintf("Round %d begins", e.Round)})
+	order := append([]*Combatant(nil), e.Combatants...)
+	for _, c := range order {
+		if c.IsDown() {
+			continue
+		}
+		e.TakeTurn(c)
+		if e.IsOver() {
+			break
+		}
+	}
+	e.RollDeathSaves()
+	return !e.IsOver()
+}
+
+// RollDeathSaves rolls a death save for every downed combatant created from
+// a dying Character. Combatants regaining hit points return to the end of
+// the initiative order.
+func (e *Encounter) RollDeathSaves() {
+	downed := e.Downed[:0]
+	for _, c := range e.Downed {
+		ch := c.Character()
+		if ch == nil || !ch.IsDying() {
+			downed = append(downed, c)
+			continue
+		}
+		result, _ := ch.RollDeathSave(e.roller)
+		c.HitPoints = ch.HitPoints.Current
+		e.record(Event{Type: EventDeathSave, Actor: c.Name, Roll: result.Roll,
+			Message: fmt.Sprintf("%s %s", c.Name, result)})
+		if c.IsDown() {
+			downed = append(downed, c)
+			continue
+		}
+		e.Combatants = append(e.Combatants, c)
+	}
+	e.Downed = downed
+}
+
+// Run rolls initiative and runs rounds until a single side is standing or
+// maxRounds is reached.
+// It returns the winning side, or an empty string if there is no winner.
+func (e *Encounter) Run(maxRounds int) string {
+	e.RollInitiative()
+	for e.Round < maxRounds && e.RunRound() {
+	}
+	winner, ok := e.Winner()
+	message := "The encounter ends without a winner"
+	if ok {
+		message = fmt.Sprintf("The encounter ends: %s wins", winner)
+	}
+	e.record(Event{Type: EventEnd, Actor: winner, Message: message})
+	return winner
+}
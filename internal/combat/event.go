@@ -0,0 +1,68 @@
+package combat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventType identifies what happened in a combat event.
+type EventType string
+
+// Enumeration of combat event types.
+const (
+	EventInitiative EventType = "initiative"
+	EventRoundStart EventType = "round_start"
+	EventTurnStart  EventType = "turn_start"
+	EventHit        EventType = "hit"
+	EventCritical   EventType = "critical"
+	EventMiss       EventType = "miss"
+	EventDamage     EventType = "damage"
+	EventDown       EventType = "down"
+	EventCondition  EventType = "condition"
+	EventRecover    EventType = "recover"
+	EventSkipTurn   EventType = "skip_turn"
+	EventDeathSave  EventType = "death_save"
+	EventEnd        EventType = "end"
+)
+
+// Event represents a single entry of the combat log.
+// Roll is the natural die roll and Total the roll with bonuses, when the
+// event involves a roll. Value holds the amount of damage for damage events.
+type Event struct {
+	Round   int       `json:"round"`
+	Type    EventType `json:"type"`
+	Actor   string    `json:"actor,omitempty"`
+	Target  string    `json:"target,omitempty"`
+	Roll    int       `json:"roll,omitempty"`
+	Total   int       `json:"total,omitempty"`
+	Value   int       `json:"value,omitempty"`
+	Message string    `json:"message"`
+}
+
+// String returns a string representation of the Event.
+func (e Event) String() string {
+	return fmt.Sprintf("[round %d] %s", e.Round, e.Message)
+}
+
+// Log is the ordered list of events of an encounter.
+type Log []Event
+
+// Filter returns the events of the given type.
+func (l Log) Filter(eventType EventType) Log {
+	var result Log
+	for _, event := range l {
+		if event.Type == eventType {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// String returns the log with one event per line.
+func (l Log) String() string {
+	lines := make([]string, len(l))
+	for i, event := range l {
+		lines[i] = event.String()
+	}
+	return strings.Join(lines, "\n")
+}
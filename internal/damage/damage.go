@@ -0,0 +1,209 @@
+package damage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Type represents a damage type, such as slashing or fire.
+type Type string
+
+// Enumeration of damage types.
+const (
+	Acid        Type = "acid"
+	Bludgeoning Type = "bludgeoning"
+	Cold        Type = "cold"
+	Fire        Type = "fire"
+	Force       Type = "force"
+	Lightning   Type = "lightning"
+	Necrotic    Type = "necrotic"
+	Piercing    Type = "piercing"
+	Poison      Type = "poison"
+	Psychic     Type = "psychic"
+	Radiant     Type = "radiant"
+	Slashing    Type = "slashing"
+	Thunder     Type = "thunder"
+)
+
+// types lists every damage type.
+var types = []Type{Acid, Bludgeoning, Cold, Fire, Force, Lightning, Necrotic, Piercing, Poison, Psychic, Radiant, Slashing, Thunder}
+
+// Types returns every damage type.
+func Types() []Type {
+	return slices.Clone(types)
+}
+
+// ParseType returns the damage Type with the given name, ignoring case.
+// It returns an error if the name does not correspond to any Type.
+func ParseType(name string) (Type, error) {
+	t := Type(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(types, t) {
+		return "", fmt.Errorf("unknown damage type %q", name)
+	}
+	return t, nil
+}
+
+// Component represents an amount of damage of a single type.
+type Component struct {
+	Amount int  `json:"amount"`
+	Type   Type `json:"type"`
+}
+
+// String returns a string representation of the Component.
+func (c Component) String() string {
+	return fmt.Sprintf("%d %s", c.Amount, c.Type)
+}
+
+// Damage is the damage dealt by a single attack or effect, which can have
+// several components of different types, like a flame tongue longsword
+// dealing slashing and fire damage.
+type Damage []Component
+
+// Of returns a Damage with a single component.
+func Of(amount int, t Type) Damage {
+	return Damage{{Amount: amount, Type: t}}
+}
+
+// Total returns the sum of every component.
+func (d Damage) Total() int {
+	total := 0
+	for _, c := range d {
+		total += c.Amount
+	}
+	return total
+}
+
+// String returns the components joined by a plus sign, as in "7 slashing + 3 fire".
+func (d Damage) String() string {
+	parts := make([]string, len(d))
+	for i, c := range d {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " + ")
+}
+
+// Defenses holds the damage types a creature resists, is vulnerable to or
+// is immune to.
+// It includes JSON struct tags for serialization.
+type Defenses struct {
+	Resistances     []Type `json:"resistances,omitempty"`
+	Vulnerabilities []Type `json:"vulnerabilities,omitempty"`
+	Immunities      []Type `json:"immunities,omitempty"`
+}
+
+// IsZero returns true if the defenses do not alter any damage.
+func (d Defenses) IsZero() bool {
+	return len(d.Resistances) == 0 && len(d.Vulnerabilities) == 0 && len(d.Immunities) == 0
+}
+
+// Merge returns the defenses of both, such as those of a character and
+// those granted by a magic item.
+func (d Defenses) Merge(other Defenses) Defenses {
+	return Defenses{
+		Resistances:     append(slices.Clone(d.Resistances), other.Resistances...),
+		Vulnerabilities: append(slices.Clone(d.Vulnerabilities), other.Vulnerabilities...),
+		Immunities:      append(slices.Clone(d.Immunities), other.Immunities...),
+	}
+}
+
+// Validate checks that every damage type is known.
+func (d Defenses) Validate() error {
+	for _, list := range [][]Type{d.Resistances, d.Vulnerabilities, d.Immunities} {
+		for _, t := range list {
+			if _, err := ParseType(string(t)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Applied describes how defenses altered a damage component.
+// Amount is the damage before defenses and Final the damage after them.
+type Applied struct {
+	Type       Type `json:"type"`
+	Amount     int  `json:"amount"`
+	Final      int  `json:"final"`
+	Resisted   bool `json:"resisted,omitempty"`
+	Vulnerable bool `json:"vulnerable,omitempty"`
+	Immune     bool `json:"immune,omitempty"`
+}
+
+// String returns a string representation of the Applied component.
+func (a Applied) String() string {
+	switch {
+	case a.Immune:
+		return fmt.Sprintf("0 %s (immune)", a.Type)
+	case a.Resisted && a.Vulnerable:
+		return fmt.Sprintf("%d %s (resisted, vulnerable)", a.Final, a.Type)
+	case a.Resisted:
+		return fmt.Sprintf("%d %s (resisted)", a.Final, a.Type)
+	case a.Vulnerable:
+		return fmt.Sprintf("%d %s (vulnerable)", a.Final, a.Type)
+	}
+	return fmt.Sprintf("%d %s", a.Final, a.Type)
+}
+
+// Result represents damage after defenses and temporary hit points.
+// Total is the damage after defenses, of which TempAbsorbed was removed
+// from temporary hit points and Taken from hit points.
+type Result struct {
+	Components   []Applied `json:"components"`
+	Total        int       `json:"total"`
+	TempAbsorbed int       `json:"temp_absorbed,omitempty"`
+	Taken        int       `json:"taken"`
+}
+
+// String returns a string representation of the Result.
+func (r Result) String() string {
+	parts := make([]string, len(r.Components))
+	for i, c := range r.Components {
+		parts[i] = c.String()
+	}
+	result := strings.Join(parts, " + ")
+	if len(r.Components) > 1 {
+		result += fmt.Sprintf(" = %d", r.Total)
+	}
+	if r.TempAbsorbed > 0 {
+		result += fmt.Sprintf(", %d absorbed by temporary hit points", r.TempAbsorbed)
+	}
+	return result
+}
+
+// Apply computes the damage dealt after the defenses.
+// Each component is handled on its own: immunity negates it, resistance
+// halves it rounding down and vulnerability then doubles it. Several
+// resistances or vulnerabilities to the same type count only once.
+// Negative amounts count as zero.
+func (d Defenses) Apply(dmg Damage) Result {
+	var result Result
+	for _, c := range dmg {
+		applied := Applied{Type: c.Type, Amount: max(c.Amount, 0)}
+		applied.Final = applied.Amount
+		switch {
+		case slices.Contains(d.Immunities, c.Type):
+			applied.Immune = true
+			applied.Final = 0
+		default:
+			if slices.Contains(d.Resistances, c.Type) {
+				applied.Resisted = true
+				applied.Final /= 2
+			}
+			if slices.Contains(d.Vulnerabilities, c.Type) {
+				applied.Vulnerable = true
+				applied.Final *= 2
+			}
+		}
+		result.Components = append(result.Components, applied)
+		result.Total += applied.Final
+	}
+	return result
+}
+
+// AbsorbTemp removes damage from temporary hit points before hit points.
+// It returns the temporary hit points left and the damage remaining.
+func AbsorbTemp(amount int, temp int) (int, int) {
+	absorbed := min(max(amount, 0), max(temp, 0))
+	return temp - absorbed, amount - absorbed
+}
@@ -0,0 +1,241 @@
+// Package dataformat reads and writes content files in JSON, YAML or TOML,
+// chosen by file extension.
+// Every format is converted to and from JSON, so the JSON struct tags of the
+// content types remain their only schema and every format produces the same
+// values.
+package dataformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format represents the format of a content file.
+type Format string
+
+// Enumeration of content file formats.
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+	TOML Format = "toml"
+)
+
+// Extensions lists the file extensions of the content file formats, in the
+// order files are looked up when the same content exists in several formats.
+var Extensions = []string{".json", ".toml", ".yaml", ".yml"}
+
+// FromPath returns the format of a file from its extension.
+// It returns an error if the extension is not a known format.
+func FromPath(path string) (Format, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	case ".toml":
+		return TOML, nil
+	default:
+		return "", fmt.Errorf("unknown content file format %q", ext)
+	}
+}
+
+// ReadFile reads a content file in the format of its extension.
+// It returns the content converted to JSON, or an error if the file can not
+// be read or converted.
+func ReadFile(path string) ([]byte, error) {
+	format, err := FromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s file: %w", format, err)
+	}
+	return ToJSON(content, format)
+}
+
+// WriteFile writes JSON content to a file in the format of its extension.
+// It returns an error if the content can not be converted or written.
+func WriteFile(path string, content []byte) error {
+	format, err := FromPath(path)
+	if err != nil {
+		return err
+	}
+	data, err := FromJSON(content, format)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s file: %w", format, err)
+	}
+	return nil
+}
+
+// ToJSON converts content in the given format to JSON.
+// YAML keys keep their order, while TOML keys are sorted.
+func ToJSON(content []byte, format Format) ([]byte, error) {
+	switch format {
+	case JSON:
+		return content, nil
+	case YAML:
+		var doc yaml.Node
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal YAML data: %w", err)
+		}
+		var b bytes.Buffer
+		if len(doc.Content) > 0 {
+			if err := writeNodeJSON(&b, doc.Content[0]); err != nil {
+				return nil, err
+			}
+		}
+		return b.Bytes(), nil
+	case TOML:
+		var tree map[string]any
+		if err := toml.Unmarshal(content, &tree); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal TOML data: %w", err)
+		}
+		data, err := json.Marshal(tree)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON data: %w", err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unknown content file format %q", format)
+}
+
+// FromJSON converts JSON content to the given format, indented.
+// YAML keys keep their order, while TOML keys are sorted and null values,
+// which TOML can not represent, are left out.
+func FromJSON(content []byte, format Format) ([]byte, error) {
+	switch format {
+	case JSON:
+		var b bytes.Buffer
+		if err := json.Indent(&b, content, "", "  "); err != nil {
+			return nil, fmt.Errorf("failed to indent JSON data: %w", err)
+		}
+		b.WriteByte('\n')
+		return b.Bytes(), nil
+	case YAML:
+		// JSON is valid YAML, so decoding it as a node keeps the key order.
+		var doc yaml.Node
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		blockStyle(&doc)
+		var b bytes.Buffer
+		encoder := yaml.NewEncoder(&b)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to marshal YAML data: %w", err)
+		}
+		return b.Bytes(), nil
+	case TOML:
+		var tree map[string]any
+		if err := json.Unmarshal(content, &tree); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		var b bytes.Buffer
+		if err := toml.NewEncoder(&b).Encode(withoutNulls(tree)); err != nil {
+			return nil, fmt.Errorf("failed to marshal TOML data: %w", err)
+		}
+		return b.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unknown content file format %q", format)
+}
+
+// writeNodeJSON writes a YAML node as JSON, keeping the order of its keys.
+func writeNodeJSON(b *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeNodeJSON(b, node.Alias)
+	case yaml.MappingNode:
+		b.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			b.Write(key)
+			b.WriteByte(':')
+			if err := writeNodeJSON(b, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+	case yaml.SequenceNode:
+		b.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeNodeJSON(b, item); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+	default:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return fmt.Errorf("failed to decode YAML value at line %d: %w", node.Line, err)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML value at line %d: %w", node.Line, err)
+		}
+		b.Write(data)
+	}
+	return nil
+}
+
+// blockStyle clears the flow style of the node and its children, so they
+// are written as indented YAML blocks. Empty collections keep the flow
+// style, as "{}" and "[]".
+func blockStyle(node *yaml.Node) {
+	if len(node.Content) > 0 {
+		node.Style &^= yaml.FlowStyle
+	}
+	if node.Kind == yaml.ScalarNode {
+		node.Style &^= yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// withoutNulls returns the value with the null map entries left out, the
+// null list items replaced by empty maps and the whole numbers decoded from
+// JSON as float64 turned back into integers.
+func withoutNulls(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			if item != nil {
+				result[key] = withoutNulls(item)
+			}
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			if item == nil {
+				item = map[string]any{}
+			}
+			result[i] = withoutNulls(item)
+		}
+		return result
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+	}
+	return value
+}
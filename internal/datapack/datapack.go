@@ -0,0 +1,172 @@
+// Package datapack locates the data files, such as the character creation
+// questions, items and spells, in a search path of data packs. Each file is
+// read from the first pack of the path that has it, so a pack can override
+// only some of the default files, or read from every pack that has it to be
+// merged. Data files can be written in any content file format and are
+// read as JSON.
+package datapack
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/jrecuero/DandD/assets"
+	"github.com/jrecuero/DandD/internal/dataformat"
+)
+
+// Pack represents a directory of data files.
+// Name tells where the pack comes from, as in "env" or "embedded", and
+// Location is the directory it is read from.
+type Pack struct {
+	Name     string
+	Location string
+	FS       fs.FS
+}
+
+// Dir returns the pack of data files in the given directory.
+func Dir(name string, dir string) Pack {
+	return Pack{Name: name, Location: dir, FS: os.DirFS(dir)}
+}
+
+// Embedded returns the default pack embedded in the binary.
+func Embedded() Pack {
+	data, err := fs.Sub(assets.Data, "data")
+	if err != nil {
+		panic(err)
+	}
+	return Pack{Name: "embedded", Location: "built-in", FS: data}
+}
+
+// String returns the pack name and location, as "env (/path/to/data)".
+func (p Pack) String() string {
+	return fmt.Sprintf("%s (%s)", p.Name, p.Location)
+}
+
+// Available returns true if the pack directory exists.
+func (p Pack) Available() bool {
+	info, err := fs.Stat(p.FS, ".")
+	return err == nil && info.IsDir()
+}
+
+// Files returns the paths of the content files in the given directory of
+// the pack, "." for the top directory, sorted.
+// It returns nil if the pack has no such directory.
+func (p Pack) Files(dir string) []string {
+	entries, err := fs.ReadDir(p.FS, dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if _, err := dataformat.FromPath(entry.Name()); err == nil && !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, path.Join(dir, entry.Name()))
+		}
+	}
+	return names
+}
+
+// ReadFile returns the content of the named data file in the pack, and the
+// name of the file it was read from. The file can be in any content file
+// format, as in "items.yaml" for "items.json", and is converted to JSON.
+// It returns an error wrapping fs.ErrNotExist if the pack has no such file.
+func (p Pack) ReadFile(name string) ([]byte, string, error) {
+	stem := strings.TrimSuffix(name, path.Ext(name))
+	for _, ext := range dataformat.Extensions {
+		content, err := fs.ReadFile(p.FS, stem+ext)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, stem + ext, fmt.Errorf("failed to read %s from %s: %w", stem+ext, p, err)
+		}
+		format, _ := dataformat.FromPath(ext)
+		if content, err = dataformat.ToJSON(content, format); err != nil {
+			return nil, stem + ext, fmt.Errorf("%s from %s: %w", stem+ext, p, err)
+		}
+		return content, stem + ext, nil
+	}
+	return nil, "", fmt.Errorf("data file %s not found in %s: %w", name, p, fs.ErrNotExist)
+}
+
+// SearchPath is the ordered list of packs data files are searched in.
+type SearchPath []Pack
+
+// ReadFile returns the content of the named data file, converted to JSON,
+// from the first pack that has it, and the pack it was read from.
+// It returns an error wrapping fs.ErrNotExist if no pack has the file.
+func (sp SearchPath) ReadFile(name string) ([]byte, Pack, error) {
+	for _, p := range sp {
+		content, _, err := p.ReadFile(name)
+		if err == nil {
+			return content, p, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, p, err
+		}
+	}
+	return nil, Pack{}, fmt.Errorf("data file %s not found: %w", name, fs.ErrNotExist)
+}
+
+// PackFile represents the content of a data file in a pack, converted to
+// JSON, and the name of the file it was read from.
+type PackFile struct {
+	Pack    Pack
+	Name    string
+	Content []byte
+}
+
+// ReadAll returns the content of the named data file from every pack that
+// has it, in search path order, for files merged from several packs.
+// It returns an error wrapping fs.ErrNotExist if no pack has the file.
+func (sp SearchPath) ReadAll(name string) ([]PackFile, error) {
+	var files []PackFile
+	for _, p := range sp {
+		content, fname, err := p.ReadFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, PackFile{Pack: p, Name: fname, Content: content})
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("data file %s not found: %w", name, fs.ErrNotExist)
+	}
+	return files, nil
+}
+
+// File represents a data file found in the search path, with the pack it
+// is read from and the later packs whose copy it overrides. Copies in other
+// content file formats, as "items.yaml" and "items.json", are the same file.
+type File struct {
+	Name       string
+	Pack       Pack
+	Overridden []Pack
+}
+
+// Files returns every data file found in the given directory of the search
+// path, "." for the top directory, sorted by name.
+func (sp SearchPath) Files(dir string) []File {
+	var files []File
+	stem := func(name string) string { return strings.TrimSuffix(name, path.Ext(name)) }
+	for _, p := range sp {
+		for _, name := range p.Files(dir) {
+			i := slices.IndexFunc(files, func(f File) bool { return stem(f.Name) == stem(name) })
+			switch {
+			case i < 0:
+				files = append(files, File{Name: name, Pack: p})
+			case files[i].Pack.Name == p.Name && files[i].Pack.Location == p.Location:
+			default:
+				files[i].Overridden = append(files[i].Overridden, p)
+			}
+		}
+	}
+	slices.SortFunc(files, func(a, b File) int { return strings.Compare(a.Name, b.Name) })
+	return files
+}
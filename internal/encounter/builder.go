@@ -0,0 +1,126 @@
+package encounter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/monster"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Rating represents the difficulty rating of an encounter against a party.
+// BaseXP is the sum of the monster XP, awarded to the party when they win,
+// while AdjustedXP applies the multiplier for the number of monsters and is
+// compared against the party thresholds.
+type Rating struct {
+	Monsters   int
+	BaseXP     int
+	Multiplier float64
+	AdjustedXP int
+	Thresholds Thresholds
+	Difficulty Difficulty
+}
+
+// String returns a string representation of the Rating.
+func (r Rating) String() string {
+	return fmt.Sprintf("%s: %d monsters, %d XP x%.1f = %d adjusted XP (%s)",
+		r.Difficulty, r.Monsters, r.BaseXP, r.Multiplier, r.AdjustedXP, r.Thresholds)
+}
+
+// Rate returns the difficulty rating of the given monsters against the party.
+func Rate(party *Party, monsters []*monster.Monster) Rating {
+	rating := Rating{Monsters: len(monsters), Thresholds: party.Thresholds()}
+	for _, m := range monsters {
+		rating.BaseXP += m.XP()
+	}
+	rating.Multiplier = Multiplier(len(monsters), party.Size())
+	rating.AdjustedXP = int(float64(rating.BaseXP) * rating.Multiplier)
+	rating.Difficulty = rating.Thresholds.Rate(rating.AdjustedXP)
+	return rating
+}
+
+// Encounter represents a group of monsters assembled for a party.
+type Encounter struct {
+	Monsters []*monster.Monster
+	Rating   Rating
+}
+
+// String returns a string representation of the Encounter, grouping
+// monsters with the same name, as in "3x Goblin, 1x Wolf".
+func (e *Encounter) String() string {
+	counts := make(map[string]int)
+	for _, m := range e.Monsters {
+		counts[m.Name]++
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%dx %s", counts[name], name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Options configures the random assembly of an encounter.
+// Environment filters monsters by environment, or allows any monster when
+// empty. MinCR and MaxCR bound the monster challenge ratings, with a zero
+// MaxCR meaning no upper bound. MaxMonsters limits the number of monsters,
+// defaulting to DefaultMaxMonsters when zero.
+type Options struct {
+	Difficulty  Difficulty
+	Environment string
+	MinCR       float64
+	MaxCR       float64
+	MaxMonsters int
+}
+
+// DefaultMaxMonsters is the maximum number of monsters of a random
+// encounter when Options.MaxMonsters is not set.
+const DefaultMaxMonsters = 8
+
+// Build randomly assembles an encounter from the bestiary for the party.
+// The XP budget is the party threshold for the requested difficulty.
+// Monsters are added one at a time, picked at random among the candidates
+// that keep the adjusted XP within the budget, until none fits or the
+// maximum number of monsters is reached.
+// Dice are rolled with the given Roller, or the DefaultRoller if it is nil.
+// It returns an error if no monster fits within the budget.
+func Build(bestiary *monster.Bestiary, party *Party, opts Options, roller dice.Roller) (*Encounter, error) {
+	if roller == nil {
+		roller = dice.DefaultRoller
+	}
+	if party.Size() == 0 {
+		return nil, fmt.Errorf("party has no characters")
+	}
+	maxCR := opts.MaxCR
+	if maxCR <= 0 {
+		maxCR = 30
+	}
+	maxMonsters := opts.MaxMonsters
+	if maxMonsters <= 0 {
+		maxMonsters = DefaultMaxMonsters
+	}
+	budget := party.Thresholds().Get(opts.Difficulty)
+	candidates := bestiary.Filter(opts.Environment, opts.MinCR, maxCR)
+	var monsters []*monster.Monster
+	for len(monsters) < maxMonsters {
+		var fits []*monster.Monster
+		for _, m := range candidates {
+			if Rate(party, append(monsters[:len(monsters):len(monsters)], m)).AdjustedXP <= budget {
+				fits = append(fits, m)
+			}
+		}
+		if len(fits) == 0 {
+			break
+		}
+		monsters = append(monsters, fits[roller.RollDie(len(fits))-1])
+	}
+	if len(monsters) == 0 {
+		return nil, fmt.Errorf("no monster fits a %s budget of %d XP", opts.Difficulty, budget)
+	}
+	return &Encounter{Monsters: monsters, Rating: Rate(party, monsters)}, nil
+}
@@ -0,0 +1,163 @@
+package encounter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Difficulty represents how dangerous an encounter is for a party.
+type Difficulty int
+
+// Enumeration of encounter difficulties, from harmless to deadly.
+// Trivial encounters do not reach the easy threshold of the party.
+const (
+	Trivial Difficulty = iota
+	Easy
+	Medium
+	Hard
+	Deadly
+)
+
+// difficultyNames maps each Difficulty to its name.
+var difficultyNames = map[Difficulty]string{
+	Trivial: "trivial",
+	Easy:    "easy",
+	Medium:  "medium",
+	Hard:    "hard",
+	Deadly:  "deadly",
+}
+
+// String returns the name of the difficulty.
+func (d Difficulty) String() string {
+	if name, ok := difficultyNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("Difficulty(%d)", int(d))
+}
+
+// ParseDifficulty returns the Difficulty with the given name, ignoring case.
+// It returns an error if the name does not correspond to any Difficulty.
+func ParseDifficulty(name string) (Difficulty, error) {
+	for d, n := range difficultyNames {
+		if strings.EqualFold(n, strings.TrimSpace(name)) {
+			return d, nil
+		}
+	}
+	return Trivial, fmt.Errorf("unknown difficulty %q", name)
+}
+
+// Thresholds holds the XP thresholds of each difficulty.
+// An encounter reaches a difficulty when its adjusted XP meets the threshold.
+type Thresholds struct {
+	Easy   int `json:"easy"`
+	Medium int `json:"medium"`
+	Hard   int `json:"hard"`
+	Deadly int `json:"deadly"`
+}
+
+// levelThresholds holds the XP thresholds of a single character by level,
+// from level 1 to 20.
+var levelThresholds = [20]Thresholds{
+	{25, 50, 75, 100},
+	{50, 100, 150, 200},
+	{75, 150, 225, 400},
+	{125, 250, 375, 500},
+	{250, 500, 750, 1100},
+	{300, 600, 900, 1400},
+	{350, 750, 1100, 1700},
+	{450, 900, 1400, 2100},
+	{550, 1100, 1600, 2400},
+	{600, 1200, 1900, 2800},
+	{800, 1600, 2400, 3600},
+	{1000, 2000, 3000, 4500},
+	{1100, 2200, 3400, 5100},
+	{1250, 2500, 3800, 5700},
+	{1400, 2800, 4300, 6400},
+	{1600, 3200, 4800, 7200},
+	{2000, 3900, 5900, 8800},
+	{2100, 4200, 6300, 9500},
+	{2400, 4900, 7300, 10900},
+	{2800, 5700, 8500, 12700},
+}
+
+// LevelThresholds returns the XP thresholds of a single character of the
+// given level. Levels are clamped between 1 and 20.
+func LevelThresholds(level int) Thresholds {
+	return levelThresholds[min(max(level, 1), 20)-1]
+}
+
+// Add returns the sum of two thresholds.
+func (t Thresholds) Add(other Thresholds) Thresholds {
+	return Thresholds{
+		Easy:   t.Easy + other.Easy,
+		Medium: t.Medium + other.Medium,
+		Hard:   t.Hard + other.Hard,
+		Deadly: t.Deadly + other.Deadly,
+	}
+}
+
+// Get returns the threshold of the given difficulty.
+// Trivial encounters have no threshold and return zero.
+func (t Thresholds) Get(d Difficulty) int {
+	switch d {
+	case Easy:
+		return t.Easy
+	case Medium:
+		return t.Medium
+	case Hard:
+		return t.Hard
+	case Deadly:
+		return t.Deadly
+	}
+	return 0
+}
+
+// Rate returns the difficulty reached by the given adjusted XP.
+func (t Thresholds) Rate(adjustedXP int) Difficulty {
+	for d := Deadly; d > Trivial; d-- {
+		if adjustedXP >= t.Get(d) {
+			return d
+		}
+	}
+	return Trivial
+}
+
+// String returns a string representation of the Thresholds.
+func (t Thresholds) String() string {
+	return fmt.Sprintf("easy %d, medium %d, hard %d, deadly %d", t.Easy, t.Medium, t.Hard, t.Deadly)
+}
+
+// multipliers holds the encounter multipliers in increasing order.
+// The first and last entries are only reached when adjusting for party size.
+var multipliers = []float64{0.5, 1, 1.5, 2, 2.5, 3, 4, 5}
+
+// Multiplier returns the XP multiplier for an encounter with the given
+// number of monsters against a party of the given size.
+// Parties with fewer than three characters use the next higher multiplier,
+// and parties with six or more characters the next lower one.
+func Multiplier(monsters int, partySize int) float64 {
+	if monsters <= 0 {
+		return 0
+	}
+	var index int
+	switch {
+	case monsters == 1:
+		index = 1
+	case monsters == 2:
+		index = 2
+	case monsters <= 6:
+		index = 3
+	case monsters <= 10:
+		index = 4
+	case monsters <= 14:
+		index = 5
+	default:
+		index = 6
+	}
+	if partySize < 3 {
+		index++
+	} else if partySize >= 6 {
+		index--
+	}
+	return multipliers[index]
+}
@@ -0,0 +1,49 @@
+package encounter
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// Party represents the group of characters facing an encounter.
+type Party struct {
+	Members []*character.Character
+}
+
+// NewParty creates and returns a new Party with the given characters.
+func NewParty(members ...*character.Character) *Party {
+	return &Party{Members: members}
+}
+
+// LoadParty loads every character file and returns them as a Party.
+// It returns an error if no files are given or any of them fails to load.
+func LoadParty(filenames ...string) (*Party, error) {
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("party has no characters")
+	}
+	party := &Party{}
+	for _, filename := range filenames {
+		c, err := character.LoadCharacter(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", filename, err)
+		}
+		party.Members = append(party.Members, c)
+	}
+	return party, nil
+}
+
+// Size returns the number of characters in the party.
+func (p *Party) Size() int {
+	return len(p.Members)
+}
+
+// Thresholds returns the XP thresholds of the party, the sum of the
+// thresholds of every character for its level.
+func (p *Party) Thresholds() Thresholds {
+	var total Thresholds
+	for _, c := range p.Members {
+		total = total.Add(LevelThresholds(c.Level))
+	}
+	return total
+}
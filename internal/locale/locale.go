@@ -0,0 +1,215 @@
+// Package locale translates the creation content and the program messages.
+// Each locale has a string table with the attribute names, the messages,
+// and the creation questions and answers, keyed by question year and
+// AnswerID. Text missing from the table falls back to English.
+package locale
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// English is the locale of the built-in text, which needs no table.
+const English = "en"
+
+// Table represents the string table of a locale.
+// It includes JSON struct tags for serialization.
+// Attributes maps attribute short names to their full names, Messages maps
+// message IDs to fmt format strings, Questions maps question years to the
+// question text and Answers maps answer IDs to the answer description.
+type Table struct {
+	Locale     string            `json:"locale"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Messages   map[string]string `json:"messages,omitempty"`
+	Questions  map[int]string    `json:"questions,omitempty"`
+	Answers    map[string]string `json:"answers,omitempty"`
+}
+
+// ParseTable unmarshals a string table from JSON content and validates it.
+// It returns the Table and any error encountered during the process.
+func ParseTable(content []byte) (*Table, error) {
+	var table Table
+	if err := json.Unmarshal(content, &table); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	if table.Locale == "" {
+		return nil, fmt.Errorf("string table has no locale")
+	}
+	for name := range table.Attributes {
+		if _, ok := character.GetAttributeFromShortName(name); !ok {
+			return nil, fmt.Errorf("string table %s translates unknown attribute %q", table.Locale, name)
+		}
+	}
+	return &table, nil
+}
+
+// Normalize returns the locale of a POSIX locale name, without its encoding
+// and modifier, as "es_ES" for "es_ES.UTF-8@euro".
+// It returns an empty string for the "C" and "POSIX" locales.
+func Normalize(name string) string {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	if name == "C" || name == "POSIX" {
+		return ""
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// FromEnv returns the locale selected by the environment: the first of the
+// LC_ALL, LC_MESSAGES and LANG variables that is set, normalized.
+func FromEnv() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			return Normalize(value)
+		}
+	}
+	return ""
+}
+
+// Candidates returns the locales to look for a table in, from the most to
+// the least specific, as "es_ES" and "es" for "es_ES".
+func Candidates(name string) []string {
+	name = Normalize(name)
+	if name == "" {
+		return nil
+	}
+	candidates := []string{name}
+	if language, _, ok := strings.Cut(name, "_"); ok {
+		candidates = append(candidates, language)
+	}
+	return candidates
+}
+
+// IsEnglish returns true if the locale is English, which needs no table.
+func IsEnglish(name string) bool {
+	return slices.Contains(Candidates(name), English)
+}
+
+// Localizer translates text with a string table, falling back to the
+// English text for anything the table does not translate.
+type Localizer struct {
+	table    *Table
+	messages map[string]string
+}
+
+// New creates and returns a new Localizer with the given table, or English
+// if it is nil. Messages maps message IDs to their English format strings.
+func New(table *Table, messages map[string]string) *Localizer {
+	if table == nil {
+		table = &Table{Locale: English}
+	}
+	return &Localizer{table: table, messages: messages}
+}
+
+// Locale returns the locale the Localizer translates to.
+func (l *Localizer) Locale() string {
+	return l.table.Locale
+}
+
+// Attribute returns the full name of the attribute.
+func (l *Localizer) Attribute(attr character.Attribute) string {
+	if name, ok := l.table.Attributes[character.GetAttributeShortName(attr)]; ok {
+		return name
+	}
+	return character.GetAttributeName(attr)
+}
+
+// Message returns the message with the given ID formatted with the
+// arguments, or the ID itself if the message is unknown.
+func (l *Localizer) Message(id string, args ...any) string {
+	format, ok := l.table.Messages[id]
+	if !ok {
+		format, ok = l.messages[id]
+	}
+	if !ok {
+		format = id
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Question returns the text of the question.
+func (l *Localizer) Question(q character.Question) string {
+	if text, ok := l.table.Questions[q.Year]; ok {
+		return text
+	}
+	return q.Question
+}
+
+// Answer returns the description of the answer.
+func (l *Localizer) Answer(a character.Answer) string {
+	if text, ok := l.table.Answers[a.AnswerID]; ok {
+		return text
+	}
+	return a.Description
+}
+
+// Report lists the entries a string table is missing and the entries it
+// translates that no longer exist, as "answer Y3-A1" or "message seed".
+type Report struct {
+	Locale  string
+	Missing []string
+	Unused  []string
+}
+
+// IsComplete returns true if the table translates every entry.
+func (r Report) IsComplete() bool {
+	return len(r.Missing) == 0
+}
+
+// Check compares the table with the English messages and the creation data
+// it translates.
+// It returns the missing and unused entries, attributes first, then
+// messages sorted by ID, then questions and answers in data order.
+func (t *Table) Check(messages map[string]string, data *character.CharacterCreationData) Report {
+	report := Report{Locale: t.Locale}
+	check := func(kind string, keys []string, translated map[string]bool) {
+		for _, key := range keys {
+			if !translated[key] {
+				report.Missing = append(report.Missing, kind+" "+key)
+			}
+			delete(translated, key)
+		}
+		for _, key := range slices.Sorted(maps.Keys(translated)) {
+			report.Unused = append(report.Unused, kind+" "+key)
+		}
+	}
+	translated := func(m map[string]string) map[string]bool {
+		result := map[string]bool{}
+		for key, value := range m {
+			result[key] = value != ""
+		}
+		return result
+	}
+
+	var attributes []string
+	for attr := character.Str; attr <= character.Cha; attr++ {
+		attributes = append(attributes, character.GetAttributeShortName(attr))
+	}
+	check("attribute", attributes, translated(t.Attributes))
+	check("message", slices.Sorted(maps.Keys(messages)), translated(t.Messages))
+
+	var years, answers []string
+	for _, q := range data.Questions {
+		years = append(years, strconv.Itoa(q.Year))
+		for _, a := range q.Answers {
+			answers = append(answers, a.AnswerID)
+		}
+	}
+	questions := map[string]bool{}
+	for year, text := range t.Questions {
+		questions[strconv.Itoa(year)] = text != ""
+	}
+	check("question", years, questions)
+	check("answer", answers, translated(t.Answers))
+	return report
+}
@@ -0,0 +1,109 @@
+package monster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/dataformat"
+)
+
+// Bestiary represents the structure of the bestiary JSON file.
+// It includes the list of monsters, indexed by name for lookups.
+type Bestiary struct {
+	Monsters []Monster `json:"monsters"`
+	index    map[string]int
+}
+
+// LoadBestiary reads a bestiary from a JSON, YAML or TOML file, chosen by
+// its extension, unmarshals it into a Bestiary struct and validates every
+// monster.
+// It returns the Bestiary and any error encountered during the process.
+func LoadBestiary(filename string) (*Bestiary, error) {
+	file, err := dataformat.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBestiary(file)
+}
+
+// ParseBestiary unmarshals the bestiary from JSON content into
+// a Bestiary struct and validates every monster.
+// It returns the Bestiary and any error encountered during the process.
+func ParseBestiary(content []byte) (*Bestiary, error) {
+	var bestiary Bestiary
+	if err := json.Unmarshal(content, &bestiary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	if err := bestiary.Validate(); err != nil {
+		return nil, err
+	}
+	return &bestiary, nil
+}
+
+// normalizeName returns the key used to look up monsters by name.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Validate checks every monster in the bestiary and that monster names are unique.
+func (b *Bestiary) Validate() error {
+	b.index = make(map[string]int, len(b.Monsters))
+	for i := range b.Monsters {
+		m := &b.Monsters[i]
+		if err := m.Validate(); err != nil {
+			return err
+		}
+		key := normalizeName(m.Name)
+		if _, ok := b.index[key]; ok {
+			return fmt.Errorf("duplicate monster %q", m.Name)
+		}
+		b.index[key] = i
+	}
+	return nil
+}
+
+// Get returns the monster with the given name, ignoring case.
+// It returns the Monster and a boolean indicating whether the monster was found.
+func (b *Bestiary) Get(name string) (*Monster, bool) {
+	if b.index == nil {
+		b.index = make(map[string]int, len(b.Monsters))
+		for i, m := range b.Monsters {
+			b.index[normalizeName(m.Name)] = i
+		}
+	}
+	i, ok := b.index[normalizeName(name)]
+	if !ok {
+		return nil, false
+	}
+	return &b.Monsters[i], true
+}
+
+// Names returns the sorted list of monster names in the bestiary.
+func (b *Bestiary) Names() []string {
+	names := make([]string, len(b.Monsters))
+	for i, m := range b.Monsters {
+		names[i] = m.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Filter returns the monsters found in the given environment with a
+// challenge rating between minCR and maxCR, inclusive. An empty environment
+// matches every monster.
+func (b *Bestiary) Filter(environment string, minCR float64, maxCR float64) []*Monster {
+	var result []*Monster
+	for i := range b.Monsters {
+		m := &b.Monsters[i]
+		if environment != "" && !m.HasEnvironment(environment) {
+			continue
+		}
+		if cr := m.CR(); cr < minCR || cr > maxCR {
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
@@ -0,0 +1,266 @@
+package monster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/damage"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// Trait represents a special trait of a monster, such as Nimble Escape.
+type Trait struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ExtraDamage represents additional damage of another type dealt by an
+// attack, such as the poison damage of a dragon bite.
+type ExtraDamage struct {
+	Damage     string `json:"damage"`
+	DamageType string `json:"damage_type"`
+}
+
+// Action represents an action a monster can take.
+// Attack actions have a Kind of "melee" or "ranged", an attack bonus and a
+// damage expression in dice notation, plus any extra damage of other types.
+// Other actions only have a description.
+type Action struct {
+	Name        string        `json:"name"`
+	Kind        string        `json:"kind,omitempty"`
+	AttackBonus int           `json:"attack_bonus,omitempty"`
+	Reach       string        `json:"reach,omitempty"`
+	Damage      string        `json:"damage,omitempty"`
+	DamageType  string        `json:"damage_type,omitempty"`
+	Extra       []ExtraDamage `json:"extra_damage,omitempty"`
+	Description string        `json:"description,omitempty"`
+}
+
+// IsAttack returns true if the action is a melee or ranged attack.
+func (a Action) IsAttack() bool {
+	return a.Kind == "melee" || a.Kind == "ranged"
+}
+
+// Monster represents a monster stat block.
+// It includes JSON struct tags for serialization.
+// Abilities holds the six ability scores, Saves and Skills the total bonus
+// of the proficient saving throws and skills, and HitDice the dice notation
+// used to roll hit points, including the constitution bonus.
+type Monster struct {
+	Name                  string                  `json:"name"`
+	Size                  string                  `json:"size"`
+	Type                  string                  `json:"type"`
+	Alignment             string                  `json:"alignment"`
+	ArmorClass            int                     `json:"armor_class"`
+	ArmorDescription      string                  `json:"armor_description,omitempty"`
+	HitDice               string                  `json:"hit_dice"`
+	Speed                 map[string]int          `json:"speed"`
+	Abilities             character.AttributesMap `json:"abilities"`
+	Saves                 map[string]int          `json:"saves,omitempty"`
+	Skills                map[string]int          `json:"skills,omitempty"`
+	DamageVulnerabilities []string                `json:"damage_vulnerabilities,omitempty"`
+	DamageResistances     []string                `json:"damage_resistances,omitempty"`
+	DamageImmunities      []string                `json:"damage_immunities,omitempty"`
+	ConditionImmunities   []string                `json:"condition_immunities,omitempty"`
+	Senses                map[string]int          `json:"senses,omitempty"`
+	Languages             []string                `json:"languages,omitempty"`
+	ChallengeRating       string                  `json:"challenge_rating"`
+	Environments          []string                `json:"environments,omitempty"`
+	Traits                []Trait                 `json:"traits,omitempty"`
+	Actions               []Action                `json:"actions"`
+}
+
+// challengeXP maps each challenge rating to its experience points.
+var challengeXP = map[string]int{
+	"0": 10, "1/8": 25, "1/4": 50, "1/2": 100,
+	"1": 200, "2": 450, "3": 700, "4": 1100, "5": 1800,
+	"6": 2300, "7": 2900, "8": 3900, "9": 5000, "10": 5900,
+	"11": 7200, "12": 8400, "13": 10000, "14": 11500, "15": 13000,
+	"16": 15000, "17": 18000, "18": 20000, "19": 22000, "20": 25000,
+	"21": 33000, "22": 41000, "23": 50000, "24": 62000, "25": 75000,
+	"26": 90000, "27": 105000, "28": 120000, "29": 135000, "30": 155000,
+}
+
+// ChallengeXP returns the experience points for a challenge rating such as "1/4".
+// It returns the XP and a boolean indicating whether the challenge rating is valid.
+func ChallengeXP(cr string) (int, bool) {
+	xp, ok := challengeXP[strings.TrimSpace(cr)]
+	return xp, ok
+}
+
+// ParseChallengeRating converts a challenge rating such as "1/4" or "3" to a number.
+// It returns an error if the challenge rating is not valid.
+func ParseChallengeRating(cr string) (float64, error) {
+	cr = strings.TrimSpace(cr)
+	if _, ok := challengeXP[cr]; !ok {
+		return 0, fmt.Errorf("invalid challenge rating %q", cr)
+	}
+	if num, den, found := strings.Cut(cr, "/"); found {
+		n, _ := strconv.Atoi(num)
+		d, _ := strconv.Atoi(den)
+		return float64(n) / float64(d), nil
+	}
+	n, _ := strconv.Atoi(cr)
+	return float64(n), nil
+}
+
+// CR returns the challenge rating of the monster as a number.
+// Invalid challenge ratings return zero.
+func (m *Monster) CR() float64 {
+	cr, _ := ParseChallengeRating(m.ChallengeRating)
+	return cr
+}
+
+// XP returns the experience points awarded for defeating the monster.
+func (m *Monster) XP() int {
+	xp, _ := ChallengeXP(m.ChallengeRating)
+	return xp
+}
+
+// ProficiencyBonus returns the proficiency bonus of the monster, based on
+// its challenge rating.
+func (m *Monster) ProficiencyBonus() int {
+	cr := m.CR()
+	if cr < 5 {
+		return 2
+	}
+	return 2 + (int(cr)-1)/4
+}
+
+// Modifier returns the ability modifier for the given attribute.
+func (m *Monster) Modifier(attr character.Attribute) int {
+	return character.AbilityModifier(m.Abilities.Get(attr))
+}
+
+// SaveBonus returns the saving throw bonus for the given attribute: the
+// listed bonus for proficient saves, or the ability modifier otherwise.
+func (m *Monster) SaveBonus(attr character.Attribute) int {
+	if bonus, ok := m.Saves[character.GetAttributeShortName(attr)]; ok {
+		return bonus
+	}
+	return m.Modifier(attr)
+}
+
+// PassivePerception returns 10 plus the perception skill bonus, or plus
+// the wisdom modifier when the monster has no perception skill.
+func (m *Monster) PassivePerception() int {
+	if bonus, ok := m.Skills["perception"]; ok {
+		return 10 + bonus
+	}
+	return 10 + m.Modifier(character.Wis)
+}
+
+// AverageHitPoints returns the average hit points of the monster.
+func (m *Monster) AverageHitPoints() int {
+	expr, err := dice.Parse(m.HitDice)
+	if err != nil {
+		return 1
+	}
+	return max(1, expr.Average())
+}
+
+// RollHitPoints rolls the hit points of the monster with the given Roller.
+func (m *Monster) RollHitPoints(r dice.Roller) int {
+	expr, err := dice.Parse(m.HitDice)
+	if err != nil {
+		return 1
+	}
+	return max(1, expr.RollWith(r))
+}
+
+// Defenses returns the damage types the monster resists, is vulnerable or
+// is immune to. Unknown damage types are ignored.
+func (m *Monster) Defenses() damage.Defenses {
+	var defenses damage.Defenses
+	for _, list := range []struct {
+		names []string
+		types *[]damage.Type
+	}{
+		{m.DamageResistances, &defenses.Resistances},
+		{m.DamageVulnerabilities, &defenses.Vulnerabilities},
+		{m.DamageImmunities, &defenses.Immunities},
+	} {
+		for _, name := range list.names {
+			if t, err := damage.ParseType(name); err == nil {
+				*list.types = append(*list.types, t)
+			}
+		}
+	}
+	return defenses
+}
+
+// HasEnvironment returns true if the monster can be found in the given environment.
+func (m *Monster) HasEnvironment(environment string) bool {
+	for _, env := range m.Environments {
+		if strings.EqualFold(env, environment) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the monster has a name, valid ability scores, armor
+// class, hit dice, challenge rating, damage types and attack damage expressions.
+func (m *Monster) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("monster has no name")
+	}
+	for _, attr := range []character.Attribute{character.Str, character.Dex, character.Con, character.Int, character.Wis, character.Cha} {
+		score, ok := m.Abilities[attr]
+		if !ok {
+			return fmt.Errorf("monster %q is missing %s", m.Name, character.GetAttributeShortName(attr))
+		}
+		if !character.AbsoluteBounds.Contains(score) {
+			return fmt.Errorf("monster %q has %s %d out of bounds %s", m.Name, character.GetAttributeShortName(attr), score, character.AbsoluteBounds)
+		}
+	}
+	if m.ArmorClass <= 0 {
+		return fmt.Errorf("monster %q has invalid armor class %d", m.Name, m.ArmorClass)
+	}
+	if _, err := dice.Parse(m.HitDice); err != nil {
+		return fmt.Errorf("monster %q hit dice: %w", m.Name, err)
+	}
+	if _, err := ParseChallengeRating(m.ChallengeRating); err != nil {
+		return fmt.Errorf("monster %q: %w", m.Name, err)
+	}
+	for save := range m.Saves {
+		if _, ok := character.GetAttributeFromShortName(save); !ok {
+			return fmt.Errorf("monster %q has invalid save %q", m.Name, save)
+		}
+	}
+	for _, names := range [][]string{m.DamageResistances, m.DamageVulnerabilities, m.DamageImmunities} {
+		for _, name := range names {
+			if _, err := damage.ParseType(name); err != nil {
+				return fmt.Errorf("monster %q: %w", m.Name, err)
+			}
+		}
+	}
+	for _, action := range m.Actions {
+		components := append([]ExtraDamage{{Damage: action.Damage, DamageType: action.DamageType}}, action.Extra...)
+		for _, c := range components {
+			if c.Damage == "" {
+				continue
+			}
+			if _, err := dice.Parse(c.Damage); err != nil {
+				return fmt.Errorf("monster %q action %q: %w", m.Name, action.Name, err)
+			}
+			if _, err := damage.ParseType(c.DamageType); err != nil {
+				return fmt.Errorf("monster %q action %q: %w", m.Name, action.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map sorted alphabetically.
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+package monster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// statBlockWidth is the width of the separator lines in a stat block.
+const statBlockWidth = 48
+
+// capitalize returns the text with its first letter in upper case.
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// formatSpeed returns the speed of the monster, walking speed first.
+func (m *Monster) formatSpeed() string {
+	var parts []string
+	if walk, ok := m.Speed["walk"]; ok {
+		parts = append(parts, fmt.Sprintf("%d ft.", walk))
+	}
+	for _, mode := range sortedKeys(m.Speed) {
+		if mode != "walk" {
+			parts = append(parts, fmt.Sprintf("%s %d ft.", mode, m.Speed[mode]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSenses returns the senses of the monster followed by its passive perception.
+func (m *Monster) formatSenses() string {
+	var parts []string
+	for _, sense := range sortedKeys(m.Senses) {
+		parts = append(parts, fmt.Sprintf("%s %d ft.", sense, m.Senses[sense]))
+	}
+	parts = append(parts, fmt.Sprintf("passive Perception %d", m.PassivePerception()))
+	return strings.Join(parts, ", ")
+}
+
+// formatDamage returns the average damage and the dice of an action, as in "5 (1d6 + 2)".
+func formatDamage(notation string) string {
+	expr, err := dice.Parse(notation)
+	if err != nil {
+		return notation
+	}
+	formula := fmt.Sprintf("%dd%d", expr.Count, expr.Sides)
+	if expr.Sides == 0 {
+		return fmt.Sprint(expr.Modifier)
+	}
+	if expr.Modifier > 0 {
+		formula += fmt.Sprintf(" + %d", expr.Modifier)
+	} else if expr.Modifier < 0 {
+		formula += fmt.Sprintf(" - %d", -expr.Modifier)
+	}
+	return fmt.Sprintf("%d (%s)", expr.Average(), formula)
+}
+
+// formatAction returns the stat block line of an action.
+func formatAction(action Action) string {
+	if !action.IsAttack() {
+		return fmt.Sprintf("%s. %s", action.Name, action.Description)
+	}
+	kind := "Melee"
+	reach := "reach"
+	if action.Kind == "ranged" {
+		kind = "Ranged"
+		reach = "range"
+	}
+	hit := fmt.Sprintf("%s %s damage", formatDamage(action.Damage), action.DamageType)
+	for _, extra := range action.Extra {
+		hit += fmt.Sprintf(" plus %s %s damage", formatDamage(extra.Damage), extra.DamageType)
+	}
+	line := fmt.Sprintf("%s. %s Weapon Attack: %+d to hit, %s %s, one target. Hit: %s.",
+		action.Name, kind, action.AttackBonus, reach, action.Reach, hit)
+	if action.Description != "" {
+		line += " " + action.Description
+	}
+	return line
+}
+
+// StatBlock returns the monster rendered as a classic stat block.
+func (m *Monster) StatBlock() string {
+	separator := strings.Repeat("-", statBlockWidth)
+	var b strings.Builder
+	fmt.Fprintln(&b, strings.ToUpper(m.Name))
+	fmt.Fprintf(&b, "%s %s, %s\n", m.Size, m.Type, m.Alignment)
+	fmt.Fprintln(&b, separator)
+	ac := fmt.Sprintf("Armor Class %d", m.ArmorClass)
+	if m.ArmorDescription != "" {
+		ac += fmt.Sprintf(" (%s)", m.ArmorDescription)
+	}
+	fmt.Fprintln(&b, ac)
+	fmt.Fprintf(&b, "Hit Points %s\n", formatDamage(m.HitDice))
+	fmt.Fprintf(&b, "Speed %s\n", m.formatSpeed())
+	fmt.Fprintln(&b, separator)
+	attrs := []character.Attribute{character.Str, character.Dex, character.Con, character.Int, character.Wis, character.Cha}
+	for _, attr := range attrs {
+		fmt.Fprintf(&b, "%-8s", character.GetAttributeShortName(attr))
+	}
+	fmt.Fprintln(&b)
+	for _, attr := range attrs {
+		fmt.Fprintf(&b, "%-8s", fmt.Sprintf("%d (%+d)", m.Abilities.Get(attr), m.Modifier(attr)))
+	}
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, separator)
+	if len(m.Saves) > 0 {
+		var saves []string
+		for _, attr := range attrs {
+			if bonus, ok := m.Saves[character.GetAttributeShortName(attr)]; ok {
+				saves = append(saves, fmt.Sprintf("%s %+d", character.GetAttributeShortName(attr), bonus))
+			}
+		}
+		fmt.Fprintf(&b, "Saving Throws %s\n", strings.Join(saves, ", "))
+	}
+	if len(m.Skills) > 0 {
+		var skills []string
+		for _, skill := range sortedKeys(m.Skills) {
+			skills = append(skills, fmt.Sprintf("%s %+d", capitalize(skill), m.Skills[skill]))
+		}
+		fmt.Fprintf(&b, "Skills %s\n", strings.Join(skills, ", "))
+	}
+	for _, line := range []struct {
+		label  string
+		values []string
+	}{
+		{"Damage Vulnerabilities", m.DamageVulnerabilities},
+		{"Damage Resistances", m.DamageResistances},
+		{"Damage Immunities", m.DamageImmunities},
+		{"Condition Immunities", m.ConditionImmunities},
+	} {
+		if len(line.values) > 0 {
+			fmt.Fprintf(&b, "%s %s\n", line.label, strings.Join(line.values, ", "))
+		}
+	}
+	fmt.Fprintf(&b, "Senses %s\n", m.formatSenses())
+	languages := "—"
+	if len(m.Languages) > 0 {
+		languages = strings.Join(m.Languages, ", ")
+	}
+	fmt.Fprintf(&b, "Languages %s\n", languages)
+	fmt.Fprintf(&b, "Challenge %s (%d XP)\n", m.ChallengeRating, m.XP())
+	fmt.Fprintln(&b, separator)
+	for _, trait := range m.Traits {
+		fmt.Fprintf(&b, "%s. %s\n", trait.Name, trait.Description)
+	}
+	if len(m.Actions) > 0 {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "ACTIONS")
+		for _, action := range m.Actions {
+			fmt.Fprintln(&b, formatAction(action))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,225 @@
+package roster
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// Roster manages the characters of a Store.
+// Now returns the time recorded in entries, and defaults to time.Now.
+type Roster struct {
+	store Store
+	Now   func() time.Time
+}
+
+// New creates and returns a new Roster using the given store.
+func New(store Store) *Roster {
+	return &Roster{store: store, Now: time.Now}
+}
+
+// Query filters the entries returned by Search.
+// Name matches entries whose name contains it and Class entries with a
+// level in that class, both ignoring case. MinLevel and MaxLevel bound the
+// character level when not zero. Archived entries are only returned when
+// Archived is true.
+type Query struct {
+	Name     string
+	Class    string
+	MinLevel int
+	MaxLevel int
+	Archived bool
+}
+
+// Matches returns true if the entry meets every filter of the query.
+func (q Query) Matches(e Entry) bool {
+	if e.Archived && !q.Archived {
+		return false
+	}
+	if q.Name != "" && !strings.Contains(strings.ToLower(e.Name), strings.ToLower(q.Name)) {
+		return false
+	}
+	if q.Class != "" && !hasClass(e.Classes, q.Class) {
+		return false
+	}
+	if q.MinLevel > 0 && e.Level < q.MinLevel {
+		return false
+	}
+	if q.MaxLevel > 0 && e.Level > q.MaxLevel {
+		return false
+	}
+	return true
+}
+
+// hasClass returns true if the classes of an entry, as in
+// "fighter 3 / wizard 2", include the given class.
+func hasClass(classes string, class string) bool {
+	for _, part := range strings.Split(classes, "/") {
+		job, _, _ := strings.Cut(strings.TrimSpace(part), " ")
+		if strings.EqualFold(job, strings.TrimSpace(class)) {
+			return true
+		}
+	}
+	return false
+}
+
+// entry returns the entry with the given ID.
+func (r *Roster) entry(id string) (Entry, error) {
+	entries, err := r.store.Entries()
+	if err != nil {
+		return Entry{}, err
+	}
+	i := slices.IndexFunc(entries, func(e Entry) bool { return e.ID == id })
+	if i < 0 {
+		return Entry{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return entries[i], nil
+}
+
+// uniqueID returns an ID for the name not used by any entry, adding a
+// numeric suffix when needed, as in "aria_2".
+func (r *Roster) uniqueID(name string) (string, error) {
+	entries, err := r.store.Entries()
+	if err != nil {
+		return "", err
+	}
+	base := character.Slug(name)
+	id := base
+	for n := 2; slices.ContainsFunc(entries, func(e Entry) bool { return e.ID == id }); n++ {
+		id = fmt.Sprintf("%s_%d", base, n)
+	}
+	return id, nil
+}
+
+// put saves the character with its entry, refreshing the entry fields
+// copied from the character.
+func (r *Roster) put(entry Entry, c *character.Character) (Entry, error) {
+	entry.Name = c.Name
+	entry.Classes = c.ClassString()
+	entry.Level = c.Level
+	entry.Updated = r.Now()
+	if entry.Created.IsZero() {
+		entry.Created = entry.Updated
+	}
+	if err := r.store.Put(entry, c); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// Add stores a new character, with an ID derived from its name.
+// It returns the new entry.
+func (r *Roster) Add(c *character.Character) (Entry, error) {
+	id, err := r.uniqueID(c.Name)
+	if err != nil {
+		return Entry{}, err
+	}
+	return r.put(Entry{ID: id}, c)
+}
+
+// Get loads the character with the given ID.
+func (r *Roster) Get(id string) (*character.Character, error) {
+	return r.store.Get(id)
+}
+
+// Update saves the character back under the given ID.
+// It returns an error if the ID is not in the roster.
+func (r *Roster) Update(id string, c *character.Character) (Entry, error) {
+	entry, err := r.entry(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	return r.put(entry, c)
+}
+
+// List returns the entries sorted by name, leaving out archived entries
+// unless archived is true.
+func (r *Roster) List(archived bool) ([]Entry, error) {
+	return r.Search(Query{Archived: archived})
+}
+
+// Search returns the entries matching the query, sorted by name and ID.
+func (r *Roster) Search(q Query) ([]Entry, error) {
+	entries, err := r.store.Entries()
+	if err != nil {
+		return nil, err
+	}
+	var found []Entry
+	for _, e := range entries {
+		if q.Matches(e) {
+			found = append(found, e)
+		}
+	}
+	slices.SortFunc(found, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.ID, b.ID))
+	})
+	return found, nil
+}
+
+// Rename changes the name of a character, keeping its ID.
+// It returns an error if the ID is not in the roster or the name is empty.
+func (r *Roster) Rename(id string, name string) (Entry, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Entry{}, fmt.Errorf("character name cannot be empty")
+	}
+	entry, err := r.entry(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	c, err := r.store.Get(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	c.Name = name
+	return r.put(entry, c)
+}
+
+// Clone stores a copy of a character under a new name and ID.
+// The copy is not archived, even if the original is.
+// It returns the entry of the copy.
+func (r *Roster) Clone(id string, name string) (Entry, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Entry{}, fmt.Errorf("character name cannot be empty")
+	}
+	c, err := r.store.Get(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	c.Name = name
+	return r.Add(c)
+}
+
+// setArchived archives or restores the entry with the given ID.
+func (r *Roster) setArchived(id string, archived bool) (Entry, error) {
+	entry, err := r.entry(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	c, err := r.store.Get(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry.Archived = archived
+	return r.put(entry, c)
+}
+
+// Archive hides a character from List and Search, without deleting it.
+func (r *Roster) Archive(id string) (Entry, error) {
+	return r.setArchived(id, true)
+}
+
+// Unarchive restores an archived character.
+func (r *Roster) Unarchive(id string) (Entry, error) {
+	return r.setArchived(id, false)
+}
+
+// Delete removes a character from the roster.
+func (r *Roster) Delete(id string) error {
+	return r.store.Delete(id)
+}
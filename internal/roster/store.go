@@ -0,0 +1,253 @@
+package roster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// ErrNotFound is returned when a roster entry does not exist.
+var ErrNotFound = errors.New("character not found")
+
+// Entry describes a character stored in a roster.
+// It includes JSON struct tags for serialization.
+// ID identifies the character in the store and never changes, while Name,
+// Classes and Level are copied from the character to list and search the
+// roster without loading every character.
+type Entry struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Classes  string    `json:"classes"`
+	Level    int       `json:"level"`
+	Archived bool      `json:"archived,omitempty"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+}
+
+// String returns a string representation of the Entry.
+func (e Entry) String() string {
+	result := fmt.Sprintf("%s: %s, %s (level %d)", e.ID, e.Name, e.Classes, e.Level)
+	if e.Archived {
+		result += " [archived]"
+	}
+	return result
+}
+
+// Store is the storage used by a Roster.
+// Entries returns every entry, Get loads the character with the given ID,
+// Put saves an entry with its character, replacing any previous one with
+// the same ID, and Delete removes an entry with its character. Get and
+// Delete return an error wrapping ErrNotFound for unknown IDs.
+type Store interface {
+	Entries() ([]Entry, error)
+	Get(id string) (*character.Character, error)
+	Put(entry Entry, c *character.Character) error
+	Delete(id string) error
+}
+
+// index_file is the name of the index file in a FileStore directory.
+const index_file = "index.json"
+
+// FileStore is a Store keeping each character as a JSON file in a
+// directory, with an index file listing the entries.
+type FileStore struct {
+	Dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore creates the directory if needed and returns a FileStore
+// using it.
+// It returns an error if the directory cannot be created.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create roster directory: %w", err)
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+// path returns the path of the character file with the given ID.
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
+
+// readIndex reads the index file, which is empty if it does not exist yet.
+func (s *FileStore) readIndex() ([]Entry, error) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, index_file))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster index: %w", err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal roster index: %w", err)
+	}
+	return entries, nil
+}
+
+// writeFile writes data to a temporary file and renames it over the
+// target, so readers never see a partially written file.
+func writeFile(fpath string, data []byte) error {
+	tmp := fpath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fpath)
+}
+
+// writeIndex writes the index file.
+func (s *FileStore) writeIndex(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal roster index: %w", err)
+	}
+	if err := writeFile(filepath.Join(s.Dir, index_file), data); err != nil {
+		return fmt.Errorf("failed to write roster index: %w", err)
+	}
+	return nil
+}
+
+// Entries returns every entry of the index.
+func (s *FileStore) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readIndex()
+}
+
+// Get loads the character with the given ID.
+// Only IDs in the index are read, so an ID can not point outside the store.
+func (s *FileStore) Get(id string) (*character.Character, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(entries, func(e Entry) bool { return e.ID == id }) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if _, err := os.Stat(s.path(id)); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return character.LoadCharacter(s.path(id))
+}
+
+// Put saves the character file and updates the index.
+// It returns an error if the entry ID is not a valid file name, as
+// returned by character.Slug.
+func (s *FileStore) Put(entry Entry, c *character.Character) error {
+	if entry.ID != character.Slug(entry.ID) {
+		return fmt.Errorf("invalid character ID %q", entry.ID)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.readIndex()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal character: %w", err)
+	}
+	if err := writeFile(s.path(entry.ID), data); err != nil {
+		return fmt.Errorf("failed to write character: %w", err)
+	}
+	if i := slices.IndexFunc(entries, func(e Entry) bool { return e.ID == entry.ID }); i >= 0 {
+		entries[i] = entry
+	} else {
+		entries = append(entries, entry)
+	}
+	return s.writeIndex(entries)
+}
+
+// Delete removes the character file and its index entry.
+func (s *FileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.readIndex()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(entries, func(e Entry) bool { return e.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete character: %w", err)
+	}
+	return s.writeIndex(slices.Delete(entries, i, i+1))
+}
+
+// MemoryStore is a Store keeping characters in memory, for tests and tools
+// that do not need to persist the roster. Characters are copied through
+// JSON so callers never share them with the store.
+type MemoryStore struct {
+	mu         sync.Mutex
+	entries    []Entry
+	characters map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{characters: map[string][]byte{}}
+}
+
+// Entries returns every entry.
+func (s *MemoryStore) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.entries), nil
+}
+
+// Get returns a copy of the character with the given ID.
+func (s *MemoryStore) Get(id string) (*character.Character, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.characters[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	var c character.Character
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal character: %w", err)
+	}
+	return &c, nil
+}
+
+// Put stores a copy of the character with its entry.
+func (s *MemoryStore) Put(entry Entry, c *character.Character) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal character: %w", err)
+	}
+	s.characters[entry.ID] = data
+	if i := slices.IndexFunc(s.entries, func(e Entry) bool { return e.ID == entry.ID }); i >= 0 {
+		s.entries[i] = entry
+	} else {
+		s.entries = append(s.entries, entry)
+	}
+	return nil
+}
+
+// Delete removes the character with the given ID.
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.entries, func(e Entry) bool { return e.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	s.entries = slices.Delete(s.entries, i, i+1)
+	delete(s.characters, id)
+	return nil
+}
@@ -0,0 +1,306 @@
+package sheet
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// Format represents an output format of a character sheet.
+type Format string
+
+// Enumeration of sheet formats.
+const (
+	Text     Format = "text"
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+// Formats lists every sheet format.
+var Formats = []Format{Text, Markdown, HTML}
+
+// ParseFormat returns the Format with the given name, ignoring case and
+// accepting "txt", "md" and "htm" as aliases.
+// It returns an error if the name does not correspond to any Format.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "text", "txt":
+		return Text, nil
+	case "markdown", "md":
+		return Markdown, nil
+	case "html", "htm":
+		return HTML, nil
+	}
+	return "", fmt.Errorf("unknown sheet format %q", name)
+}
+
+// Render writes the sheet to w in the given format.
+// It returns an error if the format is unknown or writing fails.
+func (s *Sheet) Render(w io.Writer, format Format) error {
+	switch format {
+	case Text:
+		return s.RenderText(w)
+	case Markdown:
+		return s.RenderMarkdown(w)
+	case HTML:
+		return s.RenderHTML(w)
+	}
+	return fmt.Errorf("unknown sheet format %q", format)
+}
+
+// check returns the mark used for proficiencies in text sheets.
+func check(proficient bool) string {
+	if proficient {
+		return "*"
+	}
+	return " "
+}
+
+// markdownCheck returns the escaped mark used for proficiencies in Markdown
+// tables.
+func markdownCheck(proficient bool) string {
+	if proficient {
+		return `\*`
+	}
+	return ""
+}
+
+// list returns the items joined by commas, or "-" when there are none.
+func list(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ", ")
+}
+
+// slotNote returns the slot and attunement notes of an equipment line.
+func slotNote(e EquipmentLine) string {
+	var notes []string
+	if e.Slot != "" {
+		notes = append(notes, "equipped: "+e.Slot)
+	}
+	if e.Attuned {
+		notes = append(notes, "attuned")
+	}
+	return strings.Join(notes, ", ")
+}
+
+// RenderText writes the sheet to w as plain text, with proficiencies marked
+// by an asterisk.
+func (s *Sheet) RenderText(w io.Writer) error {
+	var b strings.Builder
+	title := fmt.Sprintf("%s - %s (level %d)", s.Name, s.Classes, s.Level)
+	fmt.Fprintf(&b, "%s\n%s\n\n", title, strings.Repeat("=", len(title)))
+	fmt.Fprintf(&b, "Hit points: %s   Hit dice: %s\n", s.HitPoints, s.HitDice)
+	fmt.Fprintf(&b, "Armor class: %d   Initiative: %s   Proficiency bonus: %s\n",
+		s.ArmorClass, signed(s.Initiative), signed(s.ProficiencyBonus))
+	fmt.Fprintf(&b, "Passive perception: %d   State: %s\n", s.PassivePerception, s.State)
+	if s.Conditions != "" {
+		fmt.Fprintf(&b, "Conditions: %s\n", s.Conditions)
+	}
+
+	b.WriteString("\nAbilities       Score  Mod   Save\n")
+	for _, a := range s.Abilities {
+		fmt.Fprintf(&b, "  %-13s %5d  %3s  %s%3s\n", a.Name, a.Score, signed(a.Modifier), check(a.SaveProficient), signed(a.Save))
+	}
+
+	b.WriteString("\nSkills\n")
+	for _, sk := range s.Skills {
+		fmt.Fprintf(&b, "  %s %-16s %s %3s\n", check(sk.Proficient), sk.Name, sk.Ability, signed(sk.Modifier))
+	}
+
+	b.WriteString("\nProficiencies\n")
+	fmt.Fprintf(&b, "  Armor and weapons: %s\n", list(s.Proficiencies))
+	fmt.Fprintf(&b, "  Tools: %s\n", list(s.Tools))
+	fmt.Fprintf(&b, "  Languages: %s\n", list(s.Languages))
+	fmt.Fprintf(&b, "  Feats: %s\n", list(s.Feats))
+
+	b.WriteString("\nEquipment\n")
+	for _, e := range s.Equipment {
+		fmt.Fprintf(&b, "  %3d x %-24s %6.1f lb  %s\n", e.Quantity, e.Name, e.Weight, slotNote(e))
+	}
+	fmt.Fprintf(&b, "  Wallet: %s\n", s.Wallet)
+	if s.Encumbrance != "" {
+		fmt.Fprintf(&b, "  Encumbrance: %s\n", s.Encumbrance)
+	}
+
+	if sc := s.Spellcasting; sc != nil {
+		b.WriteString("\nSpellcasting\n")
+		fmt.Fprintf(&b, "  Ability: %s   Save DC: %d   Attack bonus: %s\n", sc.Ability, sc.SaveDC, signed(sc.AttackBonus))
+		fmt.Fprintf(&b, "  Slots: %s\n", sc.Slots)
+		if sc.PactSlots != "" {
+			fmt.Fprintf(&b, "  Pact slots: %s\n", sc.PactSlots)
+		}
+		for _, sp := range sc.Spells {
+			fmt.Fprintf(&b, "  %s %d %s\n", check(sp.Prepared), sp.Level, sp.Name)
+		}
+	}
+
+	bs := s.Backstory
+	b.WriteString("\nBackstory\n")
+	fmt.Fprintf(&b, "  Background: %s\n", bs.Background)
+	if bs.Feature != "" {
+		fmt.Fprintf(&b, "  Feature: %s. %s\n", bs.Feature, bs.FeatureText)
+	}
+	fmt.Fprintf(&b, "  Trait: %s\n  Ideal: %s\n  Bond: %s\n  Flaw: %s\n",
+		bs.Personality.Trait, bs.Personality.Ideal, bs.Personality.Bond, bs.Personality.Flaw)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderMarkdown writes the sheet to w as a Markdown document, with tables
+// for abilities, skills, equipment and spells.
+func (s *Sheet) RenderMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", s.Name)
+	fmt.Fprintf(&b, "**%s** (level %d)\n\n", s.Classes, s.Level)
+	fmt.Fprintf(&b, "| Hit points | Hit dice | Armor class | Initiative | Proficiency | Passive perception |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %s | %s | %d | %s | %s | %d |\n\n",
+		s.HitPoints, s.HitDice, s.ArmorClass, signed(s.Initiative), signed(s.ProficiencyBonus), s.PassivePerception)
+	if s.Conditions != "" {
+		fmt.Fprintf(&b, "**Conditions:** %s\n\n", s.Conditions)
+	}
+
+	b.WriteString("## Abilities\n\n| Ability | Score | Modifier | Save |\n|---|---:|---:|---:|\n")
+	for _, a := range s.Abilities {
+		fmt.Fprintf(&b, "| %s | %d | %s | %s%s |\n", a.Name, a.Score, signed(a.Modifier), signed(a.Save), markdownCheck(a.SaveProficient))
+	}
+
+	b.WriteString("\n## Skills\n\n| Skill | Ability | Modifier |\n|---|---|---:|\n")
+	for _, sk := range s.Skills {
+		fmt.Fprintf(&b, "| %s%s | %s | %s |\n", sk.Name, markdownCheck(sk.Proficient), sk.Ability, signed(sk.Modifier))
+	}
+	b.WriteString("\nProficient entries are marked with an asterisk.\n")
+
+	b.WriteString("\n## Proficiencies\n\n")
+	fmt.Fprintf(&b, "- **Armor and weapons:** %s\n", list(s.Proficiencies))
+	fmt.Fprintf(&b, "- **Tools:** %s\n", list(s.Tools))
+	fmt.Fprintf(&b, "- **Languages:** %s\n", list(s.Languages))
+	fmt.Fprintf(&b, "- **Feats:** %s\n", list(s.Feats))
+
+	b.WriteString("\n## Equipment\n\n| Item | Quantity | Weight | Notes |\n|---|---:|---:|---|\n")
+	for _, e := range s.Equipment {
+		fmt.Fprintf(&b, "| %s | %d | %.1f lb | %s |\n", e.Name, e.Quantity, e.Weight, slotNote(e))
+	}
+	fmt.Fprintf(&b, "\n**Wallet:** %s\n", s.Wallet)
+	if s.Encumbrance != "" {
+		fmt.Fprintf(&b, "\n**Encumbrance:** %s\n", s.Encumbrance)
+	}
+
+	if sc := s.Spellcasting; sc != nil {
+		b.WriteString("\n## Spellcasting\n\n")
+		fmt.Fprintf(&b, "**Ability:** %s, **save DC:** %d, **attack bonus:** %s\n\n", sc.Ability, sc.SaveDC, signed(sc.AttackBonus))
+		fmt.Fprintf(&b, "**Slots:** %s\n\n", sc.Slots)
+		if sc.PactSlots != "" {
+			fmt.Fprintf(&b, "**Pact slots:** %s\n\n", sc.PactSlots)
+		}
+		b.WriteString("| Level | Spell | School | Prepared |\n|---:|---|---|---|\n")
+		for _, sp := range sc.Spells {
+			prepared := ""
+			if sp.Prepared {
+				prepared = "yes"
+			}
+			fmt.Fprintf(&b, "| %d | %s | %s | %s |\n", sp.Level, sp.Name, sp.School, prepared)
+		}
+	}
+
+	bs := s.Backstory
+	b.WriteString("\n## Backstory\n\n")
+	fmt.Fprintf(&b, "**Background:** %s\n\n", bs.Background)
+	if bs.Feature != "" {
+		fmt.Fprintf(&b, "**%s.** %s\n\n", bs.Feature, bs.FeatureText)
+	}
+	fmt.Fprintf(&b, "- **Trait:** %s\n- **Ideal:** %s\n- **Bond:** %s\n- **Flaw:** %s\n",
+		bs.Personality.Trait, bs.Personality.Ideal, bs.Personality.Bond, bs.Personality.Flaw)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// htmlTemplate is the self-contained HTML page of a sheet, with its style
+// inline and a print layout.
+var htmlTemplate = template.Must(template.New("sheet").Funcs(template.FuncMap{
+	"signed": signed,
+	"list":   list,
+	"notes":  slotNote,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} - Character Sheet</title>
+<style>
+body { font-family: Georgia, serif; margin: 2em auto; max-width: 60em; color: #222; }
+h1 { margin-bottom: 0; }
+h2 { border-bottom: 2px solid #7a1f1f; color: #7a1f1f; font-size: 1.1em; margin-top: 1.5em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #bbb; padding: 0.2em 0.5em; text-align: left; }
+th { background: #f0e6d6; }
+td.num { text-align: right; }
+.summary td { text-align: center; font-size: 1.2em; }
+.columns { display: flex; gap: 2em; }
+.columns > section { flex: 1; }
+.prof { font-weight: bold; }
+@media print { body { margin: 0; max-width: none; font-size: 10pt; } h2 { break-after: avoid; } table { break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p>{{.Classes}} (level {{.Level}})</p>
+<table class="summary">
+<tr><th>Hit points</th><th>Hit dice</th><th>Armor class</th><th>Initiative</th><th>Proficiency</th><th>Passive perception</th></tr>
+<tr><td>{{.HitPoints}}</td><td>{{.HitDice}}</td><td>{{.ArmorClass}}</td><td>{{signed .Initiative}}</td><td>{{signed .ProficiencyBonus}}</td><td>{{.PassivePerception}}</td></tr>
+</table>
+{{if .Conditions}}<p><strong>Conditions:</strong> {{.Conditions}}</p>{{end}}
+<div class="columns">
+<section>
+<h2>Abilities</h2>
+<table>
+<tr><th>Ability</th><th>Score</th><th>Modifier</th><th>Save</th></tr>
+{{range .Abilities}}<tr><td>{{.Name}}</td><td class="num">{{.Score}}</td><td class="num">{{signed .Modifier}}</td><td class="num{{if .SaveProficient}} prof{{end}}">{{signed .Save}}</td></tr>
+{{end}}</table>
+<h2>Proficiencies</h2>
+<p><strong>Armor and weapons:</strong> {{list .Proficiencies}}</p>
+<p><strong>Tools:</strong> {{list .Tools}}</p>
+<p><strong>Languages:</strong> {{list .Languages}}</p>
+<p><strong>Feats:</strong> {{list .Feats}}</p>
+</section>
+<section>
+<h2>Skills</h2>
+<table>
+<tr><th>Skill</th><th>Ability</th><th>Modifier</th></tr>
+{{range .Skills}}<tr{{if .Proficient}} class="prof"{{end}}><td>{{.Name}}</td><td>{{.Ability}}</td><td class="num">{{signed .Modifier}}</td></tr>
+{{end}}</table>
+</section>
+</div>
+<h2>Equipment</h2>
+<table>
+<tr><th>Item</th><th>Quantity</th><th>Weight</th><th>Notes</th></tr>
+{{range .Equipment}}<tr><td>{{.Name}}</td><td class="num">{{.Quantity}}</td><td class="num">{{printf "%.1f" .Weight}} lb</td><td>{{notes .}}</td></tr>
+{{end}}</table>
+<p><strong>Wallet:</strong> {{.Wallet}}{{if .Encumbrance}} &middot; <strong>Encumbrance:</strong> {{.Encumbrance}}{{end}}</p>
+{{with .Spellcasting}}<h2>Spellcasting</h2>
+<p><strong>Ability:</strong> {{.Ability}} &middot; <strong>Save DC:</strong> {{.SaveDC}} &middot; <strong>Attack bonus:</strong> {{signed .AttackBonus}}</p>
+<p><strong>Slots:</strong> {{.Slots}}{{if .PactSlots}} &middot; <strong>Pact slots:</strong> {{.PactSlots}}{{end}}</p>
+<table>
+<tr><th>Level</th><th>Spell</th><th>School</th><th>Prepared</th></tr>
+{{range .Spells}}<tr><td class="num">{{.Level}}</td><td>{{.Name}}</td><td>{{.School}}</td><td>{{if .Prepared}}yes{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{with .Backstory}}<h2>Backstory</h2>
+<p><strong>Background:</strong> {{.Background}}</p>
+{{if .Feature}}<p><strong>{{.Feature}}.</strong> {{.FeatureText}}</p>{{end}}
+<p><strong>Trait:</strong> {{.Personality.Trait}}</p>
+<p><strong>Ideal:</strong> {{.Personality.Ideal}}</p>
+<p><strong>Bond:</strong> {{.Personality.Bond}}</p>
+<p><strong>Flaw:</strong> {{.Personality.Flaw}}</p>
+{{end}}</body>
+</html>
+`))
+
+// RenderHTML writes the sheet to w as a self-contained HTML page suitable
+// for printing. Every value is escaped.
+func (s *Sheet) RenderHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, s)
+}
@@ -0,0 +1,268 @@
+package sheet
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// Data holds the catalogs used to resolve the IDs stored in a character.
+// Any of them can be nil, in which case IDs are shown as they are and
+// values depending on them, such as the armor class, are left out.
+type Data struct {
+	Items       *character.ItemData
+	Spells      *character.SpellData
+	Backgrounds *character.BackgroundData
+}
+
+// Ability is a line of the ability scores block.
+type Ability struct {
+	Name           string
+	Short          string
+	Score          int
+	Modifier       int
+	Save           int
+	SaveProficient bool
+}
+
+// SkillLine is a line of the skills block.
+type SkillLine struct {
+	Name       string
+	Ability    string
+	Modifier   int
+	Proficient bool
+}
+
+// EquipmentLine is a line of the equipment block.
+// Slot is the slot the item is equipped in, if any.
+type EquipmentLine struct {
+	Name     string
+	Quantity int
+	Weight   float64
+	Slot     string
+	Attuned  bool
+}
+
+// SpellLine is a line of the spells block.
+type SpellLine struct {
+	Level    int
+	Name     string
+	School   string
+	Prepared bool
+}
+
+// Spellcasting is the spellcasting block, present for characters able to
+// cast spells.
+type Spellcasting struct {
+	Ability     string
+	SaveDC      int
+	AttackBonus int
+	Slots       string
+	PactSlots   string
+	Spells      []SpellLine
+}
+
+// Backstory is the backstory block, with the background, its feature and
+// the personality of the character.
+type Backstory struct {
+	Background  string
+	Feature     string
+	FeatureText string
+	Personality character.Personality
+}
+
+// Sheet is a character sheet ready to be rendered.
+// It is built from a character with New and holds every value already
+// computed, so renderers only lay it out.
+type Sheet struct {
+	Name              string
+	Classes           string
+	Level             int
+	ProficiencyBonus  int
+	HitPoints         string
+	HitDice           string
+	ArmorClass        int
+	Initiative        int
+	PassivePerception int
+	State             string
+	Conditions        string
+	Abilities         []Ability
+	Skills            []SkillLine
+	Proficiencies     []string
+	Tools             []string
+	Languages         []string
+	Feats             []string
+	Equipment         []EquipmentLine
+	Wallet            string
+	Encumbrance       string
+	Spellcasting      *Spellcasting
+	Backstory         Backstory
+}
+
+// attributes lists the attributes in sheet order.
+var attributes = []character.Attribute{character.Str, character.Dex, character.Con, character.Int, character.Wis, character.Cha}
+
+// skills lists the skills in sheet order.
+var skills = []character.Skill{
+	character.Acrobatics, character.AnimalHandling, character.Arcana, character.Athletics,
+	character.Deception, character.History, character.Insight, character.Intimidation,
+	character.Investigation, character.Medicine, character.Nature, character.Perception,
+	character.Performance, character.Persuasion, character.Religion, character.SleightOfHand,
+	character.Stealth, character.Survival,
+}
+
+// New builds the sheet of a character, resolving item, spell and
+// background IDs with the given data.
+func New(c *character.Character, data Data) *Sheet {
+	s := &Sheet{
+		Name:              c.Name,
+		Classes:           c.ClassString(),
+		Level:             c.Level,
+		ProficiencyBonus:  c.ProficiencyBonus(),
+		HitPoints:         c.HitPoints.String(),
+		HitDice:           hitDice(c),
+		Initiative:        character.AbilityModifier(c.EffectiveAttribute(character.Dex)),
+		PassivePerception: c.PassiveScore(character.SkillCheck(character.Perception)),
+		State:             string(c.State),
+		Conditions:        c.Conditions.String(),
+		Proficiencies:     c.Proficiencies,
+		Tools:             c.Tools,
+		Languages:         c.Languages,
+		Feats:             c.Feats,
+		Wallet:            c.Wallet.String(),
+	}
+	for _, attr := range attributes {
+		score := c.EffectiveAttribute(attr)
+		s.Abilities = append(s.Abilities, Ability{
+			Name:           capitalize(character.GetAttributeName(attr)),
+			Short:          character.GetAttributeShortName(attr),
+			Score:          score,
+			Modifier:       character.AbilityModifier(score),
+			Save:           c.SaveModifier(attr),
+			SaveProficient: c.IsSaveProficient(attr),
+		})
+	}
+	for _, skill := range skills {
+		s.Skills = append(s.Skills, SkillLine{
+			Name:       skillName(skill),
+			Ability:    character.GetAttributeShortName(skill.Attribute()),
+			Modifier:   c.CheckModifier(character.SkillCheck(skill)),
+			Proficient: c.IsProficient(skill),
+		})
+	}
+	if data.Items != nil {
+		s.ArmorClass = c.ArmorClass(data.Items)
+		s.Encumbrance = c.Encumbrance(data.Items).String()
+	}
+	s.Equipment = equipment(c, data.Items)
+	s.Spellcasting = spellcasting(c, data.Spells)
+	s.Backstory = backstory(c, data.Backgrounds)
+	return s
+}
+
+// capitalize returns the text with its first letter in upper case.
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// skillName returns the display name of a skill, as in "Sleight of Hand".
+func skillName(skill character.Skill) string {
+	words := strings.Split(string(skill), "_")
+	for i, word := range words {
+		if word != "of" {
+			words[i] = capitalize(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// hitDice returns the available hit dice of the character, as in "3/4 (d10, d8)".
+func hitDice(c *character.Character) string {
+	var dice []string
+	for _, die := range slices.Compact(c.HitDicePool()) {
+		dice = append(dice, fmt.Sprintf("d%d", die))
+	}
+	return fmt.Sprintf("%d/%d (%s)", c.HitDiceAvailable(), c.Level, strings.Join(dice, ", "))
+}
+
+// equipment returns the equipment lines of the character inventory, in
+// inventory order.
+func equipment(c *character.Character, items *character.ItemData) []EquipmentLine {
+	slots := make(map[string]string, len(c.Inventory.Equipped))
+	for slot, id := range c.Inventory.Equipped {
+		slots[id] = string(slot)
+	}
+	var lines []EquipmentLine
+	for _, entry := range c.Inventory.Items {
+		line := EquipmentLine{
+			Name:     entry.ItemID,
+			Quantity: entry.Quantity,
+			Slot:     slots[entry.ItemID],
+			Attuned:  c.Inventory.IsAttuned(entry.ItemID),
+		}
+		if items != nil {
+			if item, ok := items.GetItem(entry.ItemID); ok {
+				line.Name = cmp.Or(item.Name, item.ID)
+				line.Weight = item.Weight * float64(entry.Quantity)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// spellcasting returns the spellcasting block of the character, or nil if
+// it cannot cast spells. Known spells are sorted by level and name.
+func spellcasting(c *character.Character, spells *character.SpellData) *Spellcasting {
+	if c.Spellcasting == nil {
+		return nil
+	}
+	block := &Spellcasting{
+		Ability:     capitalize(character.GetAttributeName(c.Spellcasting.Ability)),
+		SaveDC:      c.SpellSaveDC(),
+		AttackBonus: c.SpellAttackBonus(),
+		Slots:       c.Spellcasting.Slots.String(),
+	}
+	if c.Spellcasting.PactSlots != nil {
+		block.PactSlots = c.Spellcasting.PactSlots.String()
+	}
+	for _, id := range c.Spellcasting.Known {
+		line := SpellLine{Name: id, Prepared: slices.Contains(c.Spellcasting.Prepared, id)}
+		if spells != nil {
+			if spell, ok := spells.GetSpell(id); ok {
+				line.Level = spell.Level
+				line.Name = spell.Name
+				line.School = string(spell.School)
+			}
+		}
+		block.Spells = append(block.Spells, line)
+	}
+	slices.SortStableFunc(block.Spells, func(a, b SpellLine) int {
+		return cmp.Or(cmp.Compare(a.Level, b.Level), cmp.Compare(a.Name, b.Name))
+	})
+	return block
+}
+
+// backstory returns the backstory block of the character.
+func backstory(c *character.Character, backgrounds *character.BackgroundData) Backstory {
+	b := Backstory{Background: c.Background, Personality: c.Personality}
+	if backgrounds != nil {
+		if background, ok := backgrounds.Get(c.Background); ok {
+			b.Background = background.Name
+			b.Feature = background.Feature.Name
+			b.FeatureText = background.Feature.Description
+		}
+	}
+	return b
+}
+
+// signed returns a modifier with its sign, as in "+2" or "-1".
+func signed(mod int) string {
+	return fmt.Sprintf("%+d", mod)
+}
@@ -0,0 +1,145 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Expression represents a parsed dice notation expression such as "2d6+3"
+// or "5d4x10". Count dice with the given number of Sides are rolled, the
+// Modifier is added and the result is multiplied by Multiplier.
+// A Sides value of zero means the expression is a flat number.
+type Expression struct {
+	Count      int
+	Sides      int
+	Modifier   int
+	Multiplier int
+}
+
+// Parse parses a dice notation string into an Expression.
+// Supported forms are "NdS", "dS", "NdS+M", "NdS-M", "NdSxK" and plain
+// integers, where the multiplier, if any, comes last, as in "5d4x10".
+// It returns an error if the notation is not valid.
+func Parse(notation string) (Expression, error) {
+	s := strings.ToLower(strings.ReplaceAll(notation, " ", ""))
+	expr := Expression{Multiplier: 1}
+	if s == "" {
+		return expr, fmt.Errorf("empty dice notation")
+	}
+	if i := strings.IndexAny(s, "x*"); i >= 0 {
+		mult, err := strconv.Atoi(s[i+1:])
+		if err != nil || mult <= 0 {
+			return expr, fmt.Errorf("invalid multiplier in dice notation %q", notation)
+		}
+		expr.Multiplier = mult
+		s = s[:i]
+	}
+	if i := strings.LastIndexAny(s, "+-"); i > 0 {
+		mod, err := strconv.Atoi(s[i:])
+		if err != nil {
+			return expr, fmt.Errorf("invalid modifier in dice notation %q", notation)
+		}
+		expr.Modifier = mod
+		s = s[:i]
+	}
+	d := strings.Index(s, "d")
+	if d < 0 {
+		value, err := strconv.Atoi(s)
+		if err != nil {
+			return expr, fmt.Errorf("invalid dice notation %q", notation)
+		}
+		expr.Modifier += value
+		return expr, nil
+	}
+	expr.Count = 1
+	if d > 0 {
+		count, err := strconv.Atoi(s[:d])
+		if err != nil || count <= 0 {
+			return expr, fmt.Errorf("invalid dice count in dice notation %q", notation)
+		}
+		expr.Count = count
+	}
+	sides, err := strconv.Atoi(s[d+1:])
+	if err != nil || sides <= 0 {
+		return expr, fmt.Errorf("invalid dice sides in dice notation %q", notation)
+	}
+	expr.Sides = sides
+	return expr, nil
+}
+
+// MustParse parses a dice notation string into an Expression.
+// It panics if the notation is not valid.
+func MustParse(notation string) Expression {
+	expr, err := Parse(notation)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// Roll rolls the expression with the DefaultRoller and returns the total.
+func (e Expression) Roll() int {
+	return e.RollWith(DefaultRoller)
+}
+
+// RollWith rolls the expression with the given Roller and returns the total.
+func (e Expression) RollWith(r Roller) int {
+	total := e.Modifier
+	if e.Sides > 0 {
+		total += RollWith(r, e.Count, e.Sides)
+	}
+	return total * e.Multiplier
+}
+
+// RollCritical rolls the expression with the given Roller doubling the
+// number of dice, as for a critical hit, and returns the total.
+func (e Expression) RollCritical(r Roller) int {
+	e.Count *= 2
+	return e.RollWith(r)
+}
+
+// Min returns the minimum possible result of the expression.
+func (e Expression) Min() int {
+	return (e.Count + e.Modifier) * e.Multiplier
+}
+
+// Max returns the maximum possible result of the expression.
+func (e Expression) Max() int {
+	return (e.Count*e.Sides + e.Modifier) * e.Multiplier
+}
+
+// Average returns the average result of the expression, rounded down,
+// as used for fixed hit points and monster stat blocks.
+func (e Expression) Average() int {
+	return (e.Count*(e.Sides+1)/2 + e.Modifier) * e.Multiplier
+}
+
+// String returns the expression in dice notation.
+func (e Expression) String() string {
+	var s string
+	if e.Sides > 0 {
+		s = fmt.Sprintf("%dd%d", e.Count, e.Sides)
+		if e.Modifier > 0 {
+			s += fmt.Sprintf("+%d", e.Modifier)
+		} else if e.Modifier < 0 {
+			s += fmt.Sprintf("%d", e.Modifier)
+		}
+	} else {
+		s = strconv.Itoa(e.Modifier)
+	}
+	if e.Multiplier > 1 {
+		s += fmt.Sprintf("x%d", e.Multiplier)
+	}
+	return s
+}
+
+// RollNotation parses and rolls a dice notation string.
+// It returns the total and any error encountered while parsing.
+func RollNotation(notation string) (int, error) {
+	expr, err := Parse(notation)
+	if err != nil {
+		return 0, err
+	}
+	return expr.Roll(), nil
+}
@@ -0,0 +1,78 @@
+package dice
+
+import "math/rand"
+
+// Roller is a source of die rolls.
+// It allows callers to replace the global random source, for example with a
+// seeded or scripted source in tests.
+type Roller interface {
+	// RollDie returns the result of rolling a die with the given number of sides.
+	RollDie(sides int) int
+}
+
+// globalRoller rolls dice using the package global random source.
+type globalRoller struct{}
+
+// RollDie rolls a die using the package global random source.
+func (globalRoller) RollDie(sides int) int {
+	return RollDie(sides)
+}
+
+// DefaultRoller is the Roller used when no other Roller is provided.
+// It rolls dice using the package global random source.
+var DefaultRoller Roller = globalRoller{}
+
+// RandRoller rolls dice using its own seeded random source,
+// so the sequence of rolls can be reproduced.
+type RandRoller struct {
+	rng *rand.Rand
+}
+
+// NewRandRoller creates and returns a new RandRoller using the given seed.
+func NewRandRoller(seed int64) *RandRoller {
+	return &RandRoller{rng: rand.New(rand.NewSource(seed))}
+}
+
+// RollDie returns a random integer between 1 and the number of sides, inclusive.
+func (r *RandRoller) RollDie(sides int) int {
+	return r.rng.Intn(sides) + 1
+}
+
+// ScriptedRoller returns a predefined sequence of rolls, ignoring the number
+// of sides. It is meant to drive tests through exact scenarios.
+// It panics if more rolls are requested than were scripted.
+type ScriptedRoller struct {
+	values []int
+	next   int
+}
+
+// NewScriptedRoller creates and returns a new ScriptedRoller returning the
+// given values in order.
+func NewScriptedRoller(values ...int) *ScriptedRoller {
+	return &ScriptedRoller{values: values}
+}
+
+// RollDie returns the next scripted value.
+func (s *ScriptedRoller) RollDie(sides int) int {
+	if s.next >= len(s.values) {
+		panic("scripted roller has no more values")
+	}
+	value := s.values[s.next]
+	s.next++
+	return value
+}
+
+// Remaining returns the number of scripted values not used yet.
+func (s *ScriptedRoller) Remaining() int {
+	return len(s.values) - s.next
+}
+
+// RollWith rolls the given number of dice with the given Roller and
+// returns the total sum of the rolls.
+func RollWith(r Roller, numDice, sides int) int {
+	total := 0
+	for i := 0; i < numDice; i++ {
+		total += r.RollDie(sides)
+	}
+	return total
+}
@@ -51,15 +51,19 @@ func TestAbilityModifier(t *testing.T) {
 		score    int
 		expected int
 	}{
-		{10, 0},
-		{9, 0},
+		{1, -5},
+		{3, -4},
+		{5, -3},
+		{7, -2},
 		{8, -1},
+		{9, -1},
+		{10, 0},
+		{11, 0},
+		{12, 1},
 		{15, 2},
 		{18, 4},
-		{3, -3},
-		{12, 1},
-		{7, -1},
 		{20, 5},
+		{30, 10},
 	}
 
 	for _, tt := range tests {
@@ -158,3 +162,41 @@ func TestGetAttributeFromShortName_CaseInsensitive(t *testing.T) {
 		}
 	}
 }
+
+func TestProficiencyBonus(t *testing.T) {
+	tests := []struct {
+		level    int
+		expected int
+	}{
+		{0, 2},
+		{1, 2},
+		{4, 2},
+		{5, 3},
+		{9, 4},
+		{13, 5},
+		{17, 6},
+		{20, 6},
+	}
+	for _, tt := range tests {
+		if got := character.ProficiencyBonus(tt.level); got != tt.expected {
+			t.Errorf("ProficiencyBonus(%d) = %d; want %d", tt.level, got, tt.expected)
+		}
+	}
+}
+
+func TestAttribute_Text(t *testing.T) {
+	text, err := character.Dex.MarshalText()
+	if err != nil || string(text) != "DEX" {
+		t.Errorf("MarshalText(Dex) = %q, %v; want DEX", text, err)
+	}
+	for _, input := range []string{"WIS", "wisdom", "4"} {
+		var attr character.Attribute
+		if err := attr.UnmarshalText([]byte(input)); err != nil || attr != character.Wis {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want WIS", input, attr, err)
+		}
+	}
+	var attr character.Attribute
+	if err := attr.UnmarshalText([]byte("LUCK")); err == nil {
+		t.Error("expected error for unknown attribute")
+	}
+}
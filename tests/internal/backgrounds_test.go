@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// loadAssetBackgrounds loads the background and creation data shipped with
+// the repository.
+func loadAssetBackgrounds(t *testing.T) (*character.BackgroundData, *character.CharacterCreationData) {
+	t.Helper()
+	backgrounds, err := character.LoadBackgroundData(filepath.Join("..", "..", "assets", "data", "backgrounds.json"))
+	if err != nil {
+		t.Fatalf("failed to load backgrounds: %v", err)
+	}
+	creation, err := character.LoadCharacterData(filepath.Join("..", "..", "assets", "data", "character_creation.json"))
+	if err != nil {
+		t.Fatalf("failed to load creation data: %v", err)
+	}
+	return backgrounds, creation
+}
+
+// upbringingOf records the answers with the given IDs from the creation data.
+func upbringingOf(t *testing.T, creation *character.CharacterCreationData, ids ...string) character.Upbringing {
+	t.Helper()
+	var u character.Upbringing
+	for _, id := range ids {
+		found := false
+		for _, q := range creation.Questions {
+			for _, a := range q.Answers {
+				if a.AnswerID == id {
+					u.Record(a)
+					found = true
+				}
+			}
+		}
+		if !found {
+			t.Fatalf("unknown answer %q", id)
+		}
+	}
+	return u
+}
+
+func TestBackgroundData_Assets(t *testing.T) {
+	backgrounds, creation := loadAssetBackgrounds(t)
+	items, err := character.LoadItemData(filepath.Join("..", "..", "assets", "data", "items.json"))
+	if err != nil {
+		t.Fatalf("failed to load items: %v", err)
+	}
+	flags := map[string]bool{}
+	answers := map[string]bool{}
+	for _, q := range creation.Questions {
+		for _, a := range q.Answers {
+			answers[a.AnswerID] = true
+			if len(a.Flags) == 0 {
+				t.Errorf("answer %s has no flags", a.AnswerID)
+			}
+			for _, flag := range a.Flags {
+				flags[flag] = true
+			}
+		}
+	}
+	for _, b := range backgrounds.Backgrounds {
+		for flag := range b.Rules.Flags {
+			if !flags[flag] {
+				t.Errorf("background %s uses unknown flag %q", b.ID, flag)
+			}
+		}
+		for answer := range b.Rules.Answers {
+			if !answers[answer] {
+				t.Errorf("background %s uses unknown answer %q", b.ID, answer)
+			}
+		}
+		for _, entry := range b.Equipment {
+			if _, ok := items.GetItem(entry.ItemID); !ok {
+				t.Errorf("background %s references unknown item %q", b.ID, entry.ItemID)
+			}
+		}
+		if len(b.Traits) == 0 || len(b.Ideals) == 0 || len(b.Bonds) == 0 || len(b.Flaws) == 0 {
+			t.Errorf("background %s has empty personality tables", b.ID)
+		}
+	}
+}
+
+func TestBackgroundData_Validate(t *testing.T) {
+	feature := character.BackgroundFeature{Name: "Feature"}
+	tests := []struct {
+		name string
+		data character.BackgroundData
+	}{
+		{"duplicate id", character.BackgroundData{Default: "a", Backgrounds: []character.Background{
+			{ID: "a", Feature: feature}, {ID: "A", Feature: feature}}}},
+		{"unknown skill", character.BackgroundData{Default: "a", Backgrounds: []character.Background{
+			{ID: "a", Feature: feature, Skills: []character.Skill{"juggling"}}}}},
+		{"no feature", character.BackgroundData{Default: "a", Backgrounds: []character.Background{{ID: "a"}}}},
+		{"unknown default", character.BackgroundData{Default: "b", Backgrounds: []character.Background{
+			{ID: "a", Feature: feature}}}},
+	}
+	for _, tt := range tests {
+		if err := tt.data.Validate(); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestBackgroundData_Infer(t *testing.T) {
+	backgrounds, creation := loadAssetBackgrounds(t)
+	tests := []struct {
+		want    string
+		answers []string
+	}{
+		{"sage", []string{"Y7-A4", "Y8-A5", "Y11-A3", "Y12-A3", "Y13-A3", "Y18-A3"}},
+		{"acolyte", []string{"Y7-A3", "Y12-A5", "Y14-A5", "Y16-A5", "Y18-A5"}},
+		{"criminal", []string{"Y3-A7", "Y6-A4", "Y6-A7", "Y10-A7", "Y11-A2", "Y16-A2"}},
+		{"soldier", []string{"Y6-A1", "Y9-A1", "Y9-A2", "Y14-A1", "Y15-A2", "Y16-A7"}},
+		{"outlander", []string{"Y3-A4", "Y9-A4", "Y14-A2", "Y16-A6", "Y17-A5"}},
+	}
+	for _, tt := range tests {
+		match := backgrounds.Infer(upbringingOf(t, creation, tt.answers...))
+		if match.Background.ID != tt.want {
+			t.Errorf("Infer(%v) = %s; want %s", tt.answers, match, tt.want)
+		}
+		if match.Score <= 0 || len(match.Reasons) == 0 {
+			t.Errorf("Infer(%v) has no explanation: %s", tt.answers, match)
+		}
+	}
+
+	match := backgrounds.Infer(character.Upbringing{})
+	if match.Background.ID != backgrounds.Default || len(match.Reasons) != 1 {
+		t.Errorf("Infer(empty) = %s; want default %s", match, backgrounds.Default)
+	}
+}
+
+func TestBackground_Match(t *testing.T) {
+	b := character.Background{ID: "sage", Name: "Sage", Rules: character.BackgroundRules{
+		Flags:   map[string]int{"lore": 3, "craft": -1},
+		Answers: map[string]int{"Y7-A4": 2},
+	}}
+	u := character.Upbringing{Answers: []string{"Y7-A4", "Y9-A7"}, Flags: []string{"lore", "craft", "lore"}}
+	match := b.Match(u)
+	if match.Score != 7 {
+		t.Errorf("Score = %d; want 7", match.Score)
+	}
+	want := "Sage (score 7: craft x1 (-1), lore x2 (+6), answer Y7-A4 (+2))"
+	if match.String() != want {
+		t.Errorf("String() = %q; want %q", match, want)
+	}
+}
+
+func TestCharacter_ApplyBackground(t *testing.T) {
+	backgrounds, _ := loadAssetBackgrounds(t)
+	items := newTestItemData()
+	items.Items = append(items.Items, character.Item{ID: "holy_symbol", Name: "Holy Symbol", Type: character.ItemGear})
+	acolyte, _ := backgrounds.Get("Acolyte")
+	char := newChecksCharacter("Brother Tuck", 10, 10, character.Insight)
+
+	if err := char.ApplyBackground(acolyte, items, dice.NewScriptedRoller(1, 2, 3, 4)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.Background != "acolyte" {
+		t.Errorf("Background = %q; want acolyte", char.Background)
+	}
+	if !slices.Equal(char.Skills, []character.Skill{character.Insight, character.Religion}) {
+		t.Errorf("Skills = %v; want insight and religion once", char.Skills)
+	}
+	if !slices.Equal(char.Languages, acolyte.Languages) {
+		t.Errorf("Languages = %v; want %v", char.Languages, acolyte.Languages)
+	}
+	if char.Inventory.Quantity("holy_symbol") != 1 || char.Inventory.Quantity("rations") != 5 {
+		t.Errorf("unexpected inventory: %+v", char.Inventory.Items)
+	}
+	if char.Wallet.Get(character.GP) != 15 {
+		t.Errorf("gold = %d; want 15", char.Wallet.Get(character.GP))
+	}
+	want := character.Personality{Trait: acolyte.Traits[0], Ideal: acolyte.Ideals[1], Bond: acolyte.Bonds[2], Flaw: acolyte.Flaws[3]}
+	if char.Personality != want {
+		t.Errorf("Personality = %+v; want %+v", char.Personality, want)
+	}
+	if err := char.ApplyBackground(acolyte, items, nil); err == nil {
+		t.Error("expected error applying a second background")
+	}
+
+	data, err := json.Marshal(char)
+	if err != nil {
+		t.Fatalf("failed to marshal character: %v", err)
+	}
+	if !strings.Contains(string(data), `"background":"acolyte"`) || !strings.Contains(string(data), `"personality":{`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	soldier, _ := backgrounds.Get("soldier")
+	recruit := newChecksCharacter("Recruit", 10, 10)
+	if err := recruit.ApplyBackground(soldier, newTestItemData(), nil); err == nil || recruit.Background != "" {
+		t.Error("expected error for equipment missing from the item data")
+	}
+}
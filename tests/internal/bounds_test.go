@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func TestBounds_Clamp(t *testing.T) {
+	tests := []struct {
+		value    int
+		expected int
+		changed  bool
+	}{
+		{0, 1, true},
+		{-4, 1, true},
+		{1, 1, false},
+		{15, 15, false},
+		{20, 20, false},
+		{21, 20, true},
+	}
+	for _, tt := range tests {
+		got, changed := character.CreationBounds.Clamp(tt.value)
+		if got != tt.expected || changed != tt.changed {
+			t.Errorf("Clamp(%d) = (%d, %v); want (%d, %v)", tt.value, got, changed, tt.expected, tt.changed)
+		}
+	}
+}
+
+func TestAttributesMap_IncreaseWithin(t *testing.T) {
+	am := character.NewAttributesMap()
+	am.Set(character.Str, 19)
+	event := am.IncreaseWithin(character.Str, 3, character.CreationBounds)
+	if got := am.Get(character.Str); got != 20 {
+		t.Errorf("IncreaseWithin: expected Str=20, got %d", got)
+	}
+	if event == nil || event.Requested != 22 || event.Value != 20 {
+		t.Fatalf("IncreaseWithin: unexpected event %v", event)
+	}
+	if got := event.String(); got != "STR clamped from 22 to 20 (bounds 1-20)" {
+		t.Errorf("ClampEvent.String() = %q", got)
+	}
+	if event := am.IncreaseWithin(character.Str, 3, character.AbsoluteBounds); event != nil {
+		t.Errorf("IncreaseWithin: expected no event within absolute bounds, got %v", event)
+	}
+}
+
+func TestAttributesMap_DecreaseWithin(t *testing.T) {
+	am := character.NewAttributesMap()
+	am.Set(character.Cha, 2)
+	event := am.DecreaseWithin(character.Cha, 5, character.CreationBounds)
+	if got := am.Get(character.Cha); got != 1 {
+		t.Errorf("DecreaseWithin: expected Cha=1, got %d", got)
+	}
+	if event == nil || event.Requested != -3 {
+		t.Errorf("DecreaseWithin: unexpected event %v", event)
+	}
+}
+
+func TestAttributesMap_Clamp(t *testing.T) {
+	am := character.NewAttributesMap()
+	for _, attr := range []character.Attribute{
+		character.Str, character.Dex, character.Con,
+		character.Int, character.Wis, character.Cha,
+	} {
+		am.Set(attr, 10)
+	}
+	am.Set(character.Dex, 0)
+	am.Set(character.Wis, 25)
+	events := am.Clamp(character.CreationBounds)
+	if len(events) != 2 {
+		t.Fatalf("Clamp: expected 2 events, got %v", events)
+	}
+	if events[0].Attribute != character.Dex || events[1].Attribute != character.Wis {
+		t.Errorf("Clamp: unexpected events order %v", events)
+	}
+}
+
+func TestCharacterCreationData_Bounds(t *testing.T) {
+	data := &character.CharacterCreationData{}
+	if got := data.Bounds(); got != character.CreationBounds {
+		t.Errorf("Bounds() default = %v; want %v", got, character.CreationBounds)
+	}
+
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "bounds.json")
+	jsonContent := `{"starting_attributes": {}, "attribute_bounds": {"min": 3, "max": 18}, "questions": []}`
+	if err := os.WriteFile(jsonPath, []byte(jsonContent), 0644); err != nil {
+		t.Fatalf("failed to write temp JSON: %v", err)
+	}
+	data, err := character.LoadCharacterData(jsonPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := data.Bounds(); got != (character.Bounds{Min: 3, Max: 18}) {
+		t.Errorf("Bounds() = %v; want 3-18", got)
+	}
+}
+
+func TestParseCharacterData_Bounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		bounds   string
+		expected character.Bounds
+		err      bool
+	}{
+		{"both", `{"min": 3, "max": 18}`, character.Bounds{Min: 3, Max: 18}, false},
+		{"max only", `{"max": 16}`, character.Bounds{Min: 1, Max: 16}, false},
+		{"min only", `{"min": 5}`, character.Bounds{Min: 5, Max: 20}, false},
+		{"min above max", `{"min": 18, "max": 3}`, character.Bounds{}, true},
+		{"above absolute", `{"min": 1, "max": 40}`, character.Bounds{}, true},
+		{"negative", `{"min": -2, "max": 20}`, character.Bounds{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := `{"starting_attributes": {}, "attribute_bounds": ` + tt.bounds + `, "questions": []}`
+			data, err := character.ParseCharacterData([]byte(content))
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected error for bounds %s, got %v", tt.bounds, data.Bounds())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := data.Bounds(); got != tt.expected {
+				t.Errorf("Bounds() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCharacter_EffectiveAttributeAbsoluteBounds(t *testing.T) {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Str, 28)
+	attrs.Set(character.Dex, 2)
+	char := character.NewCharacter("Hercules", "Fighter", attrs)
+	char.Modifiers.Add(character.Modifier{Source: "belt", Layer: character.LayerItem, Attribute: character.Str, Value: 6})
+	char.Modifiers.Add(character.Modifier{Source: "curse", Layer: character.LayerTemporary, Attribute: character.Dex, Value: -4})
+	if got := char.EffectiveAttribute(character.Str); got != 30 {
+		t.Errorf("EffectiveAttribute(STR) = %d; want 30", got)
+	}
+	effective := char.EffectiveAttributes()
+	if effective.Get(character.Str) != 30 || effective.Get(character.Dex) != 1 {
+		t.Errorf("EffectiveAttributes = %v; want STR 30 and DEX 1", effective)
+	}
+}
+
+func TestCreation_PenaltyClampsAtMin(t *testing.T) {
+	content := `{
+		"starting_attributes": {"STR": 10, "DEX": 3, "CON": 10, "INT": 10, "WIS": 10, "CHA": 10},
+		"attribute_bounds": {"min": 2, "max": 18},
+		"questions": [{"year": 3, "question": "Fall?", "answers_pool": [
+			{"id": "Y3-A1", "description": "Climb", "test_attribute": "DEX", "dc": 30,
+			 "fail_penalty": {"DEX": -2}}
+		]}]
+	}`
+	data, err := character.ParseCharacterData([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	creation, _ := data.NewCreation(1)
+	step, err := creation.Answer(data.Questions[0])
+	if err != nil {
+		t.Fatalf("Answer: %v", err)
+	}
+	if step.Result.Passed || creation.Attributes.Get(character.Dex) != 2 {
+		t.Errorf("expected a failed test lowering DEX to 2, got %+v and %v", step.Result, creation.Attributes)
+	}
+	want := character.ClampEvent{Attribute: character.Dex, Requested: 1, Value: 2, Bounds: character.Bounds{Min: 2, Max: 18}}
+	if len(step.Result.Events) != 1 || step.Result.Events[0] != want {
+		t.Errorf("Events = %v; want %v", step.Result.Events, want)
+	}
+}
@@ -60,3 +60,22 @@ func TestCharacter_AttributesModification(t *testing.T) {
 		t.Errorf("expected CON=14, got %d", char.Attributes.Get(character.Con))
 	}
 }
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Aria Stormwind", "aria_stormwind"},
+		{"  Bilbo  ", "bilbo"},
+		{"", "character"},
+		{"../../etc/passwd", "etcpasswd"},
+		{"a/b", "ab"},
+		{"..", "character"},
+	}
+	for _, tt := range tests {
+		if got := character.Slug(tt.name); got != tt.expected {
+			t.Errorf("Slug(%q) = %q; want %q", tt.name, got, tt.expected)
+		}
+	}
+}
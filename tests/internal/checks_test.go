@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// newChecksCharacter returns a level 1 character with the given STR and DEX
+// scores, proficient in the given skills.
+func newChecksCharacter(name string, str int, dex int, skills ...character.Skill) *character.Character {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Str, str)
+	attrs.Set(character.Dex, dex)
+	attrs.Set(character.Wis, 12)
+	char := character.NewCharacter(name, "Fighter", attrs)
+	char.Skills = skills
+	return char
+}
+
+func TestParseSkill(t *testing.T) {
+	if skill, err := character.ParseSkill("Sleight of Hand"); err != nil || skill != character.SleightOfHand {
+		t.Errorf("ParseSkill(Sleight of Hand) = %q, %v", skill, err)
+	}
+	if _, err := character.ParseSkill("juggling"); err == nil {
+		t.Error("expected error for unknown skill")
+	}
+	if character.Stealth.Attribute() != character.Dex || character.Arcana.Attribute() != character.Int {
+		t.Error("unexpected skill attributes")
+	}
+}
+
+func TestCharacter_CheckModifier(t *testing.T) {
+	char := newChecksCharacter("Conan", 16, 12, character.Athletics)
+	if got := char.CheckModifier(character.SkillCheck(character.Athletics)); got != 5 {
+		t.Errorf("Athletics modifier = %d; want 5", got)
+	}
+	if got := char.CheckModifier(character.SkillCheck(character.Acrobatics)); got != 1 {
+		t.Errorf("Acrobatics modifier = %d; want 1", got)
+	}
+	if got := char.PassiveScore(character.SkillCheck(character.Perception)); got != 11 {
+		t.Errorf("passive Perception = %d; want 11", got)
+	}
+	char.Conditions.Add(character.ActiveCondition{Condition: character.Poisoned})
+	if got := char.PassiveScore(character.SkillCheck(character.Perception)); got != 6 {
+		t.Errorf("poisoned passive Perception = %d; want 6", got)
+	}
+}
+
+func TestContest(t *testing.T) {
+	tests := []struct {
+		name   string
+		rolls  []int
+		active bool
+	}{
+		{"active higher", []int{11, 10}, true},
+		{"tie goes to opposing", []int{10, 10}, false},
+		{"opposing higher", []int{5, 15}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Conan Athletics +5 against Bilbo Acrobatics +5.
+			conan := newChecksCharacter("Conan", 16, 10, character.Athletics)
+			bilbo := newChecksCharacter("Bilbo", 8, 16, character.Acrobatics)
+			result := character.Contest(conan, character.SkillCheck(character.Athletics), bilbo,
+				character.SkillCheck(character.Acrobatics), dice.NewScriptedRoller(tt.rolls...))
+			if result.ActiveWins() != tt.active {
+				t.Errorf("Contest = %s", result)
+			}
+			if result.Active.DC != result.Opposing.Total || result.Opposing.DC != result.Active.Total {
+				t.Errorf("expected each DC to be the other total: %+v", result)
+			}
+		})
+	}
+}
+
+func TestGrapple(t *testing.T) {
+	conan := newChecksCharacter("Conan", 16, 10, character.Athletics)
+	bilbo := newChecksCharacter("Bilbo", 8, 16)
+	// Bilbo resists with Acrobatics +3, his better check.
+	result := character.Grapple(conan, bilbo, dice.NewScriptedRoller(9, 10))
+	if result.Opposing.Skill != character.Acrobatics || !result.ActiveWins() {
+		t.Errorf("Grapple = %s", result)
+	}
+	if !bilbo.Conditions.Has(character.Grappled) || bilbo.Conditions[0].Source != "Conan" {
+		t.Errorf("expected Bilbo grappled by Conan, got %v", bilbo.Conditions)
+	}
+	result = character.Grapple(bilbo, conan, dice.NewScriptedRoller(10, 10))
+	if result.ActiveWins() || conan.Conditions.Has(character.Grappled) {
+		t.Errorf("expected Bilbo to fail grappling Conan: %s", result)
+	}
+}
+
+func TestGrapple_SameName(t *testing.T) {
+	attacker := newChecksCharacter("Goblin", 10, 10)
+	defender := newChecksCharacter("Goblin", 10, 10)
+	result := character.Grapple(attacker, defender, dice.NewScriptedRoller(5, 15))
+	if result.ActiveWins() || defender.Conditions.Has(character.Grappled) {
+		t.Errorf("expected the attacking goblin to lose: %s", result)
+	}
+}
+
+func TestContestPassive(t *testing.T) {
+	bilbo := newChecksCharacter("Bilbo", 8, 16, character.Stealth)
+	guard := newChecksCharacter("Guard", 12, 10)
+	// Bilbo Stealth +5 against the guard passive Perception 11.
+	result := character.ContestPassive(bilbo, character.SkillCheck(character.Stealth), guard,
+		character.SkillCheck(character.Perception), dice.NewScriptedRoller(6))
+	if !result.Passive || result.Opposing.Total != 11 || result.ActiveWins() {
+		t.Errorf("ContestPassive with 6 = %s", result)
+	}
+	result = character.ContestPassive(bilbo, character.SkillCheck(character.Stealth), guard,
+		character.SkillCheck(character.Perception), dice.NewScriptedRoller(7))
+	if !result.ActiveWins() || !strings.Contains(result.String(), "passive") {
+		t.Errorf("ContestPassive with 7 = %s", result)
+	}
+}
+
+func TestGroupCheck(t *testing.T) {
+	party := []*character.Character{
+		newChecksCharacter("Conan", 16, 10, character.Athletics),
+		newChecksCharacter("Bilbo", 8, 16),
+		newChecksCharacter("Gimli", 14, 10),
+		newChecksCharacter("Legolas", 12, 18),
+	}
+	check := character.SkillCheck(character.Athletics)
+	// Totals: 15, 9, 9 and 11 against DC 11.
+	result := character.GroupCheck(party, check, 11, dice.NewScriptedRoller(10, 10, 7, 10))
+	if result.Successes != 2 || !result.Success {
+		t.Errorf("GroupCheck = %s", result)
+	}
+	result = character.GroupCheck(party, check, 11, dice.NewScriptedRoller(10, 10, 7, 9))
+	if result.Successes != 1 || result.Success {
+		t.Errorf("GroupCheck = %s", result)
+	}
+	data, err := json.Marshal(result)
+	if err != nil || !strings.Contains(string(data), `"skill":"athletics"`) {
+		t.Errorf("expected loggable JSON result, got %s, %v", data, err)
+	}
+	if character.GroupCheck(nil, check, 10, nil).Success {
+		t.Error("expected empty group to fail")
+	}
+}
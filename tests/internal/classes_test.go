@@ -0,0 +1,224 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// loadAssetSpells loads the spell data shipped with the repository.
+func loadAssetSpells(t *testing.T) *character.SpellData {
+	t.Helper()
+	spells, err := character.LoadSpellData(filepath.Join("..", "..", "assets", "data", "spells.json"))
+	if err != nil {
+		t.Fatalf("failed to load spells: %v", err)
+	}
+	return spells
+}
+
+func TestCharacter_MulticlassUnmet(t *testing.T) {
+	char := newLevelingCharacter("Fighter")
+	char.Attributes.Set(character.Str, 12)
+	char.Attributes.Set(character.Dex, 12)
+	char.Attributes.Set(character.Int, 12)
+	unmet := char.MulticlassUnmet("wizard")
+	want := []string{"wizard requires INT 13 (has 12)", "fighter requires STR 13 or DEX 13"}
+	if !slices.Equal(unmet, want) {
+		t.Errorf("MulticlassUnmet(wizard) = %q; want %q", unmet, want)
+	}
+	if _, err := char.AddClassLevel("wizard", nil); err == nil || char.IsMulticlass() || char.Level != 1 {
+		t.Error("expected error multiclassing without the prerequisites")
+	}
+
+	char.Attributes.Set(character.Dex, 13)
+	if unmet := char.MulticlassUnmet("cleric"); len(unmet) != 0 {
+		t.Errorf("MulticlassUnmet(cleric) = %q; want none", unmet)
+	}
+}
+
+func TestCharacter_AddClassLevel(t *testing.T) {
+	char := newLevelingCharacter("Wizard")
+	char.LevelUp()
+	if _, err := char.AddClassLevel("rogue", nil); err == nil {
+		t.Error("expected error multiclassing into rogue without a skill")
+	}
+	if _, err := char.AddClassLevel("rogue", nil, character.Arcana); err == nil {
+		t.Error("expected error choosing a skill outside the rogue list")
+	}
+	if _, err := char.AddClassLevel("rogue", nil, character.Stealth, character.Stealth); err == nil ||
+		!strings.Contains(err.Error(), "more than once") {
+		t.Errorf("expected error choosing a skill twice, got %v", err)
+	}
+	if _, err := char.AddClassLevel("wizzard", nil); err == nil || char.IsMulticlass() || char.Level != 2 {
+		t.Errorf("expected error multiclassing into an unknown class, got %v", err)
+	}
+	result, err := char.AddClassLevel("Rogue", nil, character.Stealth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Level != 3 || result.ClassLevel != 1 || result.HitPoints != 7 {
+		t.Errorf("AddClassLevel() = %+v; want level 3, rogue 1 and 7 hit points", result)
+	}
+	if char.ClassString() != "wizard 2 / rogue 1" {
+		t.Errorf("ClassString() = %q; want wizard 2 / rogue 1", char.ClassString())
+	}
+	if !char.IsProficient(character.Stealth) || !slices.Contains(char.Tools, "thieves' tools") ||
+		!slices.Equal(char.Proficiencies, []string{"light armor"}) {
+		t.Errorf("unexpected proficiencies: skills %v, tools %v, other %v", char.Skills, char.Tools, char.Proficiencies)
+	}
+	if _, err := char.AddClassLevel("rogue", nil, character.Acrobatics); err == nil {
+		t.Error("expected error choosing skills for an existing class")
+	}
+
+	// Wizard 6 then 4, rogue 5, with +2 CON each level.
+	if char.HitPoints.Max != 21 || char.HitPoints.Current != 21 {
+		t.Errorf("HitPoints = %s; want 21/21", char.HitPoints)
+	}
+	if got := char.HitDicePool(); !slices.Equal(got, []int{8, 6, 6}) {
+		t.Errorf("HitDicePool() = %v; want [8 6 6]", got)
+	}
+	if got := character.ClassMaxHitPoints(char.ClassLevels(), char.EffectiveAttribute(character.Con)); got != 21 {
+		t.Errorf("ClassMaxHitPoints() = %d; want 21", got)
+	}
+}
+
+func TestCharacter_AddClassLevelImprovement(t *testing.T) {
+	char := newLevelingCharacter("Fighter")
+	for range 3 {
+		char.LevelUp()
+	}
+	char.Improvements = 0
+	for range 3 {
+		if _, err := char.AddClassLevel("cleric", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if char.Improvements != 0 {
+		t.Errorf("Improvements = %d at cleric 3; want 0", char.Improvements)
+	}
+	result, _ := char.AddClassLevel("cleric", nil)
+	if !result.Improvement || char.Improvements != 1 || char.Level != 8 {
+		t.Errorf("AddClassLevel() = %+v; want an improvement at cleric 4", result)
+	}
+}
+
+func TestIsImprovementLevel(t *testing.T) {
+	tests := []struct {
+		job    string
+		levels []int
+	}{
+		{"wizard", []int{4, 8, 12, 16, 19}},
+		{"Fighter", []int{4, 6, 8, 12, 14, 16, 19}},
+		{"rogue", []int{4, 8, 10, 12, 16, 19}},
+	}
+	for _, tt := range tests {
+		var levels []int
+		for level := 1; level <= character.MaxLevel; level++ {
+			if character.IsImprovementLevel(tt.job, level) {
+				levels = append(levels, level)
+			}
+		}
+		if !slices.Equal(levels, tt.levels) {
+			t.Errorf("IsImprovementLevel(%s) levels = %v; want %v", tt.job, levels, tt.levels)
+		}
+	}
+}
+
+func TestClassSpellSlots(t *testing.T) {
+	spells := loadAssetSpells(t)
+	tests := []struct {
+		classes []character.ClassLevel
+		slots   [character.MaxSpellLevel]int
+		pact    [character.MaxSpellLevel]int
+	}{
+		{[]character.ClassLevel{{Job: "wizard", Level: 3}, {Job: "cleric", Level: 2}},
+			character.FullCasterSlots(5), [character.MaxSpellLevel]int{}},
+		{[]character.ClassLevel{{Job: "paladin", Level: 5}},
+			character.GetSpellSlots(character.HalfCaster, 5), [character.MaxSpellLevel]int{}},
+		{[]character.ClassLevel{{Job: "paladin", Level: 5}, {Job: "ranger", Level: 3}, {Job: "fighter", Level: 2}},
+			character.FullCasterSlots(3), [character.MaxSpellLevel]int{}},
+		{[]character.ClassLevel{{Job: "paladin", Level: 6}, {Job: "warlock", Level: 2}},
+			character.GetSpellSlots(character.HalfCaster, 6), character.GetSpellSlots(character.PactCaster, 2)},
+		{[]character.ClassLevel{{Job: "fighter", Level: 4}}, [character.MaxSpellLevel]int{}, [character.MaxSpellLevel]int{}},
+	}
+	for _, tt := range tests {
+		slots, pact := character.ClassSpellSlots(tt.classes, spells)
+		if slots != tt.slots || pact != tt.pact {
+			t.Errorf("ClassSpellSlots(%v) = %v, %v; want %v, %v", tt.classes, slots, pact, tt.slots, tt.pact)
+		}
+	}
+}
+
+func TestCharacter_MulticlassSpellcasting(t *testing.T) {
+	spells := loadAssetSpells(t)
+	char := newLevelingCharacter("Fighter")
+	char.LevelUp()
+	if _, err := char.AddClassLevel("wizard", spells); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.Spellcasting == nil || char.Spellcasting.Class != "wizard" || char.Spellcasting.Ability != character.Int {
+		t.Fatalf("unexpected spellcasting: %+v", char.Spellcasting)
+	}
+	if char.Spellcasting.Slots.Max != character.FullCasterSlots(1) {
+		t.Errorf("slots = %v; want those of a level 1 full caster", char.Spellcasting.Slots.Max)
+	}
+	// INT modifier +2 and wizard level 1.
+	if char.PreparedLimit() != 3 {
+		t.Errorf("PreparedLimit() = %d; want 3", char.PreparedLimit())
+	}
+	wizardSpells := spells.ClassSpells("wizard", 1)
+	if len(wizardSpells) == 0 {
+		t.Fatal("no wizard spells in the asset data")
+	}
+	if err := char.LearnSpell(wizardSpells[0]); err != nil {
+		t.Errorf("unexpected error learning a wizard spell: %v", err)
+	}
+}
+
+func TestCharacter_PactMulticlassRest(t *testing.T) {
+	spells := loadAssetSpells(t)
+	attrs := character.NewAttributesMap()
+	for attr := range attrs {
+		attrs.Set(attr, 14)
+	}
+	char := character.NewCharacter("Vex", "Paladin", attrs)
+	char.InitHitPoints()
+	if err := char.InitSpellcasting(spells); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	char.LevelUp()
+	char.AddClassLevel("warlock", spells)
+	char.AddClassLevel("warlock", spells)
+	if char.Spellcasting.PactSlots == nil || char.Spellcasting.PactSlots.Max[0] != 2 {
+		t.Fatalf("PactSlots = %v; want 2 first level slots", char.Spellcasting.PactSlots)
+	}
+	slots := char.Spellcasting.Slots.Max[0]
+	for range slots + 2 {
+		if err := char.Spellcasting.Slots.Use(1); err != nil {
+			char.Spellcasting.PactSlots.Use(1)
+		}
+	}
+	char.RestoreSpellSlots(false)
+	if char.Spellcasting.PactSlots.Available(1) != 2 || char.Spellcasting.Slots.Available(1) != 0 {
+		t.Errorf("after a short rest: slots %s, pact %s; want only pact slots back",
+			&char.Spellcasting.Slots, char.Spellcasting.PactSlots)
+	}
+	char.RestoreSpellSlots(true)
+	if char.Spellcasting.Slots.Available(1) != slots {
+		t.Errorf("after a long rest: slots %s; want every slot back", &char.Spellcasting.Slots)
+	}
+	if !strings.Contains(char.ClassString(), "warlock 2") {
+		t.Errorf("ClassString() = %q; want warlock 2", char.ClassString())
+	}
+
+	char.TakeDamage(20)
+	before := char.HitPoints.Current
+	char.ShortRest(1, dice.NewScriptedRoller(10))
+	if got := char.HitPoints.Current - before; got != 12 {
+		t.Errorf("short rest healed %d; want 12 from the paladin d10", got)
+	}
+}
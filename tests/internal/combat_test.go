@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/combat"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func newGoblin(name string) *combat.Combatant {
+	return combat.NewCombatant(name, "monsters", 15, 7, 2,
+		combat.Attack{Name: "Scimitar", Bonus: 4, Damage: "1d6+2", DamageType: "slashing"})
+}
+
+func newFighter() *combat.Combatant {
+	return combat.NewCombatant("Boromir", "party", 16, 12, 1,
+		combat.Attack{Name: "Longsword", Bonus: 5, Damage: "1d8+3", DamageType: "slashing"})
+}
+
+func TestEncounter_RollInitiative(t *testing.T) {
+	goblin := newGoblin("Goblin")
+	fighter := newFighter()
+	// Fighter rolls 12 (13), goblin rolls 11 (13): tie broken by the bonus.
+	enc := combat.NewEncounter(dice.NewScriptedRoller(12, 11), fighter, goblin)
+	enc.RollInitiative()
+	if enc.Combatants[0] != goblin {
+		t.Errorf("expected goblin first on initiative tie, got %v", enc.Combatants[0])
+	}
+	if got := len(enc.Log.Filter(combat.EventInitiative)); got != 2 {
+		t.Errorf("expected 2 initiative events, got %d", got)
+	}
+}
+
+func TestEncounter_Attack(t *testing.T) {
+	tests := []struct {
+		name     string
+		rolls    []int
+		hit      bool
+		critical bool
+		damage   int
+	}{
+		{"miss", []int{9}, false, false, 0},
+		{"hit", []int{12, 4}, true, false, 6},
+		{"natural one", []int{1}, false, false, 0},
+		{"critical", []int{20, 2, 3}, true, true, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fighter := newFighter()
+			goblin := newGoblin("Goblin")
+			goblin.HitPoints, goblin.MaxHitPoints = 30, 30
+			enc := combat.NewEncounter(dice.NewScriptedRoller(tt.rolls...), fighter, goblin)
+			result := enc.Attack(goblin, fighter, goblin.Attacks[0])
+			if result.Hit != tt.hit || result.Critical != tt.critical || result.Damage != tt.damage {
+				t.Errorf("Attack = %+v; want hit=%v critical=%v damage=%d", result, tt.hit, tt.critical, tt.damage)
+			}
+			if fighter.HitPoints != 12-tt.damage {
+				t.Errorf("fighter HP = %d; want %d", fighter.HitPoints, 12-tt.damage)
+			}
+		})
+	}
+}
+
+func TestEncounter_Run(t *testing.T) {
+	fighter := newFighter()
+	goblin1 := newGoblin("Goblin 1")
+	goblin2 := newGoblin("Goblin 2")
+	roller := dice.NewScriptedRoller(
+		15, 5, 3, // initiative: fighter 16, goblin 1 7, goblin 2 5
+		18, 6, // round 1: fighter hits goblin 1 for 9, goblin 1 is down
+		2,        // goblin 2 misses
+		20, 4, 4, // round 2: fighter crits goblin 2 for 11
+	)
+	enc := combat.NewEncounter(roller, fighter, goblin1, goblin2)
+	winner := enc.Run(10)
+	if winner != "party" {
+		t.Errorf("Run winner = %q; want party\n%s", winner, enc.Log)
+	}
+	if enc.Round != 2 {
+		t.Errorf("Run rounds = %d; want 2", enc.Round)
+	}
+	if len(enc.Downed) != 2 || len(enc.Combatants) != 1 {
+		t.Errorf("expected 2 downed and 1 standing, got %d and %d", len(enc.Downed), len(enc.Combatants))
+	}
+	if got := len(enc.Log.Filter(combat.EventDown)); got != 2 {
+		t.Errorf("expected 2 down events, got %d", got)
+	}
+	if last := enc.Log[len(enc.Log)-1]; last.Type != combat.EventEnd {
+		t.Errorf("expected last event to be end, got %v", last)
+	}
+	if roller.Remaining() != 0 {
+		t.Errorf("expected all scripted rolls used, %d left", roller.Remaining())
+	}
+}
+
+func TestFromCharacter(t *testing.T) {
+	data := newTestItemData()
+	data.Items[0].Damage = "1d8"
+	data.Items[0].Name = "Longsword"
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Str, 16)
+	attrs.Set(character.Dex, 14)
+	attrs.Set(character.Con, 14)
+	char := character.NewCharacter("Aragorn", "Fighter", attrs)
+	char.Inventory.Add("longsword", 1)
+	char.Inventory.Add("shield", 1)
+	char.Inventory.Equip(data, "longsword", "")
+	char.Inventory.Equip(data, "shield", "")
+
+	c := combat.FromCharacter(char, data, "party")
+	if c.ArmorClass != 12 || c.HitPoints != 12 || c.InitiativeBonus != 2 {
+		t.Errorf("FromCharacter = %v", c)
+	}
+	attack := c.Attacks[0]
+	if attack.Name != "Longsword" || attack.Bonus != 5 || attack.Damage != "1d8+3" {
+		t.Errorf("FromCharacter attack = %v", attack)
+	}
+	c.TakeDamage(5)
+	if char.HitPoints.Current != 7 {
+		t.Errorf("expected character HP to follow combatant, got %v", char.HitPoints)
+	}
+
+	unarmed := combat.WeaponAttack(character.NewCharacter("Monk", "Monk", attrs), data)
+	if unarmed.Name != "Unarmed Strike" || unarmed.Damage != "4" {
+		t.Errorf("WeaponAttack unarmed = %v", unarmed)
+	}
+}
+
+func TestEncounter_Conditions(t *testing.T) {
+	goblin := newGoblin("Goblin")
+	fighter := newFighter()
+	enc := combat.NewEncounter(dice.NewScriptedRoller(18, 3, 2, 12), fighter, goblin)
+	if err := enc.ApplyCondition(goblin, character.ActiveCondition{Condition: character.Prone}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := enc.ApplyCondition(fighter, character.ActiveCondition{Condition: character.Poisoned}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Poisoned attacker against a prone target: the modes cancel out.
+	result := enc.Attack(fighter, goblin, fighter.Attacks[0])
+	if result.Mode != character.Normal || !result.Hit {
+		t.Errorf("Attack = %+v", result)
+	}
+
+	fighter.RemoveCondition(character.Poisoned)
+	goblin.RemoveCondition(character.Prone)
+	goblin.AddCondition(character.ActiveCondition{Condition: character.Stunned, Save: "CON", SaveDC: 10})
+	goblin.HitPoints = goblin.MaxHitPoints
+	enc = combat.NewEncounter(dice.NewScriptedRoller(12), fighter, goblin)
+	enc.TakeTurn(goblin)
+	if len(enc.Log.Filter(combat.EventSkipTurn)) != 1 {
+		t.Errorf("expected stunned goblin to skip its turn:\n%s", enc.Log)
+	}
+	if goblin.HasCondition(character.Stunned) || len(enc.Log.Filter(combat.EventRecover)) != 1 {
+		t.Errorf("expected goblin to recover from stun:\n%s", enc.Log)
+	}
+}
@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func newConditionsCharacter() *character.Character {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Str, 14)
+	attrs.Set(character.Dex, 12)
+	attrs.Set(character.Con, 16)
+	attrs.Set(character.Wis, 12)
+	return character.NewCharacter("Frodo", "Rogue", attrs)
+}
+
+func TestCombineRollModes(t *testing.T) {
+	tests := []struct {
+		modes    []character.RollMode
+		expected character.RollMode
+	}{
+		{nil, character.Normal},
+		{[]character.RollMode{character.Advantage, character.Normal}, character.Advantage},
+		{[]character.RollMode{character.Disadvantage, character.Disadvantage}, character.Disadvantage},
+		{[]character.RollMode{character.Advantage, character.Disadvantage, character.Disadvantage}, character.Normal},
+	}
+	for _, tt := range tests {
+		if got := character.CombineRollModes(tt.modes...); got != tt.expected {
+			t.Errorf("CombineRollModes(%v) = %s; want %s", tt.modes, got, tt.expected)
+		}
+	}
+}
+
+func TestRollD20(t *testing.T) {
+	if roll, rolls := character.RollD20(dice.NewScriptedRoller(7, 15), character.Advantage); roll != 15 || len(rolls) != 2 {
+		t.Errorf("RollD20 with advantage = %d %v; want 15", roll, rolls)
+	}
+	if roll, _ := character.RollD20(dice.NewScriptedRoller(7, 15), character.Disadvantage); roll != 7 {
+		t.Errorf("RollD20 with disadvantage = %d; want 7", roll)
+	}
+	if roll, rolls := character.RollD20(dice.NewScriptedRoller(7), character.Normal); roll != 7 || len(rolls) != 1 {
+		t.Errorf("RollD20 = %d %v; want 7", roll, rolls)
+	}
+}
+
+func TestCharacter_PoisonedCheck(t *testing.T) {
+	char := newConditionsCharacter()
+	result := char.AbilityCheck(character.Str, 12, dice.NewScriptedRoller(10))
+	if !result.Success || result.Total != 12 || result.Mode != character.Normal {
+		t.Errorf("AbilityCheck = %v", result)
+	}
+	if err := char.Conditions.Add(character.ActiveCondition{Condition: character.Poisoned, Source: "Giant Spider"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result = char.AbilityCheck(character.Str, 12, dice.NewScriptedRoller(18, 4))
+	if result.Success || result.Roll != 4 || result.Mode != character.Disadvantage {
+		t.Errorf("poisoned AbilityCheck = %v", result)
+	}
+	// Poison does not affect saving throws.
+	if save := char.SavingThrow(character.Con, 10, dice.NewScriptedRoller(7)); !save.Success || save.Mode != character.Normal {
+		t.Errorf("poisoned SavingThrow = %v", save)
+	}
+}
+
+func TestCharacter_AutoFailSaves(t *testing.T) {
+	char := newConditionsCharacter()
+	char.Conditions.Add(character.ActiveCondition{Condition: character.Stunned})
+	roller := dice.NewScriptedRoller(20)
+	if save := char.SavingThrow(character.Dex, 5, roller); save.Success || !save.AutoFail {
+		t.Errorf("stunned DEX save = %v; want automatic failure", save)
+	}
+	if save := char.SavingThrow(character.Con, 5, roller); !save.Success || save.AutoFail {
+		t.Errorf("stunned CON save = %v; want success", save)
+	}
+	if !char.Conditions.IsIncapacitated() {
+		t.Error("expected stunned character to be incapacitated")
+	}
+	if char.Conditions.RollMode(character.Attacked, character.Str) != character.Advantage {
+		t.Error("expected advantage on attacks against a stunned character")
+	}
+}
+
+func TestConditions_AddAndRemove(t *testing.T) {
+	var conditions character.Conditions
+	if err := conditions.Add(character.ActiveCondition{Condition: "sleepy"}); err == nil {
+		t.Error("expected error for unknown condition")
+	}
+	if err := conditions.Add(character.ActiveCondition{Condition: character.Frightened, SaveDC: 12, Save: "LUCK"}); err == nil {
+		t.Error("expected error for invalid save attribute")
+	}
+	conditions.Add(character.ActiveCondition{Condition: character.Frightened, Source: "Dragon", Duration: 1})
+	conditions.Add(character.ActiveCondition{Condition: character.Frightened, Source: "Dragon", Duration: 3})
+	conditions.Add(character.ActiveCondition{Condition: character.Frightened, Source: "Lich"})
+	if len(conditions) != 2 || conditions[0].Duration != 3 {
+		t.Errorf("unexpected conditions %v", conditions)
+	}
+	if removed := conditions.Remove(character.Frightened); removed != 2 || conditions.Has(character.Frightened) {
+		t.Errorf("Remove(frightened) = %d, left %v", removed, conditions)
+	}
+	if c, err := character.ParseCondition(" Prone "); err != nil || c != character.Prone {
+		t.Errorf("ParseCondition(Prone) = %q, %v", c, err)
+	}
+}
+
+func TestConditions_Exhaustion(t *testing.T) {
+	var conditions character.Conditions
+	conditions.Add(character.ActiveCondition{Condition: character.Exhaustion})
+	if conditions.RollMode(character.AbilityCheck, character.Str) != character.Disadvantage {
+		t.Error("expected disadvantage on checks at exhaustion 1")
+	}
+	if conditions.RollMode(character.SavingThrow, character.Str) != character.Normal {
+		t.Error("expected normal saves at exhaustion 1")
+	}
+	conditions.Add(character.ActiveCondition{Condition: character.Exhaustion, Level: 2})
+	if conditions.ExhaustionLevel() != 3 || conditions.RollMode(character.AttackRoll, character.Str) != character.Disadvantage {
+		t.Errorf("expected disadvantage on attacks at exhaustion 3, got %v", conditions)
+	}
+	conditions.Add(character.ActiveCondition{Condition: character.Exhaustion, Level: 5})
+	if conditions.ExhaustionLevel() != character.MaxExhaustion {
+		t.Errorf("ExhaustionLevel() = %d; want %d", conditions.ExhaustionLevel(), character.MaxExhaustion)
+	}
+	if left := conditions.ReduceExhaustion(1); left != 5 {
+		t.Errorf("ReduceExhaustion(1) = %d; want 5", left)
+	}
+	if left := conditions.ReduceExhaustion(10); left != 0 || conditions.Has(character.Exhaustion) {
+		t.Errorf("ReduceExhaustion(10) = %d, left %v", left, conditions)
+	}
+}
+
+func TestCharacter_EndTurn(t *testing.T) {
+	char := newConditionsCharacter()
+	char.Conditions.Add(character.ActiveCondition{Condition: character.Paralyzed, Source: "Hold Person", Save: "WIS", SaveDC: 13})
+	char.Conditions.Add(character.ActiveCondition{Condition: character.Prone, Duration: 2})
+
+	changes := char.EndTurn(dice.NewScriptedRoller(5))
+	if len(changes) != 0 || !char.Conditions.Has(character.Paralyzed) || char.Conditions[1].Duration != 1 {
+		t.Errorf("first EndTurn ended %v, left %v", changes, char.Conditions)
+	}
+	changes = char.EndTurn(dice.NewScriptedRoller(15))
+	if len(changes) != 2 || changes[0].Save == nil || changes[1].Save != nil {
+		t.Fatalf("second EndTurn ended %v", changes)
+	}
+	if len(char.Conditions) != 0 {
+		t.Errorf("expected no conditions left, got %v", char.Conditions)
+	}
+}
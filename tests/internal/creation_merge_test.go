@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func newMergeBase() *character.CharacterCreationData {
+	return &character.CharacterCreationData{
+		StartingAttributes: map[string]int{"STR": 10, "DEX": 10},
+		Questions: []character.Question{
+			{Year: 3, Question: "Toddler?", Answers: []character.Answer{
+				{AnswerID: "Y3-A1", Description: "Lift", Test: "STR", DC: 5},
+				{AnswerID: "Y3-A2", Description: "Watch", Test: "WIS", DC: 6},
+			}},
+			{Year: 4, Question: "Child?", Answers: []character.Answer{
+				{AnswerID: "Y4-A1", Description: "Run", Test: "DEX", DC: 7},
+			}},
+		},
+		Hash: "base",
+	}
+}
+
+func answerIDs(q character.Question) []string {
+	var ids []string
+	for _, a := range q.Answers {
+		ids = append(ids, a.AnswerID)
+	}
+	return ids
+}
+
+func TestMergeCreationData(t *testing.T) {
+	homebrew := &character.CharacterCreationData{
+		StartingAttributes: map[string]int{"STR": 12},
+		AttributeBounds:    &character.Bounds{Min: 3, Max: 18},
+		Questions: []character.Question{
+			{Year: 3, Answers: []character.Answer{
+				{AnswerID: "Y3-A1", Description: "Lift more", Test: "STR", DC: 7},
+				{AnswerID: "Y3-A2", Disabled: true},
+				{AnswerID: "Y3-H1", Description: "Sing", Test: "CHA", DC: 5},
+			}},
+			{Year: 2, Question: "Baby?", Answers: []character.Answer{
+				{AnswerID: "Y2-H1", Description: "Cry", Test: "CON", DC: 3},
+			}},
+			{Year: 4, Disabled: true},
+		},
+	}
+	house := &character.CharacterCreationData{
+		Questions: []character.Question{
+			{Year: 3, Answers: []character.Answer{
+				{AnswerID: "Y3-A1", Description: "Lift most", Test: "STR", DC: 9},
+				{AnswerID: "Y9-A9", Disabled: true},
+			}},
+		},
+	}
+
+	single, report := character.MergeCreationData(character.CreationPack{Name: "core", Data: newMergeBase()})
+	if single.Hash != "base" || len(report.Events) != 0 || len(report.Conflicts) != 0 {
+		t.Errorf("single pack merge = hash %q, report %+v", single.Hash, report)
+	}
+
+	merged, report := character.MergeCreationData(
+		character.CreationPack{Name: "core", Data: newMergeBase()},
+		character.CreationPack{Name: "homebrew", Data: homebrew},
+		character.CreationPack{Name: "house", Data: house},
+	)
+	if merged.StartingAttributes["STR"] != 12 || merged.StartingAttributes["DEX"] != 10 {
+		t.Errorf("StartingAttributes = %v, want STR 12 and DEX 10", merged.StartingAttributes)
+	}
+	if merged.Bounds() != (character.Bounds{Min: 3, Max: 18}) {
+		t.Errorf("Bounds = %v, want 3-18", merged.Bounds())
+	}
+	if len(merged.Questions) != 2 || merged.Questions[0].Year != 2 || merged.Questions[1].Year != 3 {
+		t.Fatalf("Questions = %+v, want years 2 and 3", merged.Questions)
+	}
+	year3 := merged.Questions[1]
+	if year3.Question != "Toddler?" || !slices.Equal(answerIDs(year3), []string{"Y3-A1", "Y3-H1"}) {
+		t.Errorf("question 3 = %q %v, want Toddler? with Y3-A1 and Y3-H1", year3.Question, answerIDs(year3))
+	}
+	if year3.Answers[0].Description != "Lift most" {
+		t.Errorf("Y3-A1 = %q, want the house override", year3.Answers[0].Description)
+	}
+	if merged.Hash == "" || merged.Hash == "base" {
+		t.Errorf("Hash = %q, want the hash of the merged data", merged.Hash)
+	}
+
+	var conflicts []string
+	for _, c := range report.Conflicts {
+		conflicts = append(conflicts, c.Entry)
+	}
+	if !slices.Equal(conflicts, []string{"answer Y9-A9", "answer Y3-A1"}) {
+		t.Errorf("Conflicts = %v, want Y9-A9 not defined and Y3-A1 changed twice", report.Conflicts)
+	}
+	if got := report.Conflicts[1].Packs; !slices.Equal(got, []string{"homebrew", "house"}) {
+		t.Errorf("Y3-A1 conflict packs = %v", got)
+	}
+	if len(report.Events) != 9 {
+		t.Errorf("Events = %v, want 9 events", report.Events)
+	}
+
+	again, _ := character.MergeCreationData(
+		character.CreationPack{Name: "core", Data: newMergeBase()},
+		character.CreationPack{Name: "homebrew", Data: homebrew},
+		character.CreationPack{Name: "house", Data: house},
+	)
+	if again.Hash != merged.Hash {
+		t.Errorf("merge is not deterministic: hash %s and %s", merged.Hash, again.Hash)
+	}
+}
+
+func TestMergeCreationData_MovedAnswer(t *testing.T) {
+	moved := &character.CharacterCreationData{
+		Questions: []character.Question{
+			{Year: 4, Answers: []character.Answer{{AnswerID: "Y3-A2", Description: "Watch later", Test: "WIS", DC: 8}}},
+		},
+	}
+	merged, report := character.MergeCreationData(
+		character.CreationPack{Name: "core", Data: newMergeBase()},
+		character.CreationPack{Name: "homebrew", Data: moved},
+	)
+	if !slices.Equal(answerIDs(merged.Questions[0]), []string{"Y3-A1"}) ||
+		!slices.Equal(answerIDs(merged.Questions[1]), []string{"Y4-A1", "Y3-A2"}) {
+		t.Errorf("Questions = %+v, want Y3-A2 moved to year 4", merged.Questions)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Entry != "answer Y3-A2" {
+		t.Errorf("Conflicts = %v, want Y3-A2 moved", report.Conflicts)
+	}
+}
+
+func TestMergeCreationData_SamePackChanges(t *testing.T) {
+	homebrew := &character.CharacterCreationData{
+		Questions: []character.Question{
+			{Year: 3, Answers: []character.Answer{
+				{AnswerID: "Y3-A1", Description: "Lift more", Test: "STR", DC: 7},
+				{AnswerID: "Y3-A1", Disabled: true},
+			}},
+		},
+	}
+	merged, report := character.MergeCreationData(
+		character.CreationPack{Name: "core", Data: newMergeBase()},
+		character.CreationPack{Name: "homebrew", Data: homebrew},
+	)
+	if !slices.Equal(answerIDs(merged.Questions[0]), []string{"Y3-A2"}) {
+		t.Errorf("Questions = %+v, want Y3-A1 disabled", merged.Questions)
+	}
+	if len(report.Events) != 2 || len(report.Conflicts) != 0 {
+		t.Errorf("report = %+v, want 2 events and no conflicts", report)
+	}
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/combat"
+	"github.com/jrecuero/DandD/internal/damage"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func TestParseType(t *testing.T) {
+	if dt, err := damage.ParseType(" Fire "); err != nil || dt != damage.Fire {
+		t.Errorf("ParseType(Fire) = %q, %v", dt, err)
+	}
+	if _, err := damage.ParseType("sonic"); err == nil {
+		t.Error("expected error for unknown damage type")
+	}
+	if len(damage.Types()) != 13 {
+		t.Errorf("expected 13 damage types, got %d", len(damage.Types()))
+	}
+}
+
+func TestDefenses_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		defenses damage.Defenses
+		dmg      damage.Damage
+		expected int
+	}{
+		{"no defenses", damage.Defenses{}, damage.Of(7, damage.Fire), 7},
+		{"resistance rounds down", damage.Defenses{Resistances: []damage.Type{damage.Fire}}, damage.Of(7, damage.Fire), 3},
+		{"vulnerability", damage.Defenses{Vulnerabilities: []damage.Type{damage.Fire}}, damage.Of(7, damage.Fire), 14},
+		{"resistance before vulnerability", damage.Defenses{Resistances: []damage.Type{damage.Fire}, Vulnerabilities: []damage.Type{damage.Fire}}, damage.Of(7, damage.Fire), 6},
+		{"immunity", damage.Defenses{Immunities: []damage.Type{damage.Poison}, Vulnerabilities: []damage.Type{damage.Poison}}, damage.Of(7, damage.Poison), 0},
+		{"resistances do not stack", damage.Defenses{Resistances: []damage.Type{damage.Cold, damage.Cold}}, damage.Of(8, damage.Cold), 4},
+		{"other type", damage.Defenses{Resistances: []damage.Type{damage.Cold}}, damage.Of(8, damage.Fire), 8},
+		{"components apply on their own", damage.Defenses{Resistances: []damage.Type{damage.Fire}, Immunities: []damage.Type{damage.Poison}},
+			damage.Damage{{Amount: 9, Type: damage.Slashing}, {Amount: 5, Type: damage.Fire}, {Amount: 6, Type: damage.Poison}}, 11},
+		{"negative amount", damage.Defenses{}, damage.Of(-3, damage.Fire), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.defenses.Apply(tt.dmg)
+			if result.Total != tt.expected || len(result.Components) != len(tt.dmg) {
+				t.Errorf("Apply(%v) = %v; want %d", tt.dmg, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAbsorbTemp(t *testing.T) {
+	if temp, remaining := damage.AbsorbTemp(7, 5); temp != 0 || remaining != 2 {
+		t.Errorf("AbsorbTemp(7, 5) = %d, %d; want 0, 2", temp, remaining)
+	}
+	if temp, remaining := damage.AbsorbTemp(3, 5); temp != 2 || remaining != 0 {
+		t.Errorf("AbsorbTemp(3, 5) = %d, %d; want 2, 0", temp, remaining)
+	}
+}
+
+func TestCharacter_TakeTypedDamage(t *testing.T) {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Con, 14)
+	char := character.NewCharacter("Bruenor", "Fighter", attrs)
+	char.InitHitPoints()
+	char.Defenses = damage.Defenses{Resistances: []damage.Type{damage.Poison}}
+	char.AddTempHitPoints(4)
+
+	// 6 slashing + 9 poison halved to 4 = 10, of which 4 go to temporary HP.
+	result := char.TakeTypedDamage(damage.Damage{{Amount: 6, Type: damage.Slashing}, {Amount: 9, Type: damage.Poison}}, false)
+	if result.Total != 10 || result.TempAbsorbed != 4 || result.Taken != 6 || char.HitPoints.Current != 6 {
+		t.Errorf("TakeTypedDamage = %+v; HP %v", result, char.HitPoints)
+	}
+}
+
+func TestEncounter_TypedDamage(t *testing.T) {
+	skeleton := newTestMonster()
+	skeleton.Name = "Skeleton"
+	skeleton.HitDice = "2d8+4"
+	skeleton.DamageVulnerabilities = []string{"bludgeoning"}
+	skeleton.DamageImmunities = []string{"poison"}
+	target := combat.FromMonster(skeleton, "monsters", nil)
+	fighter := combat.NewCombatant("Bruenor", "party", 16, 12, 0, combat.Attack{
+		Name: "Venomous Warhammer", Bonus: 5, Damage: "1d8+3", DamageType: damage.Bludgeoning,
+		Extra: []combat.DamageRoll{{Damage: "1d6", Type: damage.Poison}},
+	})
+	// Hit with 15, 1d8 rolls 2 and 1d6 rolls 6: (2+3) x2 bludgeoning + 0 poison.
+	enc := combat.NewEncounter(dice.NewScriptedRoller(15, 2, 6), fighter, target)
+	result := enc.Attack(fighter, target, fighter.Attacks[0])
+	if result.Result.Total != 10 || result.Damage != 10 || target.HitPoints != 3 {
+		t.Errorf("Attack = %+v; target %v", result, target)
+	}
+	if !result.Result.Components[0].Vulnerable || !result.Result.Components[1].Immune {
+		t.Errorf("unexpected components %v", result.Result.Components)
+	}
+}
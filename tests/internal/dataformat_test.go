@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/dataformat"
+)
+
+func TestFromPath(t *testing.T) {
+	tests := map[string]dataformat.Format{
+		"data.json":      dataformat.JSON,
+		"data.YAML":      dataformat.YAML,
+		"dir/data.yml":   dataformat.YAML,
+		"data.toml":      dataformat.TOML,
+		"data.json.toml": dataformat.TOML,
+	}
+	for path, want := range tests {
+		if got, err := dataformat.FromPath(path); err != nil || got != want {
+			t.Errorf("FromPath(%q) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	if _, err := dataformat.FromPath("data.txt"); err == nil {
+		t.Error("FromPath: expected error for unknown extension")
+	}
+}
+
+func TestLoadCharacterData_Formats(t *testing.T) {
+	source := filepath.Join("..", "..", "assets", "data", "character_creation.json")
+	want, err := character.LoadCharacterData(source)
+	if err != nil {
+		t.Fatalf("LoadCharacterData: %v", err)
+	}
+	content, err := dataformat.ReadFile(source)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"creation.yaml", "creation.toml", "creation.json"} {
+		fpath := filepath.Join(dir, name)
+		if err := dataformat.WriteFile(fpath, content); err != nil {
+			t.Fatalf("WriteFile(%s): %v", name, err)
+		}
+		got, err := character.LoadCharacterData(fpath)
+		if err != nil {
+			t.Fatalf("LoadCharacterData(%s): %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("LoadCharacterData(%s) differs from the JSON data", name)
+		}
+	}
+}
+
+func TestLoadCharacterData_Sparse(t *testing.T) {
+	dense := `{"starting_attributes": {"STR": 10}, "questions": [{"year": 1, "question": "Q", "answers_pool": [
+		{"id": "A", "description": "D", "attribute_rewards": {"STR": 1, "DEX": 0}, "test_attribute": "STR", "dc": 5,
+		 "fail_penalty": {"STR": 0}}]}]}`
+	sparse := `
+starting_attributes: {STR: 10}
+questions:
+  - year: 1
+    question: Q
+    answers_pool:
+      - {id: A, description: D, attribute_rewards: {STR: 1}, test_attribute: STR, dc: 5}
+`
+	dir := t.TempDir()
+	jsonPath, yamlPath := filepath.Join(dir, "dense.json"), filepath.Join(dir, "sparse.yml")
+	if err := os.WriteFile(jsonPath, []byte(dense), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(yamlPath, []byte(sparse), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := character.LoadCharacterData(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadCharacterData(json): %v", err)
+	}
+	fromYAML, err := character.LoadCharacterData(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadCharacterData(yaml): %v", err)
+	}
+	if fromJSON.Hash != fromYAML.Hash || !reflect.DeepEqual(fromJSON.Questions[0].Answers[0].Increases, fromYAML.Questions[0].Answers[0].Increases) {
+		t.Errorf("sparse YAML = %+v, want %+v", fromYAML, fromJSON)
+	}
+	if len(fromJSON.Questions[0].Answers[0].FailEffect) != 0 {
+		t.Errorf("FailEffect = %v, want the zero entries removed", fromJSON.Questions[0].Answers[0].FailEffect)
+	}
+}
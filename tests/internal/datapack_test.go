@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/datapack"
+)
+
+func TestEmbedded(t *testing.T) {
+	pack := datapack.Embedded()
+	if !pack.Available() {
+		t.Fatal("embedded pack is not available")
+	}
+	content, _, err := datapack.SearchPath{pack}.ReadFile("character_creation.json")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	data, err := character.ParseCharacterData(content)
+	if err != nil {
+		t.Fatalf("ParseCharacterData: %v", err)
+	}
+	if len(data.Questions) == 0 || data.Hash == "" {
+		t.Errorf("embedded creation data has %d questions and hash %q", len(data.Questions), data.Hash)
+	}
+}
+
+func TestSearchPath(t *testing.T) {
+	override := datapack.Pack{Name: "override", FS: fstest.MapFS{
+		"feats.json":  {Data: []byte(`{"feats": []}`)},
+		"spells.yaml": {Data: []byte("spells: []\n")},
+		"README.md":   {Data: []byte("notes")},
+	}}
+	missing := datapack.Dir("missing", t.TempDir()+"/missing")
+	path := datapack.SearchPath{missing, override, datapack.Embedded()}
+
+	content, pack, err := path.ReadFile("feats.json")
+	if err != nil || pack.Name != "override" || string(content) != `{"feats": []}` {
+		t.Errorf("ReadFile(feats.json) = %q, %s, %v; want override content", content, pack, err)
+	}
+	if _, pack, err := path.ReadFile("items.json"); err != nil || pack.Name != "embedded" {
+		t.Errorf("ReadFile(items.json) = %s, %v; want embedded", pack, err)
+	}
+	if _, _, err := path.ReadFile("unknown.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile(unknown.json) error = %v, want fs.ErrNotExist", err)
+	}
+	if missing.Available() {
+		t.Error("missing pack is available")
+	}
+
+	for _, f := range path.Files(".") {
+		if f.Name == "feats.json" && (f.Pack.Name != "override" || len(f.Overridden) != 1) {
+			t.Errorf("Files: feats.json = %+v, want override overriding embedded", f)
+		}
+		if f.Name == "items.json" && (f.Pack.Name != "embedded" || len(f.Overridden) != 0) {
+			t.Errorf("Files: items.json = %+v, want embedded", f)
+		}
+	}
+
+	files, err := path.ReadAll("feats.json")
+	if err != nil || len(files) != 2 || files[0].Pack.Name != "override" || files[1].Pack.Name != "embedded" {
+		t.Errorf("ReadAll(feats.json) = %d files, %v; want override then embedded", len(files), err)
+	}
+	if _, err := path.ReadAll("unknown.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadAll(unknown.json) error = %v, want fs.ErrNotExist", err)
+	}
+
+	if content, pack, err := path.ReadFile("spells.json"); err != nil || pack.Name != "override" || string(content) != `{"spells":[]}` {
+		t.Errorf("ReadFile(spells.json) = %q, %s, %v; want override YAML as JSON", content, pack, err)
+	}
+	for _, f := range path.Files(".") {
+		if f.Name == "README.md" || f.Name == "spells.json" {
+			t.Errorf("Files: unexpected %+v", f)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func TestDiff(t *testing.T) {
+	old := newLevelingCharacter("Wizard")
+	old.Inventory.Add("dagger", 1)
+	old.Inventory.Add("rations", 5)
+	old.Skills = []character.Skill{character.Arcana}
+	spells := newTestSpellData()
+	old.InitSpellcasting(spells)
+	bolt, _ := spells.GetSpell("fire_bolt")
+	old.LearnSpell(bolt)
+
+	new, err := old.Reconstruct(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := character.Diff(old, new); !d.IsEmpty() {
+		t.Errorf("Diff() of a copy = %s; want none", d)
+	}
+
+	new.LevelUp()
+	new.Attributes.Increase(character.Int, 1)
+	new.Modifiers.Add(character.Modifier{Source: "Fox's Cunning", Layer: character.LayerTemporary, Attribute: character.Int, Value: 2, Duration: 10})
+	new.Skills = append(new.Skills, character.History)
+	new.Feats = []string{"keen_mind"}
+	new.Inventory.Remove("rations", 2)
+	new.Inventory.Add("shield", 1)
+	new.Inventory.Equip(newTestItemData(), "dagger", character.SlotMainHand)
+	missile, _ := spells.GetSpell("magic_missile")
+	new.LearnSpell(missile)
+	new.PrepareSpell(missile)
+
+	d := character.Diff(old, new)
+	if !slices.Contains(d.Summary, character.ValueDiff{Name: "level", Old: "1", New: "2"}) {
+		t.Errorf("Summary = %v; want the level change", d.Summary)
+	}
+	wantAttributes := []character.ValueDiff{{Name: "INT", Old: "14", New: "15"}, {Name: "INT effective", Old: "14", New: "17"}}
+	if !slices.Equal(d.Attributes, wantAttributes) {
+		t.Errorf("Attributes = %v; want %v", d.Attributes, wantAttributes)
+	}
+	if !slices.Equal(d.Modifiers.Added, []string{"Fox's Cunning (temporary): INT +2 for 10 rounds"}) || len(d.Modifiers.Removed) != 0 {
+		t.Errorf("Modifiers = %s", d.Modifiers)
+	}
+	if len(d.Proficiencies) != 2 || d.Proficiencies[0].String() != "skills: +history" || d.Proficiencies[1].String() != "feats: +keen_mind" {
+		t.Errorf("Proficiencies = %v", d.Proficiencies)
+	}
+	wantEquipment := []character.ValueDiff{
+		{Name: "rations", Old: "5", New: "3"},
+		{Name: "shield", Old: "", New: "1"},
+		{Name: "equipped main_hand", Old: "", New: "dagger"},
+	}
+	if !slices.Equal(d.Equipment, wantEquipment) {
+		t.Errorf("Equipment = %v; want %v", d.Equipment, wantEquipment)
+	}
+	if len(d.Spells) != 2 || d.Spells[0].String() != "known: +magic_missile" || d.Spells[1].String() != "prepared: +magic_missile" {
+		t.Errorf("Spells = %v", d.Spells)
+	}
+	if len(d.SpellSlots) != 1 || d.SpellSlots[0].Name != "slots" {
+		t.Errorf("SpellSlots = %v", d.SpellSlots)
+	}
+
+	report := d.String()
+	for _, want := range []string{"Summary:\n", "  level: 1 -> 2\n", "Equipment:\n  rations: 5 -> 3\n  shield: none -> 1\n", "Spells:\n"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("String() does not contain %q:\n%s", want, report)
+		}
+	}
+
+	back := character.Diff(new, old)
+	if !slices.Equal(back.Modifiers.Removed, d.Modifiers.Added) || back.Equipment[0] != (character.ValueDiff{Name: "rations", Old: "3", New: "5"}) {
+		t.Errorf("reverse Diff() = %s", back)
+	}
+}
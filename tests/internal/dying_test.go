@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/combat"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// newDyingCharacter returns a fighter with 12 hit points dropped to zero.
+func newDyingCharacter(t *testing.T) *character.Character {
+	t.Helper()
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Con, 14)
+	attrs.Set(character.Wis, 10)
+	char := character.NewCharacter("Boromir", "Fighter", attrs)
+	char.InitHitPoints()
+	char.TakeDamage(15)
+	if char.State != character.Dying || !char.Conditions.Has(character.Unconscious) {
+		t.Fatalf("expected dying unconscious character, got %s %v", char.State, char.Conditions)
+	}
+	return char
+}
+
+func TestCharacter_DeathSaves(t *testing.T) {
+	tests := []struct {
+		name       string
+		rolls      []int
+		state      character.LifeState
+		hitPoints  int
+		deathSaves character.DeathSaves
+	}{
+		{"one success", []int{10}, character.Dying, 0, character.DeathSaves{Successes: 1}},
+		{"one failure", []int{9}, character.Dying, 0, character.DeathSaves{Failures: 1}},
+		{"natural 1", []int{1}, character.Dying, 0, character.DeathSaves{Failures: 2}},
+		{"three successes", []int{12, 15, 10}, character.Stable, 0, character.DeathSaves{}},
+		{"three failures", []int{2, 15, 5, 9}, character.Dead, 0, character.DeathSaves{Successes: 1, Failures: 3}},
+		{"natural 1 after failure", []int{5, 1}, character.Dead, 0, character.DeathSaves{Failures: 3}},
+		{"natural 20", []int{5, 20}, character.Conscious, 1, character.DeathSaves{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newDyingCharacter(t)
+			roller := dice.NewScriptedRoller(tt.rolls...)
+			for range tt.rolls {
+				if _, err := char.RollDeathSave(roller); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if char.State != tt.state || char.HitPoints.Current != tt.hitPoints || char.DeathSaves != tt.deathSaves {
+				t.Errorf("got %s with %d HP and %v; want %s with %d HP and %v",
+					char.State, char.HitPoints.Current, char.DeathSaves, tt.state, tt.hitPoints, tt.deathSaves)
+			}
+			if tt.state == character.Dying {
+				return
+			}
+			if _, err := char.RollDeathSave(roller); err == nil {
+				t.Error("expected error rolling a death save when not dying")
+			}
+		})
+	}
+}
+
+func TestCharacter_MassiveDamage(t *testing.T) {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Con, 14)
+	char := character.NewCharacter("Boromir", "Fighter", attrs)
+	char.InitHitPoints()
+	// 12 HP: 24 damage leaves 12 remaining, equal to the maximum.
+	char.TakeDamage(24)
+	if !char.IsDead() || char.Conditions.Has(character.Unconscious) {
+		t.Errorf("expected instant death, got %s %v", char.State, char.Conditions)
+	}
+	if char.Heal(10) != 0 || char.HitPoints.Current != 0 {
+		t.Error("expected dead character not to heal")
+	}
+}
+
+func TestCharacter_DamageWhileDown(t *testing.T) {
+	char := newDyingCharacter(t)
+	char.TakeDamage(3)
+	if char.DeathSaves.Failures != 1 {
+		t.Errorf("expected one failure from damage, got %v", char.DeathSaves)
+	}
+	char.TakeHit(3, true)
+	if !char.IsDead() {
+		t.Errorf("expected critical hit to kill, got %s %v", char.State, char.DeathSaves)
+	}
+
+	char = newDyingCharacter(t)
+	char.TakeDamage(12)
+	if !char.IsDead() {
+		t.Errorf("expected massive damage while down to kill, got %s", char.State)
+	}
+}
+
+func TestCharacter_Stabilize(t *testing.T) {
+	char := newDyingCharacter(t)
+	healer := character.NewCharacter("Aragorn", "Ranger", character.AttributesMap{character.Wis: 14})
+	result, err := char.Stabilize(healer, dice.NewScriptedRoller(7))
+	if err != nil || result.Success || char.State != character.Dying {
+		t.Errorf("Stabilize with 7 + 2 = %v, %v, state %s", result, err, char.State)
+	}
+	result, _ = char.Stabilize(healer, dice.NewScriptedRoller(8))
+	if !result.Success || char.State != character.Stable || !char.Conditions.Has(character.Unconscious) {
+		t.Errorf("Stabilize with 8 + 2 = %v, state %s %v", result, char.State, char.Conditions)
+	}
+	if _, err := char.Stabilize(healer, nil); err == nil {
+		t.Error("expected error stabilizing a stable character")
+	}
+	char.TakeDamage(1)
+	if char.State != character.Dying || char.DeathSaves.Failures != 1 {
+		t.Errorf("expected damage to restart dying, got %s %v", char.State, char.DeathSaves)
+	}
+	char.Heal(4)
+	if char.State != character.Conscious || char.Conditions.Has(character.Unconscious) || char.DeathSaves.Failures != 0 {
+		t.Errorf("expected healing to revive, got %s %v %v", char.State, char.Conditions, char.DeathSaves)
+	}
+}
+
+func TestCharacter_DyingSaveLoad(t *testing.T) {
+	char := newDyingCharacter(t)
+	char.RollDeathSave(dice.NewScriptedRoller(3))
+	path := filepath.Join(t.TempDir(), "boromir.json")
+	if err := character.SaveCharacter(path, char); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err := character.LoadCharacter(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.State != character.Dying || loaded.DeathSaves.Failures != 1 {
+		t.Errorf("loaded %s %v", loaded.State, loaded.DeathSaves)
+	}
+}
+
+func TestEncounter_RollDeathSaves(t *testing.T) {
+	char := newDyingCharacter(t)
+	char.Heal(1)
+	fighter := combat.FromCharacter(char, newTestItemData(), "party")
+	goblin := newGoblin("Goblin")
+	ally := newGoblin("Ally")
+	ally.Side = "party"
+	// Goblin hits for 1d6+2 = 3, then the fighter rolls a natural 20 death save.
+	enc := combat.NewEncounter(dice.NewScriptedRoller(15, 1, 20), fighter, goblin, ally)
+	enc.Attack(goblin, fighter, goblin.Attacks[0])
+	if len(enc.Downed) != 1 || !char.IsDying() {
+		t.Fatalf("expected dying fighter to be downed, got %v", enc.Downed)
+	}
+	enc.RollDeathSaves()
+	if len(enc.Downed) != 0 || char.HitPoints.Current != 1 || fighter.HitPoints != 1 {
+		t.Errorf("expected fighter back in the fight:\n%s", enc.Log)
+	}
+	if len(enc.Log.Filter(combat.EventDeathSave)) != 1 {
+		t.Errorf("expected one death save event:\n%s", enc.Log)
+	}
+}
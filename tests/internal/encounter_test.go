@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/encounter"
+	"github.com/jrecuero/DandD/internal/monster"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func newTestParty(levels ...int) *encounter.Party {
+	party := encounter.NewParty()
+	for _, level := range levels {
+		c := character.NewCharacter("Hero", "Fighter", character.NewAttributesMap())
+		c.Level = level
+		party.Members = append(party.Members, c)
+	}
+	return party
+}
+
+func newCRMonster(name string, cr string) *monster.Monster {
+	m := newTestMonster()
+	m.Name = name
+	m.ChallengeRating = cr
+	return m
+}
+
+func TestPartyThresholds(t *testing.T) {
+	party := newTestParty(3, 3, 3, 2)
+	expected := encounter.Thresholds{Easy: 275, Medium: 550, Hard: 825, Deadly: 1400}
+	if got := party.Thresholds(); got != expected {
+		t.Errorf("Thresholds() = %v; want %v", got, expected)
+	}
+	if got := encounter.LevelThresholds(25); got != encounter.LevelThresholds(20) {
+		t.Errorf("LevelThresholds(25) = %v; want level 20 thresholds", got)
+	}
+}
+
+func TestMultiplier(t *testing.T) {
+	tests := []struct {
+		monsters  int
+		partySize int
+		expected  float64
+	}{
+		{0, 4, 0},
+		{1, 4, 1},
+		{2, 4, 1.5},
+		{3, 4, 2},
+		{6, 4, 2},
+		{7, 4, 2.5},
+		{11, 4, 3},
+		{15, 4, 4},
+		{1, 2, 1.5},
+		{15, 1, 5},
+		{1, 6, 0.5},
+		{4, 6, 1.5},
+	}
+	for _, tt := range tests {
+		if got := encounter.Multiplier(tt.monsters, tt.partySize); got != tt.expected {
+			t.Errorf("Multiplier(%d, %d) = %v; want %v", tt.monsters, tt.partySize, got, tt.expected)
+		}
+	}
+}
+
+func TestRate(t *testing.T) {
+	party := newTestParty(1, 1, 1, 1)
+	goblin := newCRMonster("Goblin", "1/4")
+	tests := []struct {
+		name     string
+		monsters []*monster.Monster
+		adjusted int
+		expected encounter.Difficulty
+	}{
+		{"none", nil, 0, encounter.Trivial},
+		{"one goblin", []*monster.Monster{goblin}, 50, encounter.Trivial},
+		{"two goblins", []*monster.Monster{goblin, goblin}, 150, encounter.Easy},
+		{"three goblins", []*monster.Monster{goblin, goblin, goblin}, 300, encounter.Hard},
+		{"ogre", []*monster.Monster{newCRMonster("Ogre", "2")}, 450, encounter.Deadly},
+		{"rat", []*monster.Monster{newCRMonster("Rat", "1/8"), goblin}, 112, encounter.Easy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rating := encounter.Rate(party, tt.monsters)
+			if rating.AdjustedXP != tt.adjusted || rating.Difficulty != tt.expected {
+				t.Errorf("Rate() = %v; want %d adjusted XP, %s", rating, tt.adjusted, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	if d, err := encounter.ParseDifficulty(" Hard "); err != nil || d != encounter.Hard {
+		t.Errorf("ParseDifficulty(Hard) = %v, %v", d, err)
+	}
+	if _, err := encounter.ParseDifficulty("impossible"); err == nil {
+		t.Error("expected error for unknown difficulty")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	bestiary := &monster.Bestiary{Monsters: []monster.Monster{
+		*newCRMonster("Goblin", "1/4"),
+		*newCRMonster("Ogre", "2"),
+		*newCRMonster("Dragon", "8"),
+	}}
+	bestiary.Monsters[2].Environments = []string{"mountain"}
+	party := newTestParty(1, 1, 1, 1)
+
+	// Medium budget is 200 XP: only goblins fit, and a third goblin would
+	// raise the adjusted XP to 300.
+	e, err := encounter.Build(bestiary, party, encounter.Options{Difficulty: encounter.Medium, Environment: "forest"}, dice.NewScriptedRoller(1, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.String() != "2x Goblin" || e.Rating.Difficulty != encounter.Easy {
+		t.Errorf("Build() = %s (%v)", e, e.Rating)
+	}
+
+	// Deadly budget is 400 XP: the ogre does not fit and the cap stops at one goblin.
+	e, err = encounter.Build(bestiary, party, encounter.Options{Difficulty: encounter.Deadly, MaxMonsters: 1}, dice.NewScriptedRoller(1))
+	if err != nil || len(e.Monsters) != 1 || e.Rating.AdjustedXP > 400 {
+		t.Errorf("Build() with one monster = %v, %v", e, err)
+	}
+
+	if _, err := encounter.Build(bestiary, party, encounter.Options{Difficulty: encounter.Easy, MinCR: 1}, nil); err == nil {
+		t.Error("expected error when no monster fits the budget")
+	}
+}
+
+func TestLoadParty(t *testing.T) {
+	dir := t.TempDir()
+	var files []string
+	for i, name := range []string{"Aragorn", "Gandalf"} {
+		c := character.NewCharacter(name, "Fighter", character.NewAttributesMap())
+		c.Level = i + 2
+		path := filepath.Join(dir, name+".json")
+		if err := character.SaveCharacter(path, c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		files = append(files, path)
+	}
+	party, err := encounter.LoadParty(files...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if party.Size() != 2 || party.Thresholds().Easy != 125 {
+		t.Errorf("unexpected party thresholds %v", party.Thresholds())
+	}
+	if _, err := encounter.LoadParty(); err == nil {
+		t.Error("expected error for empty party")
+	}
+	if _, err := encounter.LoadParty(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// loadAssetFeats loads the feat data shipped with the repository.
+func loadAssetFeats(t *testing.T) *character.FeatData {
+	t.Helper()
+	data, err := character.LoadFeatData(filepath.Join("..", "..", "assets", "data", "feats.json"))
+	if err != nil {
+		t.Fatalf("failed to load feats: %v", err)
+	}
+	return data
+}
+
+func TestFeatData_Assets(t *testing.T) {
+	data := loadAssetFeats(t)
+	ritual, ok := data.Get("Ritual_Caster")
+	if !ok {
+		t.Fatal("ritual_caster not found")
+	}
+	if ritual.Prerequisites.AnyAttributes.Get(character.Wis) != 13 {
+		t.Errorf("unexpected prerequisites: %+v", ritual.Prerequisites)
+	}
+	athlete, _ := data.Get("athlete")
+	if len(athlete.Effects.IncreaseChoice) != 2 || athlete.Effects.IncreaseChoice[1] != character.Dex {
+		t.Errorf("unexpected athlete choices: %v", athlete.Effects.IncreaseChoice)
+	}
+}
+
+func TestFeatData_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		data character.FeatData
+	}{
+		{"duplicate id", character.FeatData{Feats: []character.Feat{{ID: "a"}, {ID: "A"}}}},
+		{"unknown skill", character.FeatData{Feats: []character.Feat{{ID: "a",
+			Prerequisites: character.FeatPrerequisites{Skills: []character.Skill{"juggling"}}}}}},
+		{"negative increase", character.FeatData{Feats: []character.Feat{{ID: "a",
+			Effects: character.FeatEffects{Increases: character.AttributesMap{character.Str: -1}}}}}},
+		{"save choice without attributes", character.FeatData{Feats: []character.Feat{{ID: "a",
+			Effects: character.FeatEffects{SaveChoice: true}}}}},
+	}
+	for _, tt := range tests {
+		if err := tt.data.Validate(); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestFeat_Unmet(t *testing.T) {
+	data := loadAssetFeats(t)
+	char := newLevelingCharacter("Rogue")
+	char.Attributes.Set(character.Dex, 12)
+	char.Attributes.Set(character.Int, 10)
+	char.Attributes.Set(character.Wis, 12)
+
+	locksmith, _ := data.Get("master_locksmith")
+	unmet := locksmith.Unmet(char)
+	want := []string{"requires DEX 13 (has 12)", "requires proficiency with thieves' tools"}
+	if strings.Join(unmet, "; ") != strings.Join(want, "; ") {
+		t.Errorf("Unmet = %q; want %q", unmet, want)
+	}
+	err := char.CanTakeFeat(locksmith)
+	if err == nil || !strings.Contains(err.Error(), "requires DEX 13 (has 12)") {
+		t.Errorf("CanTakeFeat error = %v", err)
+	}
+
+	ritual, _ := data.Get("ritual_caster")
+	if unmet := ritual.Unmet(char); len(unmet) != 1 || unmet[0] != "requires INT 13 or WIS 13" {
+		t.Errorf("Unmet = %q", unmet)
+	}
+	char.Attributes.Set(character.Wis, 13)
+	if err := char.CanTakeFeat(ritual); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tracker, _ := data.Get("expert_tracker")
+	if unmet := tracker.Unmet(char); len(unmet) != 2 || unmet[1] != "requires level 4 (is 1)" {
+		t.Errorf("Unmet = %q", unmet)
+	}
+	warCaster, _ := data.Get("war_caster")
+	if unmet := warCaster.Unmet(char); len(unmet) != 1 || !strings.Contains(unmet[0], "cast at least one spell") {
+		t.Errorf("Unmet = %q", unmet)
+	}
+	armorMaster, _ := data.Get("medium_armor_master")
+	if unmet := armorMaster.Unmet(char); len(unmet) != 1 || unmet[0] != "requires proficiency with medium armor" {
+		t.Errorf("Unmet = %q", unmet)
+	}
+	char.Proficiencies = append(char.Proficiencies, "medium armor")
+	if err := char.CanTakeFeat(armorMaster); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCharacter_AddFeat(t *testing.T) {
+	data := loadAssetFeats(t)
+	char := newLevelingCharacter("Wizard")
+
+	linguist, _ := data.Get("linguist")
+	if err := char.AddFeat(linguist, character.Str); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.Attributes.Get(character.Int) != 15 || len(char.Languages) != 3 || !char.HasFeat("LINGUIST") {
+		t.Errorf("unexpected character after linguist: %+v", char)
+	}
+	if err := char.AddFeat(linguist, character.Str); err == nil {
+		t.Error("expected error taking the same feat twice")
+	}
+
+	athlete, _ := data.Get("athlete")
+	if err := char.AddFeat(athlete, character.Cha); err == nil {
+		t.Error("expected error for an attribute outside the feat choices")
+	}
+	char.Attributes.Set(character.Dex, 20)
+	if err := char.AddFeat(athlete, character.Dex); err == nil {
+		t.Error("expected error raising DEX above 20")
+	}
+	if char.HasFeat("athlete") || char.Attributes.Get(character.Dex) != 20 {
+		t.Error("failed feat must not change the character")
+	}
+
+	custom := &character.Feat{ID: "giant_blood", Name: "Giant Blood", Effects: character.FeatEffects{
+		Modifiers: []character.Modifier{{Layer: character.LayerRacial, Attribute: character.Str, Value: 2}},
+	}}
+	if err := char.AddFeat(custom, character.Str); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.EffectiveAttribute(character.Str) != 16 || char.Modifiers[0].Source != "Giant Blood" {
+		t.Errorf("unexpected modifiers: %+v", char.Modifiers)
+	}
+}
+
+func TestCharacter_AddFeat_Resilient(t *testing.T) {
+	data := loadAssetFeats(t)
+	char := newLevelingCharacter("Wizard")
+	resilient, _ := data.Get("resilient")
+	if char.IsSaveProficient(character.Con) {
+		t.Fatal("wizard must not start proficient in CON saves")
+	}
+	if err := char.AddFeat(resilient, character.Con); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !char.IsSaveProficient(character.Con) || char.IsSaveProficient(character.Str) {
+		t.Errorf("Saves = %v, want CON", char.Saves)
+	}
+	want := character.AbilityModifier(char.EffectiveAttribute(character.Con)) + char.ProficiencyBonus()
+	if got := char.SaveModifier(character.Con); got != want {
+		t.Errorf("SaveModifier(CON) = %d; want %d", got, want)
+	}
+}
+
+func TestCharacter_TakeFeat(t *testing.T) {
+	data := loadAssetFeats(t)
+	char := newLevelingCharacter("Fighter")
+	durable, _ := data.Get("durable")
+	if err := char.TakeFeat(durable, character.Str); err == nil {
+		t.Error("expected error without an improvement available")
+	}
+	for range 3 {
+		char.LevelUp()
+	}
+	if err := char.TakeFeat(durable, character.Str); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.Improvements != 0 || char.Attributes.Get(character.Con) != 15 {
+		t.Errorf("unexpected character: %d improvements, %s", char.Improvements, char.Attributes)
+	}
+
+	out, err := json.Marshal(char)
+	if err != nil {
+		t.Fatalf("failed to marshal character: %v", err)
+	}
+	if !strings.Contains(string(out), `"feats":["durable"]`) {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func TestMaxHitPoints(t *testing.T) {
+	tests := []struct {
+		job      string
+		level    int
+		con      int
+		expected int
+	}{
+		{"Fighter", 1, 14, 12},
+		{"Fighter", 3, 14, 28},
+		{"Wizard", 1, 10, 6},
+		{"Barbarian", 2, 16, 25},
+		{"Tinker", 1, 10, 8},
+		{"Wizard", 2, 1, 2},
+	}
+	for _, tt := range tests {
+		if got := character.MaxHitPoints(tt.job, tt.level, tt.con); got != tt.expected {
+			t.Errorf("MaxHitPoints(%s, %d, %d) = %d; want %d", tt.job, tt.level, tt.con, got, tt.expected)
+		}
+	}
+}
+
+func TestCharacter_DamageAndHealing(t *testing.T) {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Con, 14)
+	char := character.NewCharacter("Boromir", "Fighter", attrs)
+	char.InitHitPoints()
+	if char.HitPoints.Current != 12 || char.HitPoints.Max != 12 {
+		t.Fatalf("InitHitPoints: got %v", char.HitPoints)
+	}
+	char.AddTempHitPoints(5)
+	char.AddTempHitPoints(3)
+	if char.HitPoints.Temp != 5 {
+		t.Errorf("AddTempHitPoints: expected 5 temp HP, got %d", char.HitPoints.Temp)
+	}
+	if got := char.TakeDamage(8); got != 3 {
+		t.Errorf("TakeDamage(8) = %d; want 3 after temp HP", got)
+	}
+	if got := char.HitPoints.String(); got != "9/12" {
+		t.Errorf("HitPoints.String() = %q; want 9/12", got)
+	}
+	if got := char.Heal(10); got != 3 {
+		t.Errorf("Heal(10) = %d; want 3", got)
+	}
+	char.TakeDamage(50)
+	if !char.IsDown() || char.HitPoints.Current != 0 {
+		t.Errorf("expected character down at 0 HP, got %v", char.HitPoints)
+	}
+}
+
+func TestCharacter_ArmorClass(t *testing.T) {
+	maxDex := 2
+	data := &character.ItemData{Items: []character.Item{
+		{ID: "leather", Type: character.ItemArmor, Slot: character.SlotBody, ArmorClass: 11},
+		{ID: "scale", Type: character.ItemArmor, Slot: character.SlotBody, ArmorClass: 14, MaxDexBonus: &maxDex},
+		{ID: "shield", Type: character.ItemShield, Slot: character.SlotOffHand, ArmorClass: 2},
+	}}
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Dex, 18)
+	char := character.NewCharacter("Legolas", "Ranger", attrs)
+	if got := char.ArmorClass(data); got != 14 {
+		t.Errorf("unarmored ArmorClass = %d; want 14", got)
+	}
+	for _, id := range []string{"leather", "scale", "shield"} {
+		char.Inventory.Add(id, 1)
+	}
+	char.Inventory.Equip(data, "leather", "")
+	if got := char.ArmorClass(data); got != 15 {
+		t.Errorf("leather ArmorClass = %d; want 15", got)
+	}
+	char.Inventory.Unequip(character.SlotBody)
+	char.Inventory.Equip(data, "scale", "")
+	char.Inventory.Equip(data, "shield", "")
+	if got := char.ArmorClass(data); got != 18 {
+		t.Errorf("scale and shield ArmorClass = %d; want 18", got)
+	}
+}
@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func newTestItemData() *character.ItemData {
+	return &character.ItemData{
+		Items: []character.Item{
+			{ID: "longsword", Type: character.ItemWeapon, Weight: 3, Slot: character.SlotMainHand},
+			{ID: "dagger", Type: character.ItemWeapon, Weight: 1, Slot: character.SlotMainHand},
+			{ID: "greataxe", Type: character.ItemWeapon, Weight: 7, Slot: character.SlotMainHand, TwoHanded: true},
+			{ID: "shield", Type: character.ItemShield, Weight: 6, Slot: character.SlotOffHand},
+			{ID: "plate", Type: character.ItemArmor, Weight: 65, Slot: character.SlotBody},
+			{ID: "rations", Type: character.ItemConsumable, Weight: 2},
+			{ID: "ring", Type: character.ItemGear, Slot: character.SlotRing, RequiresAttunement: true},
+			{ID: "cloak", Type: character.ItemGear, Weight: 1, Slot: character.SlotCloak, RequiresAttunement: true},
+			{ID: "amulet", Type: character.ItemGear, Weight: 1, Slot: character.SlotNeck, RequiresAttunement: true},
+			{ID: "gauntlets", Type: character.ItemGear, Weight: 2, Slot: character.SlotHands, RequiresAttunement: true},
+		},
+		StartingEquipment: map[string][]character.InventoryEntry{
+			"fighter": {{ItemID: "longsword", Quantity: 1}, {ItemID: "rations", Quantity: 5}},
+		},
+	}
+}
+
+func TestInventory_AddRemove(t *testing.T) {
+	var inv character.Inventory
+	inv.Add("rations", 3)
+	inv.Add("rations", 2)
+	if got := inv.Quantity("rations"); got != 5 {
+		t.Errorf("Quantity(rations) = %d; want 5", got)
+	}
+	if err := inv.Remove("rations", 6); err == nil {
+		t.Error("Remove: expected error when removing more than carried")
+	}
+	if err := inv.Remove("rations", 5); err != nil {
+		t.Errorf("Remove: unexpected error %v", err)
+	}
+	if len(inv.Items) != 0 {
+		t.Errorf("expected empty inventory, got %v", inv.Items)
+	}
+	if err := inv.Remove("torch", 1); err == nil {
+		t.Error("Remove: expected error for item not carried")
+	}
+	inv.Add("rations", 2)
+	for _, quantity := range []int{0, -3} {
+		if err := inv.Remove("rations", quantity); err == nil {
+			t.Errorf("Remove(%d): expected error for quantity not positive", quantity)
+		}
+	}
+	if got := inv.Quantity("rations"); got != 2 {
+		t.Errorf("Quantity(rations) = %d; want 2", got)
+	}
+}
+
+func TestInventory_RemoveEquipped(t *testing.T) {
+	data := newTestItemData()
+	var inv character.Inventory
+	inv.Add("dagger", 3)
+	if err := inv.Equip(data, "dagger", ""); err != nil {
+		t.Fatalf("Equip(dagger): unexpected error %v", err)
+	}
+	if err := inv.Equip(data, "dagger", character.SlotOffHand); err != nil {
+		t.Fatalf("Equip(dagger, off hand): unexpected error %v", err)
+	}
+	if err := inv.Remove("dagger", 1); err != nil {
+		t.Fatalf("Remove(dagger): unexpected error %v", err)
+	}
+	if inv.EquippedIn(character.SlotMainHand) != "dagger" || inv.EquippedIn(character.SlotOffHand) != "dagger" {
+		t.Errorf("expected both daggers still equipped, got %v", inv.Equipped)
+	}
+	if err := inv.Remove("dagger", 1); err != nil {
+		t.Fatalf("Remove(dagger): unexpected error %v", err)
+	}
+	if inv.EquippedIn(character.SlotMainHand) != "dagger" || inv.EquippedIn(character.SlotOffHand) != "" {
+		t.Errorf("expected only the main hand dagger equipped, got %v", inv.Equipped)
+	}
+	if err := inv.Remove("dagger", 1); err != nil {
+		t.Fatalf("Remove(dagger): unexpected error %v", err)
+	}
+	if inv.IsEquipped("dagger") {
+		t.Errorf("expected no dagger equipped, got %v", inv.Equipped)
+	}
+}
+
+func TestInventory_Equip(t *testing.T) {
+	data := newTestItemData()
+	var inv character.Inventory
+	inv.Add("longsword", 1)
+	inv.Add("dagger", 1)
+	inv.Add("shield", 1)
+	inv.Add("greataxe", 1)
+	inv.Add("rations", 1)
+
+	if err := inv.Equip(data, "plate", ""); err == nil {
+		t.Error("Equip: expected error for item not carried")
+	}
+	if err := inv.Equip(data, "rations", ""); err == nil {
+		t.Error("Equip: expected error for item without slot")
+	}
+	if err := inv.Equip(data, "longsword", ""); err != nil {
+		t.Fatalf("Equip(longsword): unexpected error %v", err)
+	}
+	if err := inv.Equip(data, "longsword", character.SlotOffHand); err == nil {
+		t.Error("Equip: expected error equipping the only longsword twice")
+	}
+	if err := inv.Equip(data, "dagger", ""); err == nil {
+		t.Error("Equip: expected error for taken main hand")
+	}
+	if err := inv.Equip(data, "dagger", character.SlotOffHand); err != nil {
+		t.Errorf("Equip(dagger, off hand): unexpected error %v", err)
+	}
+	if err := inv.Equip(data, "shield", character.SlotBody); err == nil {
+		t.Error("Equip: expected error for wrong slot")
+	}
+	if got := inv.Unequip(character.SlotOffHand); got != "dagger" {
+		t.Errorf("Unequip(off hand) = %q; want dagger", got)
+	}
+	inv.Unequip(character.SlotMainHand)
+	if err := inv.Equip(data, "greataxe", ""); err != nil {
+		t.Fatalf("Equip(greataxe): unexpected error %v", err)
+	}
+	if err := inv.Equip(data, "shield", ""); err == nil {
+		t.Error("Equip: expected error for off hand blocked by two-handed weapon")
+	}
+	if err := inv.Remove("greataxe", 1); err != nil {
+		t.Fatalf("Remove(greataxe): unexpected error %v", err)
+	}
+	if inv.IsEquipped("greataxe") {
+		t.Error("expected greataxe to be unequipped after removal")
+	}
+	if err := inv.Equip(data, "shield", ""); err != nil {
+		t.Errorf("Equip(shield): unexpected error %v", err)
+	}
+}
+
+func TestInventory_Attune(t *testing.T) {
+	data := newTestItemData()
+	var inv character.Inventory
+	for _, id := range []string{"ring", "cloak", "amulet", "gauntlets", "longsword"} {
+		inv.Add(id, 1)
+	}
+	if err := inv.Attune(data, "longsword"); err == nil {
+		t.Error("Attune: expected error for item without attunement")
+	}
+	for _, id := range []string{"ring", "cloak", "amulet"} {
+		if err := inv.Attune(data, id); err != nil {
+			t.Fatalf("Attune(%s): unexpected error %v", id, err)
+		}
+	}
+	if err := inv.Attune(data, "ring"); err == nil {
+		t.Error("Attune: expected error for item already attuned")
+	}
+	if err := inv.Attune(data, "gauntlets"); err == nil {
+		t.Error("Attune: expected error when limit is reached")
+	}
+	if !inv.Unattune("cloak") {
+		t.Error("Unattune(cloak): expected true")
+	}
+	if err := inv.Attune(data, "gauntlets"); err != nil {
+		t.Errorf("Attune(gauntlets): unexpected error %v", err)
+	}
+}
+
+func TestGetEncumbrance(t *testing.T) {
+	tests := []struct {
+		weight   float64
+		strength int
+		expected character.EncumbranceLevel
+	}{
+		{50, 10, character.Unencumbered},
+		{51, 10, character.Encumbered},
+		{101, 10, character.HeavilyEncumbered},
+		{150, 10, character.HeavilyEncumbered},
+		{151, 10, character.OverCapacity},
+	}
+	for _, tt := range tests {
+		if got := character.GetEncumbrance(tt.weight, tt.strength); got != tt.expected {
+			t.Errorf("GetEncumbrance(%v, %d) = %v; want %v", tt.weight, tt.strength, got, tt.expected)
+		}
+	}
+}
+
+func TestCharacter_StartingEquipmentAndEncumbrance(t *testing.T) {
+	data := newTestItemData()
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Str, 2)
+	char := character.NewCharacter("Aragorn", "Fighter", attrs)
+	if !char.ApplyStartingEquipment(data) {
+		t.Fatal("ApplyStartingEquipment: expected package for Fighter")
+	}
+	if got := char.Inventory.Weight(data); got != 13 {
+		t.Errorf("Weight = %v; want 13", got)
+	}
+	if got := char.CarryingCapacity(); got != 30 {
+		t.Errorf("CarryingCapacity = %v; want 30", got)
+	}
+	if got := char.Encumbrance(data); got != character.Encumbered {
+		t.Errorf("Encumbrance = %v; want encumbered", got)
+	}
+}
+
+func TestSaveLoadCharacter(t *testing.T) {
+	data := newTestItemData()
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Str, 16)
+	char := character.NewCharacter("Aragorn", "Fighter", attrs)
+	char.ApplyStartingEquipment(data)
+	if err := char.Inventory.Equip(data, "longsword", ""); err != nil {
+		t.Fatalf("Equip: unexpected error %v", err)
+	}
+	char.Modifiers.Add(character.Modifier{Source: "human", Layer: character.LayerRacial, Attribute: character.Str, Value: 1})
+
+	path := filepath.Join(t.TempDir(), "aragorn.json")
+	if err := character.SaveCharacter(path, char); err != nil {
+		t.Fatalf("SaveCharacter: unexpected error %v", err)
+	}
+	loaded, err := character.LoadCharacter(path)
+	if err != nil {
+		t.Fatalf("LoadCharacter: unexpected error %v", err)
+	}
+	if loaded.String() != char.String() {
+		t.Errorf("loaded %q; want %q", loaded.String(), char.String())
+	}
+	if got := loaded.EffectiveAttribute(character.Str); got != 17 {
+		t.Errorf("loaded EffectiveAttribute(Str) = %d; want 17", got)
+	}
+	if got := loaded.Inventory.Quantity("rations"); got != 5 {
+		t.Errorf("loaded rations = %d; want 5", got)
+	}
+	if got := loaded.Inventory.EquippedIn(character.SlotMainHand); got != "longsword" {
+		t.Errorf("loaded main hand = %q; want longsword", got)
+	}
+}
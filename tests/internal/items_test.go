@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func TestLoadItemData_Assets(t *testing.T) {
+	data, err := character.LoadItemData(filepath.Join("..", "..", "assets", "data", "items.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	item, ok := data.GetItem("longsword")
+	if !ok {
+		t.Fatal("expected longsword in item catalog")
+	}
+	if item.Type != character.ItemWeapon || item.Damage != "1d8" || item.Slot != character.SlotMainHand {
+		t.Errorf("unexpected longsword: %+v", item)
+	}
+	if got := data.GetStartingEquipment("Fighter"); len(got) == 0 {
+		t.Error("expected starting equipment for Fighter")
+	}
+	if got := data.GetStartingEquipment("Tinker"); got != nil {
+		t.Errorf("expected no starting equipment for Tinker, got %v", got)
+	}
+}
+
+func TestLoadItemData_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid json", "not json"},
+		{"duplicate id", `{"items": [{"id": "a"}, {"id": "a"}]}`},
+		{"missing id", `{"items": [{"name": "Nameless"}]}`},
+		{"unknown starting item", `{"items": [{"id": "a"}], "starting_equipment": {"fighter": [{"item": "b", "quantity": 1}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonPath := filepath.Join(t.TempDir(), "items.json")
+			if err := os.WriteFile(jsonPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+			if _, err := character.LoadItemData(jsonPath); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// journalStart is the time of the first change of journaledCharacter.
+var journalStart = time.Date(2024, time.March, 1, 20, 0, 0, 0, time.UTC)
+
+// journaledCharacter returns a fighter with three recorded changes: a
+// strength increase, a level up and some damage. Its journal clock starts
+// at journalStart and advances a minute per entry.
+func journaledCharacter(t *testing.T) *character.Character {
+	t.Helper()
+	char := newLevelingCharacter("Fighter")
+	now := journalStart
+	char.Journal.Now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now.Add(-time.Minute)
+	}
+	steps := []struct {
+		reason string
+		change func(c *character.Character) error
+	}{
+		{"blessing", func(c *character.Character) error { c.Attributes.Increase(character.Str, 2); return nil }},
+		{"level up", func(c *character.Character) error { _, err := c.LevelUp(); return err }},
+		{"ambush", func(c *character.Character) error { c.TakeDamage(5); return nil }},
+	}
+	for _, step := range steps {
+		if _, err := char.Record("dm", step.reason, step.change); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return char
+}
+
+func TestCharacter_Record(t *testing.T) {
+	char := journaledCharacter(t)
+	if len(char.Journal.Changes) != 3 {
+		t.Fatalf("journal has %d changes; want 3", len(char.Journal.Changes))
+	}
+	first := char.Journal.Changes[0]
+	want := character.FieldChange{Path: "attributes.STR", Old: json.RawMessage("14"), New: json.RawMessage("16")}
+	if first.Seq != 1 || first.Author != "dm" || first.Reason != "blessing" || !first.Time.Equal(journalStart) ||
+		len(first.Fields) != 1 || first.Fields[0].String() != want.String() {
+		t.Errorf("first change = %s; want %s", first, want)
+	}
+	levelUp := char.Journal.Changes[1]
+	if !levelUp.Time.Equal(journalStart.Add(time.Minute)) {
+		t.Errorf("level up time = %s; want a minute after the first change", levelUp.Time)
+	}
+	var paths []string
+	for _, fc := range levelUp.Fields {
+		paths = append(paths, fc.Path)
+	}
+	if !slices.Equal(paths, []string{"hit_points.current", "hit_points.max", "level"}) {
+		t.Errorf("level up paths = %v", paths)
+	}
+
+	if ch, err := char.Record("dm", "nothing", func(c *character.Character) error { return nil }); ch != nil || err != nil {
+		t.Errorf("Record() without changes = %v, %v; want nil", ch, err)
+	}
+	failed := errors.New("failed")
+	_, err := char.Record("dm", "broken", func(c *character.Character) error {
+		c.Attributes.Decrease(character.Dex, 4)
+		return failed
+	})
+	if !errors.Is(err, failed) || char.Attributes.Get(character.Dex) != 14 || len(char.Journal.Changes) != 3 {
+		t.Errorf("failed Record() = %v, DEX %d; want the error and the character restored", err, char.Attributes.Get(character.Dex))
+	}
+}
+
+func TestCharacter_UndoRedo(t *testing.T) {
+	char := journaledCharacter(t)
+	entries, err := char.Undo(2, "dm")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Target != 3 || entries[1].Target != 2 || entries[1].Action != character.Undo {
+		t.Errorf("Undo(2) = %v; want undo #3 then #2", entries)
+	}
+	if char.Level != 1 || char.HitPoints.Current != 12 || char.Attributes.Get(character.Str) != 16 {
+		t.Errorf("after Undo(2): level %d, HP %s, STR %d; want level 1, 12 HP, STR 16",
+			char.Level, char.HitPoints, char.Attributes.Get(character.Str))
+	}
+	if !slices.Equal(char.Journal.Undoable(), []int{1}) || !slices.Equal(char.Journal.Redoable(), []int{2, 3}) {
+		t.Errorf("Undoable() = %v, Redoable() = %v", char.Journal.Undoable(), char.Journal.Redoable())
+	}
+
+	if _, err := char.Redo(1, "dm"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.Level != 2 || char.HitPoints.Current != 20 {
+		t.Errorf("after Redo(1): level %d, HP %s; want level 2 and 20 HP", char.Level, char.HitPoints)
+	}
+	if _, err := char.Redo(2, "dm"); err == nil || char.HitPoints.Current != 20 {
+		t.Error("expected error redoing more changes than undone")
+	}
+
+	char.Record("dm", "trap", func(c *character.Character) error { c.TakeDamage(1); return nil })
+	if len(char.Journal.Redoable()) != 0 {
+		t.Errorf("Redoable() = %v after a new change; want none", char.Journal.Redoable())
+	}
+	if len(char.Journal.Changes) != 7 {
+		t.Errorf("journal has %d changes; want 7", len(char.Journal.Changes))
+	}
+	if _, err := char.Undo(0, "dm"); err == nil {
+		t.Error("expected error undoing zero changes")
+	}
+}
+
+func TestCharacter_Reconstruct(t *testing.T) {
+	char := journaledCharacter(t)
+	char.Undo(1, "dm")
+	tests := []struct {
+		seq   int
+		level int
+		hp    int
+		str   int
+	}{
+		{0, 1, 12, 14},
+		{1, 1, 12, 16},
+		{2, 2, 20, 16},
+		{3, 2, 15, 16},
+		{4, 2, 20, 16},
+	}
+	for _, tt := range tests {
+		past, err := char.Reconstruct(tt.seq)
+		if err != nil {
+			t.Fatalf("Reconstruct(%d): unexpected error: %v", tt.seq, err)
+		}
+		if past.Level != tt.level || past.HitPoints.Current != tt.hp || past.Attributes.Get(character.Str) != tt.str {
+			t.Errorf("Reconstruct(%d) = level %d, HP %s, STR %d; want level %d, %d HP, STR %d", tt.seq,
+				past.Level, past.HitPoints, past.Attributes.Get(character.Str), tt.level, tt.hp, tt.str)
+		}
+		if len(past.Journal.Changes) != tt.seq {
+			t.Errorf("Reconstruct(%d) journal has %d changes", tt.seq, len(past.Journal.Changes))
+		}
+	}
+	if char.Level != 2 || len(char.Journal.Changes) != 4 {
+		t.Error("Reconstruct() modified the character")
+	}
+	if _, err := char.Reconstruct(5); err == nil {
+		t.Error("expected error for a change not in the journal")
+	}
+
+	data, err := json.Marshal(char)
+	if err != nil {
+		t.Fatalf("failed to marshal character: %v", err)
+	}
+	var loaded character.Character
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("failed to unmarshal character: %v", err)
+	}
+	if past, _ := loaded.Reconstruct(1); past.Attributes.Get(character.Str) != 16 || past.Level != 1 {
+		t.Errorf("Reconstruct(1) after a JSON round trip = %s", past)
+	}
+	if want := "#4 2024-03-01 20:03:00 dm undo #3: ambush ("; !strings.HasPrefix(char.Journal.Changes[3].String(), want) {
+		t.Errorf("String() = %q; want prefix %q", char.Journal.Changes[3], want)
+	}
+	anonymous := character.Change{Seq: 5, Time: journalStart, Reason: "rest", Action: character.Edit}
+	if want := "#5 2024-03-01 20:00:00: rest ()"; anonymous.String() != want {
+		t.Errorf("String() = %q; want %q", anonymous, want)
+	}
+}
@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+// newLevelingCharacter returns a level 1 character of the given job with
+// every attribute at 14 and full hit points.
+func newLevelingCharacter(job string) *character.Character {
+	attrs := character.NewAttributesMap()
+	for attr := range attrs {
+		attrs.Set(attr, 14)
+	}
+	char := character.NewCharacter("Aria", job, attrs)
+	char.InitHitPoints()
+	return char
+}
+
+func TestCharacter_LevelUp(t *testing.T) {
+	char := newLevelingCharacter("Fighter")
+	char.TakeDamage(5)
+	for level := 2; level <= 4; level++ {
+		result, err := char.LevelUp()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Level != level || result.HitPoints != 8 {
+			t.Errorf("LevelUp() = %+v; want level %d and 8 hit points", result, level)
+		}
+		if result.Improvement != (level == 4) {
+			t.Errorf("level %d improvement = %v", level, result.Improvement)
+		}
+	}
+	if char.HitPoints.Max != 36 || char.HitPoints.Current != 31 {
+		t.Errorf("HitPoints = %s; want 31/36", char.HitPoints)
+	}
+	if char.Improvements != 1 {
+		t.Errorf("Improvements = %d; want 1", char.Improvements)
+	}
+
+	char.Level = character.MaxLevel
+	if _, err := char.LevelUp(); err == nil {
+		t.Error("expected error leveling up past the maximum level")
+	}
+}
+
+func TestCharacter_LevelUpSpellSlots(t *testing.T) {
+	char := newLevelingCharacter("Wizard")
+	if err := char.InitSpellcasting(newTestSpellData()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	char.LevelUp()
+	char.LevelUp()
+	if got := char.Spellcasting.Slots.Max; got[0] != 4 || got[1] != 2 {
+		t.Errorf("slots at level 3 = %v; want 4 first and 2 second level slots", got)
+	}
+}
+
+func TestCharacter_ImproveAbilities(t *testing.T) {
+	char := newLevelingCharacter("Fighter")
+	increases := map[character.Attribute]int{character.Str: 1, character.Con: 1}
+	if err := char.ImproveAbilities(increases); err == nil {
+		t.Error("expected error without an improvement available")
+	}
+	char.Improvements = 2
+	if err := char.ImproveAbilities(map[character.Attribute]int{character.Str: 3}); err == nil {
+		t.Error("expected error for an improvement of 3 points")
+	}
+	if err := char.ImproveAbilities(increases); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.Attributes.Get(character.Str) != 15 || char.Attributes.Get(character.Con) != 15 || char.Improvements != 1 {
+		t.Errorf("unexpected attributes %s with %d improvements", char.Attributes, char.Improvements)
+	}
+	char.Attributes.Set(character.Dex, 19)
+	if err := char.ImproveAbilities(map[character.Attribute]int{character.Dex: 2}); err == nil {
+		t.Error("expected error raising DEX above 20")
+	}
+	if char.Attributes.Get(character.Dex) != 19 || char.Improvements != 1 {
+		t.Error("failed improvement must not change the character")
+	}
+}
+
+func TestParseIncreases(t *testing.T) {
+	increases, err := character.ParseIncreases("STR:1, dexterity")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if increases[character.Str] != 1 || increases[character.Dex] != 1 || len(increases) != 2 {
+		t.Errorf("ParseIncreases = %v", increases)
+	}
+	if _, err := character.ParseIncreases("LUCK:2"); err == nil {
+		t.Error("expected error for unknown attribute")
+	}
+	if _, err := character.ParseIncreases("STR:x"); err == nil {
+		t.Error("expected error for invalid value")
+	}
+}
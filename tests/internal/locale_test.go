@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/datapack"
+	"github.com/jrecuero/DandD/internal/locale"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"es_ES.UTF-8":      "es_ES",
+		"es_ES.UTF-8@euro": "es_ES",
+		"pt-BR":            "pt_BR",
+		"fr":               "fr",
+		"C":                "",
+		"POSIX":            "",
+		"":                 "",
+	}
+	for name, want := range tests {
+		if got := locale.Normalize(name); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if got := locale.Candidates("es_ES.UTF-8"); !slices.Equal(got, []string{"es_ES", "es"}) {
+		t.Errorf("Candidates(es_ES.UTF-8) = %v, want [es_ES es]", got)
+	}
+	if !locale.IsEnglish("en_US.UTF-8") || locale.IsEnglish("es") {
+		t.Error("IsEnglish: expected en_US to be English and es not")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "es_ES.UTF-8")
+	if got := locale.FromEnv(); got != "es_ES" {
+		t.Errorf("FromEnv() = %q, want es_ES from LANG", got)
+	}
+	t.Setenv("LC_ALL", "fr_FR.UTF-8")
+	if got := locale.FromEnv(); got != "fr_FR" {
+		t.Errorf("FromEnv() = %q, want fr_FR from LC_ALL", got)
+	}
+}
+
+func TestParseTable(t *testing.T) {
+	if _, err := locale.ParseTable([]byte(`{"attributes": {"STR": "fuerza"}}`)); err == nil {
+		t.Error("ParseTable: expected error for table without locale")
+	}
+	if _, err := locale.ParseTable([]byte(`{"locale": "es", "attributes": {"LUCK": "suerte"}}`)); err == nil {
+		t.Error("ParseTable: expected error for unknown attribute")
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	messages := map[string]string{"seed": "Seed: %d", "rolling": "Rolling..."}
+	table, err := locale.ParseTable([]byte(`{
+		"locale": "es",
+		"attributes": {"STR": "fuerza"},
+		"messages": {"seed": "Semilla: %d"},
+		"questions": {"3": "¿Pregunta?"},
+		"answers": {"A1": "Respuesta"}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseTable: %v", err)
+	}
+	l := locale.New(table, messages)
+	question := character.Question{Year: 3, Question: "Question?"}
+	tests := []struct{ got, want string }{
+		{l.Locale(), "es"},
+		{l.Attribute(character.Str), "fuerza"},
+		{l.Attribute(character.Dex), "dexterity"},
+		{l.Message("seed", 7), "Semilla: 7"},
+		{l.Message("rolling"), "Rolling..."},
+		{l.Message("unknown"), "unknown"},
+		{l.Question(question), "¿Pregunta?"},
+		{l.Question(character.Question{Year: 4, Question: "Other?"}), "Other?"},
+		{l.Answer(character.Answer{AnswerID: "A1", Description: "Answer"}), "Respuesta"},
+		{l.Answer(character.Answer{AnswerID: "A2", Description: "Other"}), "Other"},
+	}
+	for i, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("case %d = %q, want %q", i, tt.got, tt.want)
+		}
+	}
+	english := locale.New(nil, messages)
+	if english.Locale() != locale.English || english.Attribute(character.Str) != "strength" || english.Message("seed", 1) != "Seed: 1" {
+		t.Error("New(nil) is not English")
+	}
+
+	data := &character.CharacterCreationData{Questions: []character.Question{
+		{Year: 3, Answers: []character.Answer{{AnswerID: "A1"}, {AnswerID: "A3"}}},
+	}}
+	table.Answers["OLD"] = "Vieja"
+	report := table.Check(messages, data)
+	wantMissing := []string{"attribute DEX", "attribute CON", "attribute INT", "attribute WIS", "attribute CHA", "message rolling", "answer A3"}
+	if !slices.Equal(report.Missing, wantMissing) || report.IsComplete() {
+		t.Errorf("Check missing = %v, want %v", report.Missing, wantMissing)
+	}
+	if !slices.Equal(report.Unused, []string{"answer OLD"}) {
+		t.Errorf("Check unused = %v, want [answer OLD]", report.Unused)
+	}
+}
+
+func TestEmbeddedLocales(t *testing.T) {
+	content, _, err := datapack.SearchPath{datapack.Embedded()}.ReadFile("locales/es.json")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	table, err := locale.ParseTable(content)
+	if err != nil {
+		t.Fatalf("ParseTable: %v", err)
+	}
+	report := table.Check(table.Messages, loadAssetCreationData(t))
+	if !report.IsComplete() || len(report.Unused) > 0 {
+		t.Errorf("es table missing %v, unused %v", report.Missing, report.Unused)
+	}
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func newModifiersBase() character.AttributesMap {
+	base := character.NewAttributesMap()
+	base.Set(character.Str, 14)
+	base.Set(character.Dex, 12)
+	return base
+}
+
+func TestModifiers_EffectiveAdditiveLayers(t *testing.T) {
+	base := newModifiersBase()
+	var ms character.Modifiers
+	ms.Add(character.Modifier{Source: "half-orc", Layer: character.LayerRacial, Attribute: character.Str, Value: 2})
+	ms.Add(character.Modifier{Source: "gauntlets", Layer: character.LayerItem, Attribute: character.Str, Value: 1})
+	ms.Add(character.Modifier{Source: "curse", Layer: character.LayerTemporary, Attribute: character.Str, Value: -3, Duration: 2})
+
+	if got := ms.Effective(base, character.Str); got != 14 {
+		t.Errorf("Effective(Str) = %d; want 14", got)
+	}
+	if got := ms.Effective(base, character.Dex); got != 12 {
+		t.Errorf("Effective(Dex) = %d; want 12", got)
+	}
+	if base.Get(character.Str) != 14 {
+		t.Errorf("base Str was modified: got %d", base.Get(character.Str))
+	}
+}
+
+func TestModifiers_Minimum(t *testing.T) {
+	base := newModifiersBase()
+	var ms character.Modifiers
+	ms.Add(character.Modifier{Source: "potion of giant strength", Layer: character.LayerMinimum, Attribute: character.Str, Value: 21, Duration: 10})
+	ms.Add(character.Modifier{Source: "belt of hill giant strength", Layer: character.LayerMinimum, Attribute: character.Str, Value: 19})
+	ms.Add(character.Modifier{Source: "amulet of health", Layer: character.LayerMinimum, Attribute: character.Dex, Value: 10})
+
+	if got := ms.Effective(base, character.Str); got != 21 {
+		t.Errorf("Effective(Str) = %d; want 21", got)
+	}
+	// A minimum lower than the current score has no effect.
+	if got := ms.Effective(base, character.Dex); got != 12 {
+		t.Errorf("Effective(Dex) = %d; want 12", got)
+	}
+}
+
+func TestModifiers_Override(t *testing.T) {
+	base := newModifiersBase()
+	var ms character.Modifiers
+	ms.Add(character.Modifier{Source: "gauntlets", Layer: character.LayerItem, Attribute: character.Str, Value: 2})
+	ms.Add(character.Modifier{Source: "curse of weakness", Layer: character.LayerOverride, Attribute: character.Str, Value: 3})
+
+	// An override lowers the score too.
+	if got := ms.Effective(base, character.Str); got != 3 {
+		t.Errorf("Effective(Str) = %d; want 3", got)
+	}
+	ms.Add(character.Modifier{Source: "belt of hill giant strength", Layer: character.LayerMinimum, Attribute: character.Str, Value: 21})
+	if got := ms.Effective(base, character.Str); got != 21 {
+		t.Errorf("Effective(Str) with minimum = %d; want 21", got)
+	}
+	sources := ms.Sources(base, character.Str)
+	if got := sources[len(sources)-1].String(); got != "belt of hill giant strength (minimum): 21" {
+		t.Errorf("last source = %q", got)
+	}
+}
+
+func TestModifiers_ApplyMissingAttributes(t *testing.T) {
+	var ms character.Modifiers
+	ms.Add(character.Modifier{Source: "headband of intellect", Layer: character.LayerOverride, Attribute: character.Int, Value: 19})
+	effective := ms.Apply(newModifiersBase())
+	if got := effective.Get(character.Int); got != 19 {
+		t.Errorf("Apply Int = %d; want 19", got)
+	}
+	if len(effective) != 6 {
+		t.Errorf("Apply: expected 6 attributes, got %v", effective)
+	}
+}
+
+func TestModifiers_Sources(t *testing.T) {
+	base := newModifiersBase()
+	var ms character.Modifiers
+	ms.Add(character.Modifier{Source: "bless", Layer: character.LayerTemporary, Attribute: character.Str, Value: 1, Duration: 1})
+	ms.Add(character.Modifier{Source: "dwarf", Layer: character.LayerRacial, Attribute: character.Str, Value: 2})
+
+	sources := ms.Sources(base, character.Str)
+	if len(sources) != 3 {
+		t.Fatalf("expected 3 sources, got %d: %v", len(sources), sources)
+	}
+	expected := []string{"base (base): 14", "dwarf (racial): +2", "bless (temporary): +1"}
+	for i, want := range expected {
+		if got := sources[i].String(); got != want {
+			t.Errorf("sources[%d] = %q; want %q", i, got, want)
+		}
+	}
+}
+
+func TestModifiers_TickAndRemove(t *testing.T) {
+	var ms character.Modifiers
+	ms.Add(character.Modifier{Source: "bless", Layer: character.LayerTemporary, Attribute: character.Str, Value: 1, Duration: 1})
+	ms.Add(character.Modifier{Source: "haste", Layer: character.LayerTemporary, Attribute: character.Dex, Value: 2, Duration: 2})
+	ms.Add(character.Modifier{Source: "elf", Layer: character.LayerRacial, Attribute: character.Dex, Value: 2})
+
+	expired := ms.Tick()
+	if len(expired) != 1 || expired[0].Source != "bless" {
+		t.Errorf("Tick: expected bless to expire, got %v", expired)
+	}
+	if len(ms) != 2 {
+		t.Errorf("Tick: expected 2 modifiers left, got %d", len(ms))
+	}
+	if removed := ms.Remove("elf"); removed != 1 {
+		t.Errorf("Remove: expected 1 removed, got %d", removed)
+	}
+	ms.Tick()
+	if len(ms) != 0 {
+		t.Errorf("expected no modifiers left, got %v", ms)
+	}
+}
+
+func TestCharacter_EffectiveAttributes(t *testing.T) {
+	char := character.NewCharacter("Bruenor", "Fighter", newModifiersBase())
+	char.Modifiers.Add(character.Modifier{Source: "dwarf", Layer: character.LayerRacial, Attribute: character.Con, Value: 2})
+	effective := char.EffectiveAttributes()
+	if got := effective.Get(character.Con); got != 2 {
+		t.Errorf("EffectiveAttributes Con = %d; want 2", got)
+	}
+	if got := char.EffectiveAttribute(character.Str); got != 14 {
+		t.Errorf("EffectiveAttribute(Str) = %d; want 14", got)
+	}
+	if got := len(char.AttributeSources(character.Con)); got != 2 {
+		t.Errorf("AttributeSources(Con): expected 2 sources, got %d", got)
+	}
+}
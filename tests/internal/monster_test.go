@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/combat"
+	"github.com/jrecuero/DandD/internal/monster"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func newTestMonster() *monster.Monster {
+	return &monster.Monster{
+		Name:       "Goblin",
+		Size:       "Small",
+		Type:       "humanoid (goblinoid)",
+		Alignment:  "neutral evil",
+		ArmorClass: 15,
+		HitDice:    "2d6",
+		Speed:      map[string]int{"walk": 30},
+		Abilities: character.AttributesMap{
+			character.Str: 8, character.Dex: 14, character.Con: 10,
+			character.Int: 10, character.Wis: 8, character.Cha: 8,
+		},
+		Skills:          map[string]int{"stealth": 6},
+		Senses:          map[string]int{"darkvision": 60},
+		ChallengeRating: "1/4",
+		Environments:    []string{"forest", "hill"},
+		Actions: []monster.Action{
+			{Name: "Multiattack", Description: "The goblin attacks twice."},
+			{Name: "Scimitar", Kind: "melee", AttackBonus: 4, Reach: "5 ft.", Damage: "1d6+2", DamageType: "slashing"},
+		},
+	}
+}
+
+func TestParseChallengeRating(t *testing.T) {
+	tests := []struct {
+		cr       string
+		expected float64
+		xp       int
+	}{
+		{"0", 0, 10},
+		{"1/8", 0.125, 25},
+		{"1/4", 0.25, 50},
+		{"1/2", 0.5, 100},
+		{"5", 5, 1800},
+		{"30", 30, 155000},
+	}
+	for _, tt := range tests {
+		got, err := monster.ParseChallengeRating(tt.cr)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseChallengeRating(%q) = %v, %v; want %v", tt.cr, got, err, tt.expected)
+		}
+		if xp, ok := monster.ChallengeXP(tt.cr); !ok || xp != tt.xp {
+			t.Errorf("ChallengeXP(%q) = %d, %v; want %d", tt.cr, xp, ok, tt.xp)
+		}
+	}
+	if _, err := monster.ParseChallengeRating("1/3"); err == nil {
+		t.Error("expected error for invalid challenge rating 1/3")
+	}
+}
+
+func TestMonster_Bonuses(t *testing.T) {
+	m := newTestMonster()
+	if m.ProficiencyBonus() != 2 {
+		t.Errorf("ProficiencyBonus() = %d; want 2", m.ProficiencyBonus())
+	}
+	if m.Modifier(character.Dex) != 2 || m.SaveBonus(character.Dex) != 2 {
+		t.Errorf("unexpected DEX modifier %d or save %d", m.Modifier(character.Dex), m.SaveBonus(character.Dex))
+	}
+	m.Saves = map[string]int{"DEX": 4}
+	if m.SaveBonus(character.Dex) != 4 {
+		t.Errorf("SaveBonus(DEX) = %d; want proficient 4", m.SaveBonus(character.Dex))
+	}
+	if m.PassivePerception() != 9 {
+		t.Errorf("PassivePerception() = %d; want 9", m.PassivePerception())
+	}
+	if m.AverageHitPoints() != 7 {
+		t.Errorf("AverageHitPoints() = %d; want 7", m.AverageHitPoints())
+	}
+	if hp := m.RollHitPoints(dice.NewScriptedRoller(6, 2)); hp != 8 {
+		t.Errorf("RollHitPoints() = %d; want 8", hp)
+	}
+	m.ChallengeRating = "8"
+	if m.ProficiencyBonus() != 3 {
+		t.Errorf("ProficiencyBonus() at CR 8 = %d; want 3", m.ProficiencyBonus())
+	}
+	if !m.HasEnvironment("Forest") || m.HasEnvironment("desert") {
+		t.Errorf("unexpected environments %v", m.Environments)
+	}
+}
+
+func TestMonster_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(m *monster.Monster)
+	}{
+		{"no name", func(m *monster.Monster) { m.Name = " " }},
+		{"missing ability", func(m *monster.Monster) { delete(m.Abilities, character.Cha) }},
+		{"ability out of bounds", func(m *monster.Monster) { m.Abilities[character.Str] = 31 }},
+		{"armor class", func(m *monster.Monster) { m.ArmorClass = 0 }},
+		{"hit dice", func(m *monster.Monster) { m.HitDice = "two d6" }},
+		{"challenge rating", func(m *monster.Monster) { m.ChallengeRating = "1/3" }},
+		{"save", func(m *monster.Monster) { m.Saves = map[string]int{"LUCK": 2} }},
+		{"damage", func(m *monster.Monster) { m.Actions[1].Damage = "d" }},
+		{"damage type", func(m *monster.Monster) { m.Actions[1].DamageType = "sonic" }},
+		{"immunity", func(m *monster.Monster) { m.DamageImmunities = []string{"sonic"} }},
+	}
+	if err := newTestMonster().Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMonster()
+			tt.modify(m)
+			if err := m.Validate(); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestMonster_StatBlock(t *testing.T) {
+	block := newTestMonster().StatBlock()
+	for _, expected := range []string{
+		"GOBLIN",
+		"Small humanoid (goblinoid), neutral evil",
+		"Armor Class 15",
+		"Hit Points 7 (2d6)",
+		"Speed 30 ft.",
+		"14 (+2)",
+		"Skills Stealth +6",
+		"Senses darkvision 60 ft., passive Perception 9",
+		"Challenge 1/4 (50 XP)",
+		"Multiattack. The goblin attacks twice.",
+		"Scimitar. Melee Weapon Attack: +4 to hit, reach 5 ft., one target. Hit: 5 (1d6 + 2) slashing damage.",
+	} {
+		if !strings.Contains(block, expected) {
+			t.Errorf("stat block missing %q:\n%s", expected, block)
+		}
+	}
+}
+
+func TestLoadBestiary_Assets(t *testing.T) {
+	bestiary, err := monster.LoadBestiary(filepath.Join("..", "..", "assets", "data", "bestiary.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	goblin, ok := bestiary.Get("GOBLIN")
+	if !ok {
+		t.Fatal("expected goblin in bestiary")
+	}
+	if goblin.Abilities.Get(character.Dex) != 14 || goblin.XP() != 50 {
+		t.Errorf("unexpected goblin: %+v", goblin)
+	}
+	if _, ok := bestiary.Get("tarrasque"); ok {
+		t.Error("did not expect tarrasque in bestiary")
+	}
+	names := bestiary.Names()
+	if len(names) != len(bestiary.Monsters) || names[0] > names[len(names)-1] {
+		t.Errorf("unexpected names %v", names)
+	}
+	for _, m := range bestiary.Filter("forest", 0, 0.25) {
+		if !m.HasEnvironment("forest") || m.CR() > 0.25 {
+			t.Errorf("Filter returned %s CR %s", m.Name, m.ChallengeRating)
+		}
+	}
+}
+
+func TestBestiary_ValidateDuplicate(t *testing.T) {
+	bestiary := &monster.Bestiary{Monsters: []monster.Monster{*newTestMonster(), *newTestMonster()}}
+	bestiary.Monsters[1].Name = "goblin"
+	if err := bestiary.Validate(); err == nil {
+		t.Error("expected duplicate monster error")
+	}
+}
+
+func TestFromMonster(t *testing.T) {
+	m := newTestMonster()
+	c := combat.FromMonster(m, "monsters", nil)
+	if c.ArmorClass != 15 || c.HitPoints != 7 || c.InitiativeBonus != 2 {
+		t.Errorf("FromMonster = %v", c)
+	}
+	if len(c.Attacks) != 1 || c.Attacks[0].Name != "Scimitar" || c.Attacks[0].Damage != "1d6+2" {
+		t.Errorf("FromMonster attacks = %v", c.Attacks)
+	}
+	rolled := combat.FromMonster(m, "monsters", dice.NewScriptedRoller(1, 1))
+	if rolled.HitPoints != 2 || rolled.MaxHitPoints != 2 {
+		t.Errorf("FromMonster rolled HP = %d/%d; want 2/2", rolled.HitPoints, rolled.MaxHitPoints)
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+// newRestingFighter returns a level 4 fighter with 14 CON and 36 max HP.
+func newRestingFighter() *character.Character {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Con, 14)
+	char := character.NewCharacter("Boromir", "Fighter", attrs)
+	char.Level = 4
+	char.InitHitPoints()
+	char.AddFeature("Second Wind", 1, character.RechargeShortRest)
+	char.AddFeature("Indomitable", 1, character.RechargeLongRest)
+	return char
+}
+
+func TestCharacter_ShortRest(t *testing.T) {
+	char := newRestingFighter()
+	char.TakeDamage(20)
+	char.UseFeature("Second Wind")
+	char.UseFeature("Indomitable")
+	if err := char.UseFeature("second wind"); err == nil {
+		t.Error("expected error using a spent feature")
+	}
+
+	// d10 rolls of 6 and 1: 6+2 and 1+2 hit points.
+	result, err := char.ShortRest(2, dice.NewScriptedRoller(6, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.HitPointsRestored != 11 || char.HitPoints.Current != 27 || char.HitDiceAvailable() != 2 {
+		t.Errorf("ShortRest = %v; HP %v, %d hit dice left", result, char.HitPoints, char.HitDiceAvailable())
+	}
+	if len(result.FeaturesRestored) != 1 || result.FeaturesRestored[0] != "Second Wind" {
+		t.Errorf("expected only Second Wind restored, got %v", result.FeaturesRestored)
+	}
+	if err := char.UseFeature("Indomitable"); err == nil {
+		t.Error("expected Indomitable not to recharge on a short rest")
+	}
+	if _, err := char.ShortRest(3, nil); err == nil {
+		t.Error("expected error spending more hit dice than available")
+	}
+
+	// Spending stops once the character is at maximum hit points.
+	result, _ = char.ShortRest(2, dice.NewScriptedRoller(10))
+	if len(result.HitDiceRolls) != 1 || result.HitPointsRestored != 9 || char.HitDiceAvailable() != 1 {
+		t.Errorf("ShortRest at max HP = %v, %d hit dice left", result, char.HitDiceAvailable())
+	}
+}
+
+func TestCharacter_LongRest(t *testing.T) {
+	char := newRestingFighter()
+	char.TakeDamage(30)
+	char.ShortRest(4, dice.NewScriptedRoller(1, 1, 1, 1))
+	char.UseFeature("Indomitable")
+	char.Conditions.Add(character.ActiveCondition{Condition: character.Exhaustion, Level: 2})
+
+	result, err := char.LongRest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if char.HitPoints.Current != 36 || result.HitDiceRecovered != 2 || char.HitDiceAvailable() != 2 {
+		t.Errorf("LongRest = %v; HP %v, %d hit dice", result, char.HitPoints, char.HitDiceAvailable())
+	}
+	if result.ExhaustionLevel != 1 || char.Conditions.ExhaustionLevel() != 1 {
+		t.Errorf("expected exhaustion reduced to 1, got %d", char.Conditions.ExhaustionLevel())
+	}
+	if err := char.UseFeature("Indomitable"); err != nil {
+		t.Errorf("expected Indomitable restored: %v", err)
+	}
+
+	char.TakeDamage(36)
+	if _, err := char.LongRest(); err == nil {
+		t.Error("expected error resting at zero hit points")
+	}
+}
+
+func TestCharacter_RestSpellSlots(t *testing.T) {
+	char := newTestWizard(3)
+	if err := char.InitSpellcasting(newTestSpellData()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	char.InitHitPoints()
+	char.Spellcasting.Slots.Use(1)
+	char.ShortRest(0, nil)
+	if char.Spellcasting.Slots.Available(1) != char.Spellcasting.Slots.Max[0]-1 {
+		t.Error("expected short rest not to restore wizard slots")
+	}
+	char.LongRest()
+	if char.Spellcasting.Slots.Available(1) != char.Spellcasting.Slots.Max[0] {
+		t.Error("expected long rest to restore wizard slots")
+	}
+}
+
+func TestCharacter_ShortRestDying(t *testing.T) {
+	char := newDyingCharacter(t)
+	if _, err := char.ShortRest(1, dice.NewScriptedRoller(6)); err == nil || char.State != character.Dying {
+		t.Errorf("ShortRest while dying: expected error, got %v and state %s", err, char.State)
+	}
+	healer := character.NewCharacter("Aragorn", "Ranger", character.AttributesMap{character.Wis: 14})
+	if _, err := char.Stabilize(healer, dice.NewScriptedRoller(10)); err != nil {
+		t.Fatalf("Stabilize: unexpected error %v", err)
+	}
+	result, err := char.ShortRest(1, dice.NewScriptedRoller(6))
+	if err != nil || result.HitPointsRestored != 8 || char.State != character.Conscious {
+		t.Errorf("ShortRest while stable = %v, %v; want 8 HP restored and conscious, got %s", result, err, char.State)
+	}
+}
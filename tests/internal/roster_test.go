@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/roster"
+)
+
+// newTestRoster returns a roster over the given store with a fixed clock,
+// holding a level 3 fighter, a level 1 wizard and a fighter/wizard.
+func newTestRoster(t *testing.T, store roster.Store) *roster.Roster {
+	t.Helper()
+	r := roster.New(store)
+	r.Now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	fighter := newLevelingCharacter("Fighter")
+	fighter.Name = "Boromir"
+	fighter.LevelUp()
+	fighter.LevelUp()
+	wizard := newLevelingCharacter("Wizard")
+	wizard.Name = "Gandalf the Grey"
+	multiclass := newLevelingCharacter("Fighter")
+	multiclass.Name = "Aria"
+	if _, err := multiclass.AddClassLevel("wizard", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, c := range []*character.Character{fighter, wizard, multiclass} {
+		if _, err := r.Add(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return r
+}
+
+// ids returns the IDs of the entries.
+func ids(entries []roster.Entry) []string {
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = e.ID
+	}
+	return result
+}
+
+func TestRoster(t *testing.T) {
+	stores := map[string]func(t *testing.T) roster.Store{
+		"memory": func(t *testing.T) roster.Store { return roster.NewMemoryStore() },
+		"file": func(t *testing.T) roster.Store {
+			store, err := roster.NewFileStore(t.TempDir())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return store
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRoster(t, newStore(t))
+			entries, err := r.List(false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := ids(entries); len(got) != 3 || got[0] != "aria" || got[1] != "boromir" || got[2] != "gandalf_the_grey" {
+				t.Errorf("List() = %v; want aria, boromir and gandalf_the_grey", got)
+			}
+
+			tests := []struct {
+				query roster.Query
+				want  int
+			}{
+				{roster.Query{Class: "FIGHTER"}, 2},
+				{roster.Query{Class: "wizard", MaxLevel: 1}, 1},
+				{roster.Query{MinLevel: 2}, 2},
+				{roster.Query{Name: "grey"}, 1},
+				{roster.Query{Name: "legolas"}, 0},
+			}
+			for _, tt := range tests {
+				found, _ := r.Search(tt.query)
+				if len(found) != tt.want {
+					t.Errorf("Search(%+v) = %v; want %d entries", tt.query, ids(found), tt.want)
+				}
+			}
+
+			renamed, err := r.Rename("boromir", "Faramir")
+			if err != nil || renamed.ID != "boromir" || renamed.Name != "Faramir" {
+				t.Errorf("Rename() = %v, %v; want Faramir under the same ID", renamed, err)
+			}
+			if c, _ := r.Get("boromir"); c == nil || c.Name != "Faramir" || c.Level != 3 {
+				t.Errorf("Get(boromir) = %v; want the renamed level 3 character", c)
+			}
+
+			clone, err := r.Clone("boromir", "Boromir")
+			if err != nil || clone.ID != "boromir_2" || clone.Level != 3 {
+				t.Errorf("Clone() = %v, %v; want boromir_2 at level 3", clone, err)
+			}
+
+			if _, err := r.Archive("aria"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found, _ := r.Search(roster.Query{Class: "fighter"}); len(found) != 2 {
+				t.Errorf("Search(fighter) = %v; want the archived character left out", ids(found))
+			}
+			if all, _ := r.List(true); len(all) != 4 {
+				t.Errorf("List(true) = %v; want 4 entries", ids(all))
+			}
+			if entry, _ := r.Unarchive("aria"); entry.Archived {
+				t.Error("Unarchive() left the entry archived")
+			}
+
+			if err := r.Delete("gandalf_the_grey"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := r.Get("gandalf_the_grey"); !errors.Is(err, roster.ErrNotFound) {
+				t.Errorf("Get() after Delete() = %v; want ErrNotFound", err)
+			}
+			if err := r.Delete("gandalf_the_grey"); !errors.Is(err, roster.ErrNotFound) {
+				t.Errorf("Delete() twice = %v; want ErrNotFound", err)
+			}
+			if _, err := r.Rename("nobody", "Somebody"); !errors.Is(err, roster.ErrNotFound) {
+				t.Errorf("Rename(nobody) = %v; want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestFileStore_Persistence(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := roster.NewFileStore(dir)
+	r := newTestRoster(t, store)
+	c, _ := r.Get("aria")
+	c.TakeDamage(3)
+	if _, err := r.Update("aria", c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reopened, _ := roster.NewFileStore(dir)
+	entries, err := reopened.Entries()
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("Entries() = %v, %v; want 3 entries", entries, err)
+	}
+	if entries[2].Classes != "fighter 1 / wizard 1" || entries[2].Level != 2 {
+		t.Errorf("entry = %+v; want fighter 1 / wizard 1 at level 2", entries[2])
+	}
+	loaded, err := roster.New(reopened).Get("aria")
+	if err != nil || loaded.HitPoints.Current != c.HitPoints.Current {
+		t.Errorf("Get(aria) = %v, %v; want the updated hit points", loaded, err)
+	}
+}
+
+func TestFileStore_PathOutsideStore(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := roster.NewFileStore(filepath.Join(dir, "roster"))
+	outside := filepath.Join(dir, "outside.json")
+	if err := character.SaveCharacter(outside, character.NewCharacter("Mallory", "Rogue", character.NewAttributesMap())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get("../outside"); !errors.Is(err, roster.ErrNotFound) {
+		t.Errorf("Get(../outside) error = %v; want ErrNotFound", err)
+	}
+	c := character.NewCharacter("Mallory", "Rogue", character.NewAttributesMap())
+	if err := store.Put(roster.Entry{ID: "../outside"}, c); err == nil {
+		t.Error("Put(../outside): expected error for an invalid ID")
+	}
+}
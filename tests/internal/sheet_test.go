@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/internal/sheet"
+)
+
+// newSheetCharacter returns a level 1 wizard with equipment and spells.
+func newSheetCharacter(t *testing.T) *character.Character {
+	t.Helper()
+	char := newLevelingCharacter("Wizard")
+	char.Skills = []character.Skill{character.Arcana}
+	char.Inventory.Add("dagger", 2)
+	char.Inventory.Add("rations", 3)
+	if err := char.Inventory.Equip(newTestItemData(), "dagger", character.SlotMainHand); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spells := newTestSpellData()
+	if err := char.InitSpellcasting(spells); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, id := range []string{"magic_missile", "fire_bolt"} {
+		spell, _ := spells.GetSpell(id)
+		char.LearnSpell(spell)
+	}
+	missile, _ := spells.GetSpell("magic_missile")
+	char.PrepareSpell(missile)
+	return char
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]sheet.Format{"text": sheet.Text, "MD": sheet.Markdown, "markdown": sheet.Markdown, "htm": sheet.HTML}
+	for name, want := range tests {
+		if got, err := sheet.ParseFormat(name); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := sheet.ParseFormat("pdf"); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+}
+
+func TestSheet_New(t *testing.T) {
+	s := sheet.New(newSheetCharacter(t), sheet.Data{Items: newTestItemData(), Spells: newTestSpellData()})
+	if s.Classes != "wizard 1" || s.HitPoints != "8/8" || s.HitDice != "1/1 (d6)" || s.ArmorClass != 12 {
+		t.Errorf("unexpected summary: %s, %s, %s, AC %d", s.Classes, s.HitPoints, s.HitDice, s.ArmorClass)
+	}
+	intelligence := s.Abilities[3]
+	if intelligence.Short != "INT" || intelligence.Modifier != 2 || intelligence.Save != 4 || !intelligence.SaveProficient {
+		t.Errorf("INT = %+v; want a proficient +4 save", intelligence)
+	}
+	if s.Abilities[0].Save != 2 || s.Abilities[0].SaveProficient {
+		t.Errorf("STR = %+v; want a +2 save", s.Abilities[0])
+	}
+	for _, sk := range s.Skills {
+		if sk.Name == "Arcana" && (!sk.Proficient || sk.Modifier != 4) {
+			t.Errorf("Arcana = %+v; want proficient +4", sk)
+		}
+	}
+	if len(s.Equipment) != 2 || s.Equipment[0].Slot != string(character.SlotMainHand) || s.Equipment[1].Weight != 6 {
+		t.Errorf("unexpected equipment: %+v", s.Equipment)
+	}
+	if s.Spellcasting == nil || len(s.Spellcasting.Spells) != 2 {
+		t.Fatalf("unexpected spellcasting: %+v", s.Spellcasting)
+	}
+	first, second := s.Spellcasting.Spells[0], s.Spellcasting.Spells[1]
+	if first.Name != "Fire Bolt" || first.Prepared || second.Name != "Magic Missile" || !second.Prepared {
+		t.Errorf("spells = %+v; want cantrips first and Magic Missile prepared", s.Spellcasting.Spells)
+	}
+
+	bare := sheet.New(character.NewCharacter("Nobody", "Fighter", character.NewAttributesMap()), sheet.Data{})
+	if bare.Spellcasting != nil || bare.ArmorClass != 0 || bare.Encumbrance != "" {
+		t.Errorf("unexpected sheet without data: %+v", bare)
+	}
+}
+
+func TestSheet_Render(t *testing.T) {
+	char := newSheetCharacter(t)
+	char.Name = "Elara <the Bold>"
+	char.Personality = character.Personality{Trait: "Curious", Ideal: "Knowledge", Bond: "My books", Flaw: "Arrogant"}
+	s := sheet.New(char, sheet.Data{Items: newTestItemData(), Spells: newTestSpellData()})
+	tests := []struct {
+		format sheet.Format
+		want   []string
+	}{
+		{sheet.Text, []string{"Elara <the Bold> - wizard 1 (level 1)", "Intelligence", "* Arcana", "Magic Missile", "Trait: Curious"}},
+		{sheet.Markdown, []string{"# Elara <the Bold>", "| Arcana\\* | INT | +4 |", "| 1 | Magic Missile |", "- **Flaw:** Arrogant"}},
+		{sheet.HTML, []string{"<!DOCTYPE html>", "Elara &lt;the Bold&gt;", "@media print", "<td>Magic Missile</td>", "My books"}},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := s.Render(&b, tt.format); err != nil {
+			t.Fatalf("Render(%s): unexpected error: %v", tt.format, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(b.String(), want) {
+				t.Errorf("Render(%s) does not contain %q:\n%s", tt.format, want, b.String())
+			}
+		}
+	}
+	if err := s.Render(&strings.Builder{}, "pdf"); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+}
@@ -0,0 +1,227 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func TestLoadSpellData_Assets(t *testing.T) {
+	data, err := character.LoadSpellData(filepath.Join("..", "..", "assets", "data", "spells.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spell, ok := data.GetSpell("fireball")
+	if !ok {
+		t.Fatal("expected fireball in spell catalog")
+	}
+	if spell.Level != 3 || spell.School != character.Evocation || spell.Damage != "8d6" {
+		t.Errorf("unexpected fireball: %+v", spell)
+	}
+	caster, ok := data.GetCaster("Wizard")
+	if !ok || caster.Ability != "INT" || caster.Progression != character.FullCaster {
+		t.Errorf("unexpected wizard caster: %+v", caster)
+	}
+	spells := data.ClassSpells("wizard", 1)
+	if len(spells) == 0 || spells[0].Level != 0 {
+		t.Errorf("ClassSpells(wizard, 1): expected cantrips first, got %v", spells)
+	}
+	for _, s := range spells {
+		if s.Level > 1 {
+			t.Errorf("ClassSpells(wizard, 1) returned level %d spell %q", s.Level, s.ID)
+		}
+	}
+}
+
+func TestGetSpellSlots(t *testing.T) {
+	tests := []struct {
+		name        string
+		progression character.CasterProgression
+		level       int
+		expected    [character.MaxSpellLevel]int
+	}{
+		{"full 1", character.FullCaster, 1, [character.MaxSpellLevel]int{2}},
+		{"full 5", character.FullCaster, 5, [character.MaxSpellLevel]int{4, 3, 2}},
+		{"full 20", character.FullCaster, 20, [character.MaxSpellLevel]int{4, 3, 3, 3, 3, 2, 2, 1, 1}},
+		{"half 1", character.HalfCaster, 1, [character.MaxSpellLevel]int{}},
+		{"half 2", character.HalfCaster, 2, [character.MaxSpellLevel]int{2}},
+		{"half 5", character.HalfCaster, 5, [character.MaxSpellLevel]int{4, 2}},
+		{"pact 1", character.PactCaster, 1, [character.MaxSpellLevel]int{1}},
+		{"pact 11", character.PactCaster, 11, [character.MaxSpellLevel]int{0, 0, 0, 0, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := character.GetSpellSlots(tt.progression, tt.level); got != tt.expected {
+				t.Errorf("GetSpellSlots(%s, %d) = %v; want %v", tt.progression, tt.level, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSpellSlots_UseAndRestore(t *testing.T) {
+	slots := character.SpellSlots{Max: [character.MaxSpellLevel]int{2, 1}}
+	if err := slots.Use(1); err != nil {
+		t.Fatalf("Use(1): unexpected error %v", err)
+	}
+	if err := slots.Use(1); err != nil {
+		t.Fatalf("Use(1): unexpected error %v", err)
+	}
+	if err := slots.Use(1); err == nil {
+		t.Error("Use(1): expected error with no slots left")
+	}
+	if err := slots.Use(3); err == nil {
+		t.Error("Use(3): expected error for missing slot level")
+	}
+	if got := slots.String(); got != "1: 0/2, 2: 1/1" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := slots.Restore(1, 5); got != 2 {
+		t.Errorf("Restore(1, 5) = %d; want 2", got)
+	}
+	slots.Use(2)
+	slots.RestoreAll()
+	if slots.Available(2) != 1 {
+		t.Errorf("RestoreAll: expected level 2 slot available")
+	}
+}
+
+func newTestSpellData() *character.SpellData {
+	return &character.SpellData{
+		Spells: []character.Spell{
+			{ID: "fire_bolt", Name: "Fire Bolt", Level: 0, Damage: "1d10", DamageType: "fire", Classes: []string{"wizard"}},
+			{ID: "magic_missile", Name: "Magic Missile", Level: 1, Damage: "3d4+3", DamageType: "force", Scaling: "1d4+1", Classes: []string{"wizard"}},
+			{ID: "cure_wounds", Name: "Cure Wounds", Level: 1, Healing: "1d8", Scaling: "1d8", Classes: []string{"cleric"}},
+		},
+		Casters: map[string]character.CasterClass{
+			"wizard":  {Ability: "INT", Progression: character.FullCaster, Prepares: true},
+			"cleric":  {Ability: "WIS", Progression: character.FullCaster, Prepares: true},
+			"paladin": {Ability: "CHA", Progression: character.HalfCaster, Prepares: true},
+		},
+	}
+}
+
+func newTestWizard(level int) *character.Character {
+	attrs := character.NewAttributesMap()
+	attrs.Set(character.Int, 16)
+	char := character.NewCharacter("Gandalf", "Wizard", attrs)
+	char.Level = level
+	return char
+}
+
+func TestCharacter_SpellcastingStats(t *testing.T) {
+	data := newTestSpellData()
+	char := newTestWizard(5)
+	if err := char.InitSpellcasting(data); err != nil {
+		t.Fatalf("InitSpellcasting: unexpected error %v", err)
+	}
+	if got := char.SpellSaveDC(); got != 14 {
+		t.Errorf("SpellSaveDC = %d; want 14", got)
+	}
+	if got := char.SpellAttackBonus(); got != 6 {
+		t.Errorf("SpellAttackBonus = %d; want 6", got)
+	}
+	if got := char.PreparedLimit(); got != 8 {
+		t.Errorf("PreparedLimit = %d; want 8", got)
+	}
+	if char.Spellcasting.Slots.Max[2] != 2 {
+		t.Errorf("expected 2 level 3 slots, got %v", char.Spellcasting.Slots.Max)
+	}
+	fighter := character.NewCharacter("Boromir", "Fighter", character.NewAttributesMap())
+	if err := fighter.InitSpellcasting(data); err == nil {
+		t.Error("InitSpellcasting: expected error for fighter")
+	}
+}
+
+func TestCharacter_PreparedLimitHalfCaster(t *testing.T) {
+	tests := []struct {
+		level    int
+		cha      int
+		expected int
+	}{
+		{2, 14, 3},
+		{5, 14, 4},
+		{20, 16, 13},
+		{1, 14, 3},
+		{2, 6, 1},
+	}
+	for _, tt := range tests {
+		attrs := character.NewAttributesMap()
+		attrs.Set(character.Cha, tt.cha)
+		char := character.NewCharacter("Aragorn", "Paladin", attrs)
+		char.Level = tt.level
+		if err := char.InitSpellcasting(newTestSpellData()); err != nil {
+			t.Fatalf("InitSpellcasting: unexpected error %v", err)
+		}
+		if got := char.PreparedLimit(); got != tt.expected {
+			t.Errorf("PreparedLimit(level %d, CHA %d) = %d; want %d", tt.level, tt.cha, got, tt.expected)
+		}
+	}
+}
+
+func TestCharacter_Cast(t *testing.T) {
+	data := newTestSpellData()
+	char := newTestWizard(1)
+	char.InitSpellcasting(data)
+	missile, _ := data.GetSpell("magic_missile")
+	bolt, _ := data.GetSpell("fire_bolt")
+	cure, _ := data.GetSpell("cure_wounds")
+
+	if err := char.LearnSpell(cure); err == nil {
+		t.Error("LearnSpell: expected error for spell from another class")
+	}
+	for _, spell := range []character.Spell{missile, bolt} {
+		if err := char.LearnSpell(spell); err != nil {
+			t.Fatalf("LearnSpell(%s): unexpected error %v", spell.ID, err)
+		}
+	}
+	if _, err := char.Cast(missile, 1); err == nil {
+		t.Error("Cast: expected error for unprepared spell")
+	}
+	if err := char.PrepareSpell(missile); err != nil {
+		t.Fatalf("PrepareSpell: unexpected error %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		result, err := char.Cast(missile, 1)
+		if err != nil {
+			t.Fatalf("Cast: unexpected error %v", err)
+		}
+		if result.Damage < 6 || result.Damage > 15 {
+			t.Errorf("Cast damage = %d; want between 6 and 15", result.Damage)
+		}
+	}
+	if _, err := char.Cast(missile, 1); err == nil {
+		t.Error("Cast: expected error with no slots left")
+	}
+	result, err := char.Cast(bolt, 1)
+	if err != nil || result.SlotLevel != 0 {
+		t.Errorf("Cast cantrip = (%v, %v); want no slot used", result, err)
+	}
+	char.RestoreSpellSlots(false)
+	if char.Spellcasting.Slots.Available(1) != 0 {
+		t.Error("RestoreSpellSlots(short): expected no slots restored for wizard")
+	}
+	char.RestoreSpellSlots(true)
+	if char.Spellcasting.Slots.Available(1) != 2 {
+		t.Error("RestoreSpellSlots(long): expected all slots restored")
+	}
+}
+
+func TestCharacter_CastUpcast(t *testing.T) {
+	data := newTestSpellData()
+	char := newTestWizard(3)
+	char.InitSpellcasting(data)
+	missile, _ := data.GetSpell("magic_missile")
+	char.LearnSpell(missile)
+	char.PrepareSpell(missile)
+	result, err := char.Cast(missile, 2)
+	if err != nil {
+		t.Fatalf("Cast: unexpected error %v", err)
+	}
+	if result.Damage < 8 || result.Damage > 20 {
+		t.Errorf("upcast damage = %d; want between 8 and 20", result.Damage)
+	}
+	if char.Spellcasting.Slots.Available(2) != 1 {
+		t.Errorf("expected a level 2 slot used, got %v", &char.Spellcasting.Slots)
+	}
+}
@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func loadAssetCreationData(t *testing.T) *character.CharacterCreationData {
+	t.Helper()
+	data, err := character.LoadCharacterData(filepath.Join("..", "..", "assets", "data", "character_creation.json"))
+	if err != nil {
+		t.Fatalf("LoadCharacterData: %v", err)
+	}
+	return data
+}
+
+func TestTestAnswer(t *testing.T) {
+	answer := character.Answer{
+		AnswerID:   "A1",
+		Increases:  map[string]int{"STR": 2, "CON": 1},
+		Test:       "STR",
+		DC:         12,
+		FailEffect: map[string]int{"DEX": -1},
+	}
+	bounds := character.Bounds{Min: 1, Max: 13}
+	attributes := character.AttributesMap{character.Str: 12, character.Dex: 10, character.Con: 10}
+
+	result, err := character.TestAnswer(attributes, answer, bounds, dice.NewScriptedRoller(11))
+	if err != nil {
+		t.Fatalf("TestAnswer: %v", err)
+	}
+	if !result.Passed || result.Total() != 12 || result.Score != 12 {
+		t.Errorf("TestAnswer = %+v, want passed with total 12", result)
+	}
+	if attributes.Get(character.Str) != 13 || attributes.Get(character.Con) != 11 {
+		t.Errorf("attributes = %v, want STR 13 and CON 11", attributes)
+	}
+	if len(result.Events) != 1 || result.Events[0].Attribute != character.Str {
+		t.Errorf("Events = %v, want STR clamped", result.Events)
+	}
+
+	result, _ = character.TestAnswer(attributes, answer, bounds, dice.NewScriptedRoller(1))
+	if result.Passed || attributes.Get(character.Dex) != 9 {
+		t.Errorf("TestAnswer = %+v, attributes %v, want failed with DEX 9", result, attributes)
+	}
+
+	attributes.Set(character.Dex, 1)
+	result, _ = character.TestAnswer(attributes, answer, bounds, dice.NewScriptedRoller(1))
+	if attributes.Get(character.Dex) != 1 || len(result.Events) != 1 || result.Events[0].Requested != 0 {
+		t.Errorf("TestAnswer = %+v, attributes %v, want DEX clamped from 0 to 1", result, attributes)
+	}
+
+	answer.Test = "LUCK"
+	if _, err := character.TestAnswer(attributes, answer, bounds, nil); err == nil {
+		t.Error("TestAnswer: expected error for unknown attribute")
+	}
+}
+
+func TestCreation_Reproducible(t *testing.T) {
+	data := loadAssetCreationData(t)
+	run := func(seed int64) *character.Transcript {
+		c, _ := data.NewCreation(seed)
+		if _, err := c.Run(); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return c.Transcript()
+	}
+	first, second := run(7), run(7)
+	if !slices.Equal(first.Answers, second.Answers) || !slices.Equal(first.Rolls, second.Rolls) {
+		t.Errorf("seed 7 runs differ: %v %v and %v %v", first.Answers, first.Rolls, second.Answers, second.Rolls)
+	}
+	if first.Attributes.String() != second.Attributes.String() {
+		t.Errorf("seed 7 attributes differ: %s and %s", first.Attributes, second.Attributes)
+	}
+	if len(first.Answers) != len(data.Questions) || first.DataHash != data.Hash || first.Seed != 7 {
+		t.Errorf("Transcript = %+v", first)
+	}
+}
+
+func TestCreationData_Replay(t *testing.T) {
+	data := loadAssetCreationData(t)
+	c, _ := data.NewCreation(42)
+	if _, err := c.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	transcript := c.Transcript()
+	steps, err := data.Replay(transcript)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if len(steps) != len(data.Questions) {
+		t.Errorf("Replay returned %d steps, want %d", len(steps), len(data.Questions))
+	}
+	// Failed tests apply the penalties of the data, which lower attributes.
+	failed := 0
+	for _, step := range steps {
+		if step.Result.Passed {
+			continue
+		}
+		for attr, value := range step.Result.Changes {
+			failed++
+			if value >= 0 {
+				t.Errorf("answer %s failed with %s change %+d, want a penalty", step.Answer.AnswerID, attr, value)
+			}
+		}
+	}
+	if failed == 0 {
+		t.Error("expected seed 42 to fail a test with a penalty")
+	}
+
+	tampered := *c.Transcript()
+	tampered.Attributes.Increase(character.Str, 1)
+	if _, err := data.Replay(&tampered); err == nil {
+		t.Error("Replay: expected error for tampered attributes")
+	}
+
+	tampered = *c.Transcript()
+	tampered.Rolls[0] = 21 - tampered.Rolls[0]
+	if _, err := data.Replay(&tampered); err == nil {
+		t.Error("Replay: expected error for tampered rolls")
+	}
+
+	tampered = *c.Transcript()
+	tampered.DataHash = "other"
+	if _, err := data.Replay(&tampered); err == nil {
+		t.Error("Replay: expected error for other creation data")
+	}
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jrecuero/DandD/internal/character"
+)
+
+func TestParseCost(t *testing.T) {
+	tests := []struct {
+		cost     string
+		expected int
+	}{
+		{"15 gp", 1500},
+		{"2sp", 20},
+		{"1 PP", 1000},
+		{"5 ep", 250},
+		{"7", 7},
+	}
+	for _, tt := range tests {
+		got, err := character.ParseCost(tt.cost)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseCost(%q) = (%d, %v); want %d", tt.cost, got, err, tt.expected)
+		}
+	}
+	if _, err := character.ParseCost("lots of gp"); err == nil {
+		t.Error("ParseCost: expected error for invalid cost")
+	}
+}
+
+func TestWallet_EarnAndTotal(t *testing.T) {
+	var w character.Wallet
+	w.Earn(1234)
+	if w.PP != 1 || w.GP != 2 || w.SP != 3 || w.CP != 4 || w.EP != 0 {
+		t.Errorf("Earn(1234) = %+v", w)
+	}
+	w.Add(character.EP, 2)
+	if got := w.Total(); got != 1334 {
+		t.Errorf("Total() = %d; want 1334", got)
+	}
+	if got := w.String(); got != "1 pp, 2 gp, 2 ep, 3 sp, 4 cp" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestWallet_SpendExact(t *testing.T) {
+	w := character.Wallet{CP: 5, SP: 1}
+	if err := w.Spend(15); err != nil {
+		t.Fatalf("Spend: unexpected error %v", err)
+	}
+	if w.Total() != 0 {
+		t.Errorf("expected empty wallet, got %+v", w)
+	}
+}
+
+func TestWallet_SpendWithChange(t *testing.T) {
+	w := character.Wallet{GP: 2}
+	if err := w.Spend(37); err != nil {
+		t.Fatalf("Spend: unexpected error %v", err)
+	}
+	if w.Total() != 163 {
+		t.Errorf("Total() = %d; want 163", w.Total())
+	}
+	if w.GP != 1 || w.SP != 6 || w.CP != 3 {
+		t.Errorf("expected 1 gp, 6 sp, 3 cp; got %+v", w)
+	}
+}
+
+func TestWallet_SpendInsufficientFunds(t *testing.T) {
+	w := character.Wallet{SP: 3}
+	err := w.Spend(31)
+	if !errors.Is(err, character.ErrInsufficientFunds) {
+		t.Fatalf("Spend: expected ErrInsufficientFunds, got %v", err)
+	}
+	if w.SP != 3 {
+		t.Errorf("wallet changed after failed spend: %+v", w)
+	}
+}
+
+func TestWallet_ExchangeAndConsolidate(t *testing.T) {
+	w := character.Wallet{GP: 3}
+	if err := w.Exchange(character.GP, 2, character.SP); err != nil {
+		t.Fatalf("Exchange: unexpected error %v", err)
+	}
+	if w.GP != 1 || w.SP != 20 {
+		t.Errorf("Exchange: got %+v", w)
+	}
+	if err := w.Exchange(character.SP, 5, character.GP); err == nil {
+		t.Error("Exchange: expected error for inexact conversion")
+	}
+	if err := w.Exchange(character.PP, 1, character.GP); !errors.Is(err, character.ErrInsufficientFunds) {
+		t.Errorf("Exchange: expected ErrInsufficientFunds, got %v", err)
+	}
+	w.Consolidate()
+	if w.GP != 3 || w.SP != 0 {
+		t.Errorf("Consolidate: got %+v", w)
+	}
+}
+
+func TestCharacter_RollStartingGold(t *testing.T) {
+	data := &character.ItemData{StartingGold: map[string]string{"fighter": "5d4x10"}}
+	char := character.NewCharacter("Aragorn", "Fighter", character.NewAttributesMap())
+	gold, err := char.RollStartingGold(data)
+	if err != nil {
+		t.Fatalf("RollStartingGold: unexpected error %v", err)
+	}
+	if gold < 50 || gold > 200 || char.Wallet.GP != gold {
+		t.Errorf("RollStartingGold = %d, wallet %+v", gold, char.Wallet)
+	}
+	char.Job = "Tinker"
+	if _, err := char.RollStartingGold(data); err == nil {
+		t.Error("RollStartingGold: expected error for unknown job")
+	}
+}
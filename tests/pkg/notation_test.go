@@ -0,0 +1,80 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		notation string
+		expected dice.Expression
+		str      string
+	}{
+		{"1d20", dice.Expression{Count: 1, Sides: 20, Multiplier: 1}, "1d20"},
+		{"d6", dice.Expression{Count: 1, Sides: 6, Multiplier: 1}, "1d6"},
+		{"2d6+3", dice.Expression{Count: 2, Sides: 6, Modifier: 3, Multiplier: 1}, "2d6+3"},
+		{"4d8 - 2", dice.Expression{Count: 4, Sides: 8, Modifier: -2, Multiplier: 1}, "4d8-2"},
+		{"5d4x10", dice.Expression{Count: 5, Sides: 4, Multiplier: 10}, "5d4x10"},
+		{"7", dice.Expression{Modifier: 7, Multiplier: 1}, "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			expr, err := dice.Parse(tt.notation)
+			if err != nil {
+				t.Fatalf("Parse(%q): unexpected error %v", tt.notation, err)
+			}
+			if expr != tt.expected {
+				t.Errorf("Parse(%q) = %+v; want %+v", tt.notation, expr, tt.expected)
+			}
+			if got := expr.String(); got != tt.str {
+				t.Errorf("String() = %q; want %q", got, tt.str)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, notation := range []string{"", "d", "0d6", "2d0", "xd6", "2d6+x", "2d6x0", "abc"} {
+		if _, err := dice.Parse(notation); err == nil {
+			t.Errorf("Parse(%q): expected error, got nil", notation)
+		}
+	}
+}
+
+func TestExpression_Range(t *testing.T) {
+	tests := []struct {
+		notation string
+		min      int
+		max      int
+		average  int
+	}{
+		{"1d20", 1, 20, 10},
+		{"2d8+2", 4, 18, 11},
+		{"5d4x10", 50, 200, 120},
+		{"3", 3, 3, 3},
+	}
+	for _, tt := range tests {
+		expr := dice.MustParse(tt.notation)
+		if expr.Min() != tt.min || expr.Max() != tt.max || expr.Average() != tt.average {
+			t.Errorf("%s: got min=%d max=%d avg=%d; want %d %d %d",
+				tt.notation, expr.Min(), expr.Max(), expr.Average(), tt.min, tt.max, tt.average)
+		}
+		for i := 0; i < 50; i++ {
+			if got := expr.Roll(); got < tt.min || got > tt.max {
+				t.Errorf("%s: Roll() = %d; want value between %d and %d", tt.notation, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestRollNotation(t *testing.T) {
+	if _, err := dice.RollNotation("bad"); err == nil {
+		t.Error("RollNotation: expected error for invalid notation")
+	}
+	got, err := dice.RollNotation("1d4+1")
+	if err != nil || got < 2 || got > 5 {
+		t.Errorf("RollNotation(1d4+1) = (%d, %v)", got, err)
+	}
+}
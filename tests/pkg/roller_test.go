@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/jrecuero/DandD/pkg/dice"
+)
+
+func TestRandRoller_Deterministic(t *testing.T) {
+	a := dice.NewRandRoller(42)
+	b := dice.NewRandRoller(42)
+	for i := 0; i < 20; i++ {
+		ra, rb := a.RollDie(20), b.RollDie(20)
+		if ra != rb {
+			t.Fatalf("roll %d: same seed produced %d and %d", i, ra, rb)
+		}
+		if ra < 1 || ra > 20 {
+			t.Errorf("RollDie(20) = %d; want value between 1 and 20", ra)
+		}
+	}
+}
+
+func TestScriptedRoller(t *testing.T) {
+	r := dice.NewScriptedRoller(3, 5, 20)
+	if got := dice.RollWith(r, 2, 6); got != 8 {
+		t.Errorf("RollWith(2d6) = %d; want 8", got)
+	}
+	if r.Remaining() != 1 {
+		t.Errorf("Remaining() = %d; want 1", r.Remaining())
+	}
+	if got := r.RollDie(20); got != 20 {
+		t.Errorf("RollDie(20) = %d; want 20", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when scripted values run out")
+		}
+	}()
+	r.RollDie(20)
+}
+
+func TestExpression_RollWith(t *testing.T) {
+	expr := dice.MustParse("2d6+3")
+	if got := expr.RollWith(dice.NewScriptedRoller(4, 2)); got != 9 {
+		t.Errorf("RollWith = %d; want 9", got)
+	}
+	if got := expr.RollCritical(dice.NewScriptedRoller(4, 2, 6, 1)); got != 16 {
+		t.Errorf("RollCritical = %d; want 16", got)
+	}
+}